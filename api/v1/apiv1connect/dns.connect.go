@@ -23,10 +23,82 @@ const _ = connect_go.IsAtLeastVersion0_1_0
 const (
 	// TokenServiceName is the fully-qualified name of the TokenService service.
 	TokenServiceName = "api.v1.TokenService"
+	// AuthzServiceName is the fully-qualified name of the AuthzService service.
+	AuthzServiceName = "api.v1.AuthzService"
+	// AuditServiceName is the fully-qualified name of the AuditService service.
+	AuditServiceName = "api.v1.AuditService"
 	// DomainServiceName is the fully-qualified name of the DomainService service.
 	DomainServiceName = "api.v1.DomainService"
 	// RecordServiceName is the fully-qualified name of the RecordService service.
 	RecordServiceName = "api.v1.RecordService"
+	// ChallengeServiceName is the fully-qualified name of the ChallengeService service.
+	ChallengeServiceName = "api.v1.ChallengeService"
+	// WebhookServiceName is the fully-qualified name of the WebhookService service.
+	WebhookServiceName = "api.v1.WebhookService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TokenServiceCreateProcedure is the fully-qualified name of the TokenService's Create RPC.
+	TokenServiceCreateProcedure = "/api.v1.TokenService/Create"
+	// AuthzServiceExplainProcedure is the fully-qualified name of the AuthzService's Explain RPC.
+	AuthzServiceExplainProcedure = "/api.v1.AuthzService/Explain"
+	// AuditServiceListProcedure is the fully-qualified name of the AuditService's List RPC.
+	AuditServiceListProcedure = "/api.v1.AuditService/List"
+	// DomainServiceListProcedure is the fully-qualified name of the DomainService's List RPC.
+	DomainServiceListProcedure = "/api.v1.DomainService/List"
+	// DomainServiceGetProcedure is the fully-qualified name of the DomainService's Get RPC.
+	DomainServiceGetProcedure = "/api.v1.DomainService/Get"
+	// DomainServiceCreateProcedure is the fully-qualified name of the DomainService's Create RPC.
+	DomainServiceCreateProcedure = "/api.v1.DomainService/Create"
+	// DomainServiceUpdateProcedure is the fully-qualified name of the DomainService's Update RPC.
+	DomainServiceUpdateProcedure = "/api.v1.DomainService/Update"
+	// DomainServiceDeleteProcedure is the fully-qualified name of the DomainService's Delete RPC.
+	DomainServiceDeleteProcedure = "/api.v1.DomainService/Delete"
+	// DomainServiceListRevisionsProcedure is the fully-qualified name of the DomainService's
+	// ListRevisions RPC.
+	DomainServiceListRevisionsProcedure = "/api.v1.DomainService/ListRevisions"
+	// DomainServiceDiffRevisionsProcedure is the fully-qualified name of the DomainService's
+	// DiffRevisions RPC.
+	DomainServiceDiffRevisionsProcedure = "/api.v1.DomainService/DiffRevisions"
+	// DomainServiceRollbackProcedure is the fully-qualified name of the DomainService's Rollback RPC.
+	DomainServiceRollbackProcedure = "/api.v1.DomainService/Rollback"
+	// DomainServiceWatchProcedure is the fully-qualified name of the DomainService's Watch RPC.
+	DomainServiceWatchProcedure = "/api.v1.DomainService/Watch"
+	// RecordServiceListProcedure is the fully-qualified name of the RecordService's List RPC.
+	RecordServiceListProcedure = "/api.v1.RecordService/List"
+	// RecordServiceDeleteProcedure is the fully-qualified name of the RecordService's Delete RPC.
+	RecordServiceDeleteProcedure = "/api.v1.RecordService/Delete"
+	// RecordServiceUpdateProcedure is the fully-qualified name of the RecordService's Update RPC.
+	RecordServiceUpdateProcedure = "/api.v1.RecordService/Update"
+	// RecordServiceCreateProcedure is the fully-qualified name of the RecordService's Create RPC.
+	RecordServiceCreateProcedure = "/api.v1.RecordService/Create"
+	// RecordServiceVerifyProcedure is the fully-qualified name of the RecordService's Verify RPC.
+	RecordServiceVerifyProcedure = "/api.v1.RecordService/Verify"
+	// RecordServiceWatchProcedure is the fully-qualified name of the RecordService's Watch RPC.
+	RecordServiceWatchProcedure = "/api.v1.RecordService/Watch"
+	// ChallengeServicePresentProcedure is the fully-qualified name of the ChallengeService's Present
+	// RPC.
+	ChallengeServicePresentProcedure = "/api.v1.ChallengeService/Present"
+	// ChallengeServiceCleanUpProcedure is the fully-qualified name of the ChallengeService's CleanUp
+	// RPC.
+	ChallengeServiceCleanUpProcedure = "/api.v1.ChallengeService/CleanUp"
+	// WebhookServiceCreateProcedure is the fully-qualified name of the WebhookService's Create RPC.
+	WebhookServiceCreateProcedure = "/api.v1.WebhookService/Create"
+	// WebhookServiceGetProcedure is the fully-qualified name of the WebhookService's Get RPC.
+	WebhookServiceGetProcedure = "/api.v1.WebhookService/Get"
+	// WebhookServiceListProcedure is the fully-qualified name of the WebhookService's List RPC.
+	WebhookServiceListProcedure = "/api.v1.WebhookService/List"
+	// WebhookServiceUpdateProcedure is the fully-qualified name of the WebhookService's Update RPC.
+	WebhookServiceUpdateProcedure = "/api.v1.WebhookService/Update"
+	// WebhookServiceDeleteProcedure is the fully-qualified name of the WebhookService's Delete RPC.
+	WebhookServiceDeleteProcedure = "/api.v1.WebhookService/Delete"
 )
 
 // TokenServiceClient is a client for the api.v1.TokenService service.
@@ -46,7 +118,7 @@ func NewTokenServiceClient(httpClient connect_go.HTTPClient, baseURL string, opt
 	return &tokenServiceClient{
 		create: connect_go.NewClient[v1.TokenServiceCreateRequest, v1.TokenServiceCreateResponse](
 			httpClient,
-			baseURL+"/api.v1.TokenService/Create",
+			baseURL+TokenServiceCreateProcedure,
 			opts...,
 		),
 	}
@@ -73,13 +145,19 @@ type TokenServiceHandler interface {
 // By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
 // and JSON codecs. They also support gzip compression.
 func NewTokenServiceHandler(svc TokenServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
-	mux := http.NewServeMux()
-	mux.Handle("/api.v1.TokenService/Create", connect_go.NewUnaryHandler(
-		"/api.v1.TokenService/Create",
+	tokenServiceCreateHandler := connect_go.NewUnaryHandler(
+		TokenServiceCreateProcedure,
 		svc.Create,
 		opts...,
-	))
-	return "/api.v1.TokenService/", mux
+	)
+	return "/api.v1.TokenService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TokenServiceCreateProcedure:
+			tokenServiceCreateHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
 }
 
 // UnimplementedTokenServiceHandler returns CodeUnimplemented from all methods.
@@ -89,6 +167,138 @@ func (UnimplementedTokenServiceHandler) Create(context.Context, *connect_go.Requ
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.TokenService.Create is not implemented"))
 }
 
+// AuthzServiceClient is a client for the api.v1.AuthzService service.
+type AuthzServiceClient interface {
+	Explain(context.Context, *connect_go.Request[v1.AuthzServiceExplainRequest]) (*connect_go.Response[v1.AuthzServiceExplainResponse], error)
+}
+
+// NewAuthzServiceClient constructs a client for the api.v1.AuthzService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuthzServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AuthzServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &authzServiceClient{
+		explain: connect_go.NewClient[v1.AuthzServiceExplainRequest, v1.AuthzServiceExplainResponse](
+			httpClient,
+			baseURL+AuthzServiceExplainProcedure,
+			opts...,
+		),
+	}
+}
+
+// authzServiceClient implements AuthzServiceClient.
+type authzServiceClient struct {
+	explain *connect_go.Client[v1.AuthzServiceExplainRequest, v1.AuthzServiceExplainResponse]
+}
+
+// Explain calls api.v1.AuthzService.Explain.
+func (c *authzServiceClient) Explain(ctx context.Context, req *connect_go.Request[v1.AuthzServiceExplainRequest]) (*connect_go.Response[v1.AuthzServiceExplainResponse], error) {
+	return c.explain.CallUnary(ctx, req)
+}
+
+// AuthzServiceHandler is an implementation of the api.v1.AuthzService service.
+type AuthzServiceHandler interface {
+	Explain(context.Context, *connect_go.Request[v1.AuthzServiceExplainRequest]) (*connect_go.Response[v1.AuthzServiceExplainResponse], error)
+}
+
+// NewAuthzServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuthzServiceHandler(svc AuthzServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	authzServiceExplainHandler := connect_go.NewUnaryHandler(
+		AuthzServiceExplainProcedure,
+		svc.Explain,
+		opts...,
+	)
+	return "/api.v1.AuthzService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuthzServiceExplainProcedure:
+			authzServiceExplainHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuthzServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuthzServiceHandler struct{}
+
+func (UnimplementedAuthzServiceHandler) Explain(context.Context, *connect_go.Request[v1.AuthzServiceExplainRequest]) (*connect_go.Response[v1.AuthzServiceExplainResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.AuthzService.Explain is not implemented"))
+}
+
+// AuditServiceClient is a client for the api.v1.AuditService service.
+type AuditServiceClient interface {
+	List(context.Context, *connect_go.Request[v1.AuditServiceListRequest]) (*connect_go.Response[v1.AuditServiceListResponse], error)
+}
+
+// NewAuditServiceClient constructs a client for the api.v1.AuditService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAuditServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) AuditServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &auditServiceClient{
+		list: connect_go.NewClient[v1.AuditServiceListRequest, v1.AuditServiceListResponse](
+			httpClient,
+			baseURL+AuditServiceListProcedure,
+			opts...,
+		),
+	}
+}
+
+// auditServiceClient implements AuditServiceClient.
+type auditServiceClient struct {
+	list *connect_go.Client[v1.AuditServiceListRequest, v1.AuditServiceListResponse]
+}
+
+// List calls api.v1.AuditService.List.
+func (c *auditServiceClient) List(ctx context.Context, req *connect_go.Request[v1.AuditServiceListRequest]) (*connect_go.Response[v1.AuditServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// AuditServiceHandler is an implementation of the api.v1.AuditService service.
+type AuditServiceHandler interface {
+	List(context.Context, *connect_go.Request[v1.AuditServiceListRequest]) (*connect_go.Response[v1.AuditServiceListResponse], error)
+}
+
+// NewAuditServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAuditServiceHandler(svc AuditServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	auditServiceListHandler := connect_go.NewUnaryHandler(
+		AuditServiceListProcedure,
+		svc.List,
+		opts...,
+	)
+	return "/api.v1.AuditService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AuditServiceListProcedure:
+			auditServiceListHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAuditServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAuditServiceHandler struct{}
+
+func (UnimplementedAuditServiceHandler) List(context.Context, *connect_go.Request[v1.AuditServiceListRequest]) (*connect_go.Response[v1.AuditServiceListResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.AuditService.List is not implemented"))
+}
+
 // DomainServiceClient is a client for the api.v1.DomainService service.
 type DomainServiceClient interface {
 	List(context.Context, *connect_go.Request[v1.DomainServiceListRequest]) (*connect_go.Response[v1.DomainServiceListResponse], error)
@@ -96,6 +306,10 @@ type DomainServiceClient interface {
 	Create(context.Context, *connect_go.Request[v1.DomainServiceCreateRequest]) (*connect_go.Response[v1.DomainServiceCreateResponse], error)
 	Update(context.Context, *connect_go.Request[v1.DomainServiceUpdateRequest]) (*connect_go.Response[v1.DomainServiceUpdateResponse], error)
 	Delete(context.Context, *connect_go.Request[v1.DomainServiceDeleteRequest]) (*connect_go.Response[v1.DomainServiceDeleteResponse], error)
+	ListRevisions(context.Context, *connect_go.Request[v1.DomainServiceListRevisionsRequest]) (*connect_go.Response[v1.DomainServiceListRevisionsResponse], error)
+	DiffRevisions(context.Context, *connect_go.Request[v1.DomainServiceDiffRevisionsRequest]) (*connect_go.Response[v1.DomainServiceDiffRevisionsResponse], error)
+	Rollback(context.Context, *connect_go.Request[v1.DomainServiceRollbackRequest]) (*connect_go.Response[v1.DomainServiceRollbackResponse], error)
+	Watch(context.Context, *connect_go.Request[v1.DomainServiceWatchRequest]) (*connect_go.ServerStreamForClient[v1.DomainServiceWatchResponse], error)
 }
 
 // NewDomainServiceClient constructs a client for the api.v1.DomainService service. By default, it
@@ -110,27 +324,47 @@ func NewDomainServiceClient(httpClient connect_go.HTTPClient, baseURL string, op
 	return &domainServiceClient{
 		list: connect_go.NewClient[v1.DomainServiceListRequest, v1.DomainServiceListResponse](
 			httpClient,
-			baseURL+"/api.v1.DomainService/List",
+			baseURL+DomainServiceListProcedure,
 			opts...,
 		),
 		get: connect_go.NewClient[v1.DomainServiceGetRequest, v1.DomainServiceGetResponse](
 			httpClient,
-			baseURL+"/api.v1.DomainService/Get",
+			baseURL+DomainServiceGetProcedure,
 			opts...,
 		),
 		create: connect_go.NewClient[v1.DomainServiceCreateRequest, v1.DomainServiceCreateResponse](
 			httpClient,
-			baseURL+"/api.v1.DomainService/Create",
+			baseURL+DomainServiceCreateProcedure,
 			opts...,
 		),
 		update: connect_go.NewClient[v1.DomainServiceUpdateRequest, v1.DomainServiceUpdateResponse](
 			httpClient,
-			baseURL+"/api.v1.DomainService/Update",
+			baseURL+DomainServiceUpdateProcedure,
 			opts...,
 		),
 		delete: connect_go.NewClient[v1.DomainServiceDeleteRequest, v1.DomainServiceDeleteResponse](
 			httpClient,
-			baseURL+"/api.v1.DomainService/Delete",
+			baseURL+DomainServiceDeleteProcedure,
+			opts...,
+		),
+		listRevisions: connect_go.NewClient[v1.DomainServiceListRevisionsRequest, v1.DomainServiceListRevisionsResponse](
+			httpClient,
+			baseURL+DomainServiceListRevisionsProcedure,
+			opts...,
+		),
+		diffRevisions: connect_go.NewClient[v1.DomainServiceDiffRevisionsRequest, v1.DomainServiceDiffRevisionsResponse](
+			httpClient,
+			baseURL+DomainServiceDiffRevisionsProcedure,
+			opts...,
+		),
+		rollback: connect_go.NewClient[v1.DomainServiceRollbackRequest, v1.DomainServiceRollbackResponse](
+			httpClient,
+			baseURL+DomainServiceRollbackProcedure,
+			opts...,
+		),
+		watch: connect_go.NewClient[v1.DomainServiceWatchRequest, v1.DomainServiceWatchResponse](
+			httpClient,
+			baseURL+DomainServiceWatchProcedure,
 			opts...,
 		),
 	}
@@ -138,11 +372,15 @@ func NewDomainServiceClient(httpClient connect_go.HTTPClient, baseURL string, op
 
 // domainServiceClient implements DomainServiceClient.
 type domainServiceClient struct {
-	list   *connect_go.Client[v1.DomainServiceListRequest, v1.DomainServiceListResponse]
-	get    *connect_go.Client[v1.DomainServiceGetRequest, v1.DomainServiceGetResponse]
-	create *connect_go.Client[v1.DomainServiceCreateRequest, v1.DomainServiceCreateResponse]
-	update *connect_go.Client[v1.DomainServiceUpdateRequest, v1.DomainServiceUpdateResponse]
-	delete *connect_go.Client[v1.DomainServiceDeleteRequest, v1.DomainServiceDeleteResponse]
+	list          *connect_go.Client[v1.DomainServiceListRequest, v1.DomainServiceListResponse]
+	get           *connect_go.Client[v1.DomainServiceGetRequest, v1.DomainServiceGetResponse]
+	create        *connect_go.Client[v1.DomainServiceCreateRequest, v1.DomainServiceCreateResponse]
+	update        *connect_go.Client[v1.DomainServiceUpdateRequest, v1.DomainServiceUpdateResponse]
+	delete        *connect_go.Client[v1.DomainServiceDeleteRequest, v1.DomainServiceDeleteResponse]
+	listRevisions *connect_go.Client[v1.DomainServiceListRevisionsRequest, v1.DomainServiceListRevisionsResponse]
+	diffRevisions *connect_go.Client[v1.DomainServiceDiffRevisionsRequest, v1.DomainServiceDiffRevisionsResponse]
+	rollback      *connect_go.Client[v1.DomainServiceRollbackRequest, v1.DomainServiceRollbackResponse]
+	watch         *connect_go.Client[v1.DomainServiceWatchRequest, v1.DomainServiceWatchResponse]
 }
 
 // List calls api.v1.DomainService.List.
@@ -170,6 +408,26 @@ func (c *domainServiceClient) Delete(ctx context.Context, req *connect_go.Reques
 	return c.delete.CallUnary(ctx, req)
 }
 
+// ListRevisions calls api.v1.DomainService.ListRevisions.
+func (c *domainServiceClient) ListRevisions(ctx context.Context, req *connect_go.Request[v1.DomainServiceListRevisionsRequest]) (*connect_go.Response[v1.DomainServiceListRevisionsResponse], error) {
+	return c.listRevisions.CallUnary(ctx, req)
+}
+
+// DiffRevisions calls api.v1.DomainService.DiffRevisions.
+func (c *domainServiceClient) DiffRevisions(ctx context.Context, req *connect_go.Request[v1.DomainServiceDiffRevisionsRequest]) (*connect_go.Response[v1.DomainServiceDiffRevisionsResponse], error) {
+	return c.diffRevisions.CallUnary(ctx, req)
+}
+
+// Rollback calls api.v1.DomainService.Rollback.
+func (c *domainServiceClient) Rollback(ctx context.Context, req *connect_go.Request[v1.DomainServiceRollbackRequest]) (*connect_go.Response[v1.DomainServiceRollbackResponse], error) {
+	return c.rollback.CallUnary(ctx, req)
+}
+
+// Watch calls api.v1.DomainService.Watch.
+func (c *domainServiceClient) Watch(ctx context.Context, req *connect_go.Request[v1.DomainServiceWatchRequest]) (*connect_go.ServerStreamForClient[v1.DomainServiceWatchResponse], error) {
+	return c.watch.CallServerStream(ctx, req)
+}
+
 // DomainServiceHandler is an implementation of the api.v1.DomainService service.
 type DomainServiceHandler interface {
 	List(context.Context, *connect_go.Request[v1.DomainServiceListRequest]) (*connect_go.Response[v1.DomainServiceListResponse], error)
@@ -177,6 +435,10 @@ type DomainServiceHandler interface {
 	Create(context.Context, *connect_go.Request[v1.DomainServiceCreateRequest]) (*connect_go.Response[v1.DomainServiceCreateResponse], error)
 	Update(context.Context, *connect_go.Request[v1.DomainServiceUpdateRequest]) (*connect_go.Response[v1.DomainServiceUpdateResponse], error)
 	Delete(context.Context, *connect_go.Request[v1.DomainServiceDeleteRequest]) (*connect_go.Response[v1.DomainServiceDeleteResponse], error)
+	ListRevisions(context.Context, *connect_go.Request[v1.DomainServiceListRevisionsRequest]) (*connect_go.Response[v1.DomainServiceListRevisionsResponse], error)
+	DiffRevisions(context.Context, *connect_go.Request[v1.DomainServiceDiffRevisionsRequest]) (*connect_go.Response[v1.DomainServiceDiffRevisionsResponse], error)
+	Rollback(context.Context, *connect_go.Request[v1.DomainServiceRollbackRequest]) (*connect_go.Response[v1.DomainServiceRollbackResponse], error)
+	Watch(context.Context, *connect_go.Request[v1.DomainServiceWatchRequest], *connect_go.ServerStream[v1.DomainServiceWatchResponse]) error
 }
 
 // NewDomainServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -185,33 +447,75 @@ type DomainServiceHandler interface {
 // By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
 // and JSON codecs. They also support gzip compression.
 func NewDomainServiceHandler(svc DomainServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
-	mux := http.NewServeMux()
-	mux.Handle("/api.v1.DomainService/List", connect_go.NewUnaryHandler(
-		"/api.v1.DomainService/List",
+	domainServiceListHandler := connect_go.NewUnaryHandler(
+		DomainServiceListProcedure,
 		svc.List,
 		opts...,
-	))
-	mux.Handle("/api.v1.DomainService/Get", connect_go.NewUnaryHandler(
-		"/api.v1.DomainService/Get",
+	)
+	domainServiceGetHandler := connect_go.NewUnaryHandler(
+		DomainServiceGetProcedure,
 		svc.Get,
 		opts...,
-	))
-	mux.Handle("/api.v1.DomainService/Create", connect_go.NewUnaryHandler(
-		"/api.v1.DomainService/Create",
+	)
+	domainServiceCreateHandler := connect_go.NewUnaryHandler(
+		DomainServiceCreateProcedure,
 		svc.Create,
 		opts...,
-	))
-	mux.Handle("/api.v1.DomainService/Update", connect_go.NewUnaryHandler(
-		"/api.v1.DomainService/Update",
+	)
+	domainServiceUpdateHandler := connect_go.NewUnaryHandler(
+		DomainServiceUpdateProcedure,
 		svc.Update,
 		opts...,
-	))
-	mux.Handle("/api.v1.DomainService/Delete", connect_go.NewUnaryHandler(
-		"/api.v1.DomainService/Delete",
+	)
+	domainServiceDeleteHandler := connect_go.NewUnaryHandler(
+		DomainServiceDeleteProcedure,
 		svc.Delete,
 		opts...,
-	))
-	return "/api.v1.DomainService/", mux
+	)
+	domainServiceListRevisionsHandler := connect_go.NewUnaryHandler(
+		DomainServiceListRevisionsProcedure,
+		svc.ListRevisions,
+		opts...,
+	)
+	domainServiceDiffRevisionsHandler := connect_go.NewUnaryHandler(
+		DomainServiceDiffRevisionsProcedure,
+		svc.DiffRevisions,
+		opts...,
+	)
+	domainServiceRollbackHandler := connect_go.NewUnaryHandler(
+		DomainServiceRollbackProcedure,
+		svc.Rollback,
+		opts...,
+	)
+	domainServiceWatchHandler := connect_go.NewServerStreamHandler(
+		DomainServiceWatchProcedure,
+		svc.Watch,
+		opts...,
+	)
+	return "/api.v1.DomainService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DomainServiceListProcedure:
+			domainServiceListHandler.ServeHTTP(w, r)
+		case DomainServiceGetProcedure:
+			domainServiceGetHandler.ServeHTTP(w, r)
+		case DomainServiceCreateProcedure:
+			domainServiceCreateHandler.ServeHTTP(w, r)
+		case DomainServiceUpdateProcedure:
+			domainServiceUpdateHandler.ServeHTTP(w, r)
+		case DomainServiceDeleteProcedure:
+			domainServiceDeleteHandler.ServeHTTP(w, r)
+		case DomainServiceListRevisionsProcedure:
+			domainServiceListRevisionsHandler.ServeHTTP(w, r)
+		case DomainServiceDiffRevisionsProcedure:
+			domainServiceDiffRevisionsHandler.ServeHTTP(w, r)
+		case DomainServiceRollbackProcedure:
+			domainServiceRollbackHandler.ServeHTTP(w, r)
+		case DomainServiceWatchProcedure:
+			domainServiceWatchHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
 }
 
 // UnimplementedDomainServiceHandler returns CodeUnimplemented from all methods.
@@ -237,12 +541,30 @@ func (UnimplementedDomainServiceHandler) Delete(context.Context, *connect_go.Req
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.DomainService.Delete is not implemented"))
 }
 
+func (UnimplementedDomainServiceHandler) ListRevisions(context.Context, *connect_go.Request[v1.DomainServiceListRevisionsRequest]) (*connect_go.Response[v1.DomainServiceListRevisionsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.DomainService.ListRevisions is not implemented"))
+}
+
+func (UnimplementedDomainServiceHandler) DiffRevisions(context.Context, *connect_go.Request[v1.DomainServiceDiffRevisionsRequest]) (*connect_go.Response[v1.DomainServiceDiffRevisionsResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.DomainService.DiffRevisions is not implemented"))
+}
+
+func (UnimplementedDomainServiceHandler) Rollback(context.Context, *connect_go.Request[v1.DomainServiceRollbackRequest]) (*connect_go.Response[v1.DomainServiceRollbackResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.DomainService.Rollback is not implemented"))
+}
+
+func (UnimplementedDomainServiceHandler) Watch(context.Context, *connect_go.Request[v1.DomainServiceWatchRequest], *connect_go.ServerStream[v1.DomainServiceWatchResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.DomainService.Watch is not implemented"))
+}
+
 // RecordServiceClient is a client for the api.v1.RecordService service.
 type RecordServiceClient interface {
 	List(context.Context, *connect_go.Request[v1.RecordServiceListRequest]) (*connect_go.Response[v1.RecordServiceListResponse], error)
 	Delete(context.Context, *connect_go.Request[v1.RecordServiceDeleteRequest]) (*connect_go.Response[v1.RecordServiceDeleteResponse], error)
 	Update(context.Context, *connect_go.Request[v1.RecordServiceUpdateRequest]) (*connect_go.Response[v1.RecordServiceUpdateResponse], error)
 	Create(context.Context, *connect_go.Request[v1.RecordServiceCreateRequest]) (*connect_go.Response[v1.RecordServiceCreateResponse], error)
+	Verify(context.Context, *connect_go.Request[v1.RecordServiceVerifyRequest]) (*connect_go.Response[v1.RecordServiceVerifyResponse], error)
+	Watch(context.Context, *connect_go.Request[v1.RecordServiceWatchRequest]) (*connect_go.ServerStreamForClient[v1.RecordServiceWatchResponse], error)
 }
 
 // NewRecordServiceClient constructs a client for the api.v1.RecordService service. By default, it
@@ -257,22 +579,32 @@ func NewRecordServiceClient(httpClient connect_go.HTTPClient, baseURL string, op
 	return &recordServiceClient{
 		list: connect_go.NewClient[v1.RecordServiceListRequest, v1.RecordServiceListResponse](
 			httpClient,
-			baseURL+"/api.v1.RecordService/List",
+			baseURL+RecordServiceListProcedure,
 			opts...,
 		),
 		delete: connect_go.NewClient[v1.RecordServiceDeleteRequest, v1.RecordServiceDeleteResponse](
 			httpClient,
-			baseURL+"/api.v1.RecordService/Delete",
+			baseURL+RecordServiceDeleteProcedure,
 			opts...,
 		),
 		update: connect_go.NewClient[v1.RecordServiceUpdateRequest, v1.RecordServiceUpdateResponse](
 			httpClient,
-			baseURL+"/api.v1.RecordService/Update",
+			baseURL+RecordServiceUpdateProcedure,
 			opts...,
 		),
 		create: connect_go.NewClient[v1.RecordServiceCreateRequest, v1.RecordServiceCreateResponse](
 			httpClient,
-			baseURL+"/api.v1.RecordService/Create",
+			baseURL+RecordServiceCreateProcedure,
+			opts...,
+		),
+		verify: connect_go.NewClient[v1.RecordServiceVerifyRequest, v1.RecordServiceVerifyResponse](
+			httpClient,
+			baseURL+RecordServiceVerifyProcedure,
+			opts...,
+		),
+		watch: connect_go.NewClient[v1.RecordServiceWatchRequest, v1.RecordServiceWatchResponse](
+			httpClient,
+			baseURL+RecordServiceWatchProcedure,
 			opts...,
 		),
 	}
@@ -284,6 +616,8 @@ type recordServiceClient struct {
 	delete *connect_go.Client[v1.RecordServiceDeleteRequest, v1.RecordServiceDeleteResponse]
 	update *connect_go.Client[v1.RecordServiceUpdateRequest, v1.RecordServiceUpdateResponse]
 	create *connect_go.Client[v1.RecordServiceCreateRequest, v1.RecordServiceCreateResponse]
+	verify *connect_go.Client[v1.RecordServiceVerifyRequest, v1.RecordServiceVerifyResponse]
+	watch  *connect_go.Client[v1.RecordServiceWatchRequest, v1.RecordServiceWatchResponse]
 }
 
 // List calls api.v1.RecordService.List.
@@ -306,12 +640,24 @@ func (c *recordServiceClient) Create(ctx context.Context, req *connect_go.Reques
 	return c.create.CallUnary(ctx, req)
 }
 
+// Verify calls api.v1.RecordService.Verify.
+func (c *recordServiceClient) Verify(ctx context.Context, req *connect_go.Request[v1.RecordServiceVerifyRequest]) (*connect_go.Response[v1.RecordServiceVerifyResponse], error) {
+	return c.verify.CallUnary(ctx, req)
+}
+
+// Watch calls api.v1.RecordService.Watch.
+func (c *recordServiceClient) Watch(ctx context.Context, req *connect_go.Request[v1.RecordServiceWatchRequest]) (*connect_go.ServerStreamForClient[v1.RecordServiceWatchResponse], error) {
+	return c.watch.CallServerStream(ctx, req)
+}
+
 // RecordServiceHandler is an implementation of the api.v1.RecordService service.
 type RecordServiceHandler interface {
 	List(context.Context, *connect_go.Request[v1.RecordServiceListRequest]) (*connect_go.Response[v1.RecordServiceListResponse], error)
 	Delete(context.Context, *connect_go.Request[v1.RecordServiceDeleteRequest]) (*connect_go.Response[v1.RecordServiceDeleteResponse], error)
 	Update(context.Context, *connect_go.Request[v1.RecordServiceUpdateRequest]) (*connect_go.Response[v1.RecordServiceUpdateResponse], error)
 	Create(context.Context, *connect_go.Request[v1.RecordServiceCreateRequest]) (*connect_go.Response[v1.RecordServiceCreateResponse], error)
+	Verify(context.Context, *connect_go.Request[v1.RecordServiceVerifyRequest]) (*connect_go.Response[v1.RecordServiceVerifyResponse], error)
+	Watch(context.Context, *connect_go.Request[v1.RecordServiceWatchRequest], *connect_go.ServerStream[v1.RecordServiceWatchResponse]) error
 }
 
 // NewRecordServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -320,28 +666,54 @@ type RecordServiceHandler interface {
 // By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
 // and JSON codecs. They also support gzip compression.
 func NewRecordServiceHandler(svc RecordServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
-	mux := http.NewServeMux()
-	mux.Handle("/api.v1.RecordService/List", connect_go.NewUnaryHandler(
-		"/api.v1.RecordService/List",
+	recordServiceListHandler := connect_go.NewUnaryHandler(
+		RecordServiceListProcedure,
 		svc.List,
 		opts...,
-	))
-	mux.Handle("/api.v1.RecordService/Delete", connect_go.NewUnaryHandler(
-		"/api.v1.RecordService/Delete",
+	)
+	recordServiceDeleteHandler := connect_go.NewUnaryHandler(
+		RecordServiceDeleteProcedure,
 		svc.Delete,
 		opts...,
-	))
-	mux.Handle("/api.v1.RecordService/Update", connect_go.NewUnaryHandler(
-		"/api.v1.RecordService/Update",
+	)
+	recordServiceUpdateHandler := connect_go.NewUnaryHandler(
+		RecordServiceUpdateProcedure,
 		svc.Update,
 		opts...,
-	))
-	mux.Handle("/api.v1.RecordService/Create", connect_go.NewUnaryHandler(
-		"/api.v1.RecordService/Create",
+	)
+	recordServiceCreateHandler := connect_go.NewUnaryHandler(
+		RecordServiceCreateProcedure,
 		svc.Create,
 		opts...,
-	))
-	return "/api.v1.RecordService/", mux
+	)
+	recordServiceVerifyHandler := connect_go.NewUnaryHandler(
+		RecordServiceVerifyProcedure,
+		svc.Verify,
+		opts...,
+	)
+	recordServiceWatchHandler := connect_go.NewServerStreamHandler(
+		RecordServiceWatchProcedure,
+		svc.Watch,
+		opts...,
+	)
+	return "/api.v1.RecordService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RecordServiceListProcedure:
+			recordServiceListHandler.ServeHTTP(w, r)
+		case RecordServiceDeleteProcedure:
+			recordServiceDeleteHandler.ServeHTTP(w, r)
+		case RecordServiceUpdateProcedure:
+			recordServiceUpdateHandler.ServeHTTP(w, r)
+		case RecordServiceCreateProcedure:
+			recordServiceCreateHandler.ServeHTTP(w, r)
+		case RecordServiceVerifyProcedure:
+			recordServiceVerifyHandler.ServeHTTP(w, r)
+		case RecordServiceWatchProcedure:
+			recordServiceWatchHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
 }
 
 // UnimplementedRecordServiceHandler returns CodeUnimplemented from all methods.
@@ -362,3 +734,263 @@ func (UnimplementedRecordServiceHandler) Update(context.Context, *connect_go.Req
 func (UnimplementedRecordServiceHandler) Create(context.Context, *connect_go.Request[v1.RecordServiceCreateRequest]) (*connect_go.Response[v1.RecordServiceCreateResponse], error) {
 	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.RecordService.Create is not implemented"))
 }
+
+func (UnimplementedRecordServiceHandler) Verify(context.Context, *connect_go.Request[v1.RecordServiceVerifyRequest]) (*connect_go.Response[v1.RecordServiceVerifyResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.RecordService.Verify is not implemented"))
+}
+
+func (UnimplementedRecordServiceHandler) Watch(context.Context, *connect_go.Request[v1.RecordServiceWatchRequest], *connect_go.ServerStream[v1.RecordServiceWatchResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.RecordService.Watch is not implemented"))
+}
+
+// ChallengeServiceClient is a client for the api.v1.ChallengeService service.
+type ChallengeServiceClient interface {
+	Present(context.Context, *connect_go.Request[v1.ChallengeServicePresentRequest]) (*connect_go.Response[v1.ChallengeServicePresentResponse], error)
+	CleanUp(context.Context, *connect_go.Request[v1.ChallengeServiceCleanUpRequest]) (*connect_go.Response[v1.ChallengeServiceCleanUpResponse], error)
+}
+
+// NewChallengeServiceClient constructs a client for the api.v1.ChallengeService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewChallengeServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) ChallengeServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &challengeServiceClient{
+		present: connect_go.NewClient[v1.ChallengeServicePresentRequest, v1.ChallengeServicePresentResponse](
+			httpClient,
+			baseURL+ChallengeServicePresentProcedure,
+			opts...,
+		),
+		cleanUp: connect_go.NewClient[v1.ChallengeServiceCleanUpRequest, v1.ChallengeServiceCleanUpResponse](
+			httpClient,
+			baseURL+ChallengeServiceCleanUpProcedure,
+			opts...,
+		),
+	}
+}
+
+// challengeServiceClient implements ChallengeServiceClient.
+type challengeServiceClient struct {
+	present *connect_go.Client[v1.ChallengeServicePresentRequest, v1.ChallengeServicePresentResponse]
+	cleanUp *connect_go.Client[v1.ChallengeServiceCleanUpRequest, v1.ChallengeServiceCleanUpResponse]
+}
+
+// Present calls api.v1.ChallengeService.Present.
+func (c *challengeServiceClient) Present(ctx context.Context, req *connect_go.Request[v1.ChallengeServicePresentRequest]) (*connect_go.Response[v1.ChallengeServicePresentResponse], error) {
+	return c.present.CallUnary(ctx, req)
+}
+
+// CleanUp calls api.v1.ChallengeService.CleanUp.
+func (c *challengeServiceClient) CleanUp(ctx context.Context, req *connect_go.Request[v1.ChallengeServiceCleanUpRequest]) (*connect_go.Response[v1.ChallengeServiceCleanUpResponse], error) {
+	return c.cleanUp.CallUnary(ctx, req)
+}
+
+// ChallengeServiceHandler is an implementation of the api.v1.ChallengeService service.
+type ChallengeServiceHandler interface {
+	Present(context.Context, *connect_go.Request[v1.ChallengeServicePresentRequest]) (*connect_go.Response[v1.ChallengeServicePresentResponse], error)
+	CleanUp(context.Context, *connect_go.Request[v1.ChallengeServiceCleanUpRequest]) (*connect_go.Response[v1.ChallengeServiceCleanUpResponse], error)
+}
+
+// NewChallengeServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewChallengeServiceHandler(svc ChallengeServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	challengeServicePresentHandler := connect_go.NewUnaryHandler(
+		ChallengeServicePresentProcedure,
+		svc.Present,
+		opts...,
+	)
+	challengeServiceCleanUpHandler := connect_go.NewUnaryHandler(
+		ChallengeServiceCleanUpProcedure,
+		svc.CleanUp,
+		opts...,
+	)
+	return "/api.v1.ChallengeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case ChallengeServicePresentProcedure:
+			challengeServicePresentHandler.ServeHTTP(w, r)
+		case ChallengeServiceCleanUpProcedure:
+			challengeServiceCleanUpHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedChallengeServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedChallengeServiceHandler struct{}
+
+func (UnimplementedChallengeServiceHandler) Present(context.Context, *connect_go.Request[v1.ChallengeServicePresentRequest]) (*connect_go.Response[v1.ChallengeServicePresentResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.ChallengeService.Present is not implemented"))
+}
+
+func (UnimplementedChallengeServiceHandler) CleanUp(context.Context, *connect_go.Request[v1.ChallengeServiceCleanUpRequest]) (*connect_go.Response[v1.ChallengeServiceCleanUpResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.ChallengeService.CleanUp is not implemented"))
+}
+
+// WebhookServiceClient is a client for the api.v1.WebhookService service.
+type WebhookServiceClient interface {
+	Create(context.Context, *connect_go.Request[v1.WebhookServiceCreateRequest]) (*connect_go.Response[v1.WebhookServiceCreateResponse], error)
+	Get(context.Context, *connect_go.Request[v1.WebhookServiceGetRequest]) (*connect_go.Response[v1.WebhookServiceGetResponse], error)
+	List(context.Context, *connect_go.Request[v1.WebhookServiceListRequest]) (*connect_go.Response[v1.WebhookServiceListResponse], error)
+	Update(context.Context, *connect_go.Request[v1.WebhookServiceUpdateRequest]) (*connect_go.Response[v1.WebhookServiceUpdateResponse], error)
+	Delete(context.Context, *connect_go.Request[v1.WebhookServiceDeleteRequest]) (*connect_go.Response[v1.WebhookServiceDeleteResponse], error)
+}
+
+// NewWebhookServiceClient constructs a client for the api.v1.WebhookService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewWebhookServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) WebhookServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &webhookServiceClient{
+		create: connect_go.NewClient[v1.WebhookServiceCreateRequest, v1.WebhookServiceCreateResponse](
+			httpClient,
+			baseURL+WebhookServiceCreateProcedure,
+			opts...,
+		),
+		get: connect_go.NewClient[v1.WebhookServiceGetRequest, v1.WebhookServiceGetResponse](
+			httpClient,
+			baseURL+WebhookServiceGetProcedure,
+			opts...,
+		),
+		list: connect_go.NewClient[v1.WebhookServiceListRequest, v1.WebhookServiceListResponse](
+			httpClient,
+			baseURL+WebhookServiceListProcedure,
+			opts...,
+		),
+		update: connect_go.NewClient[v1.WebhookServiceUpdateRequest, v1.WebhookServiceUpdateResponse](
+			httpClient,
+			baseURL+WebhookServiceUpdateProcedure,
+			opts...,
+		),
+		delete: connect_go.NewClient[v1.WebhookServiceDeleteRequest, v1.WebhookServiceDeleteResponse](
+			httpClient,
+			baseURL+WebhookServiceDeleteProcedure,
+			opts...,
+		),
+	}
+}
+
+// webhookServiceClient implements WebhookServiceClient.
+type webhookServiceClient struct {
+	create *connect_go.Client[v1.WebhookServiceCreateRequest, v1.WebhookServiceCreateResponse]
+	get    *connect_go.Client[v1.WebhookServiceGetRequest, v1.WebhookServiceGetResponse]
+	list   *connect_go.Client[v1.WebhookServiceListRequest, v1.WebhookServiceListResponse]
+	update *connect_go.Client[v1.WebhookServiceUpdateRequest, v1.WebhookServiceUpdateResponse]
+	delete *connect_go.Client[v1.WebhookServiceDeleteRequest, v1.WebhookServiceDeleteResponse]
+}
+
+// Create calls api.v1.WebhookService.Create.
+func (c *webhookServiceClient) Create(ctx context.Context, req *connect_go.Request[v1.WebhookServiceCreateRequest]) (*connect_go.Response[v1.WebhookServiceCreateResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Get calls api.v1.WebhookService.Get.
+func (c *webhookServiceClient) Get(ctx context.Context, req *connect_go.Request[v1.WebhookServiceGetRequest]) (*connect_go.Response[v1.WebhookServiceGetResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// List calls api.v1.WebhookService.List.
+func (c *webhookServiceClient) List(ctx context.Context, req *connect_go.Request[v1.WebhookServiceListRequest]) (*connect_go.Response[v1.WebhookServiceListResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// Update calls api.v1.WebhookService.Update.
+func (c *webhookServiceClient) Update(ctx context.Context, req *connect_go.Request[v1.WebhookServiceUpdateRequest]) (*connect_go.Response[v1.WebhookServiceUpdateResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Delete calls api.v1.WebhookService.Delete.
+func (c *webhookServiceClient) Delete(ctx context.Context, req *connect_go.Request[v1.WebhookServiceDeleteRequest]) (*connect_go.Response[v1.WebhookServiceDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// WebhookServiceHandler is an implementation of the api.v1.WebhookService service.
+type WebhookServiceHandler interface {
+	Create(context.Context, *connect_go.Request[v1.WebhookServiceCreateRequest]) (*connect_go.Response[v1.WebhookServiceCreateResponse], error)
+	Get(context.Context, *connect_go.Request[v1.WebhookServiceGetRequest]) (*connect_go.Response[v1.WebhookServiceGetResponse], error)
+	List(context.Context, *connect_go.Request[v1.WebhookServiceListRequest]) (*connect_go.Response[v1.WebhookServiceListResponse], error)
+	Update(context.Context, *connect_go.Request[v1.WebhookServiceUpdateRequest]) (*connect_go.Response[v1.WebhookServiceUpdateResponse], error)
+	Delete(context.Context, *connect_go.Request[v1.WebhookServiceDeleteRequest]) (*connect_go.Response[v1.WebhookServiceDeleteResponse], error)
+}
+
+// NewWebhookServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewWebhookServiceHandler(svc WebhookServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	webhookServiceCreateHandler := connect_go.NewUnaryHandler(
+		WebhookServiceCreateProcedure,
+		svc.Create,
+		opts...,
+	)
+	webhookServiceGetHandler := connect_go.NewUnaryHandler(
+		WebhookServiceGetProcedure,
+		svc.Get,
+		opts...,
+	)
+	webhookServiceListHandler := connect_go.NewUnaryHandler(
+		WebhookServiceListProcedure,
+		svc.List,
+		opts...,
+	)
+	webhookServiceUpdateHandler := connect_go.NewUnaryHandler(
+		WebhookServiceUpdateProcedure,
+		svc.Update,
+		opts...,
+	)
+	webhookServiceDeleteHandler := connect_go.NewUnaryHandler(
+		WebhookServiceDeleteProcedure,
+		svc.Delete,
+		opts...,
+	)
+	return "/api.v1.WebhookService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case WebhookServiceCreateProcedure:
+			webhookServiceCreateHandler.ServeHTTP(w, r)
+		case WebhookServiceGetProcedure:
+			webhookServiceGetHandler.ServeHTTP(w, r)
+		case WebhookServiceListProcedure:
+			webhookServiceListHandler.ServeHTTP(w, r)
+		case WebhookServiceUpdateProcedure:
+			webhookServiceUpdateHandler.ServeHTTP(w, r)
+		case WebhookServiceDeleteProcedure:
+			webhookServiceDeleteHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedWebhookServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedWebhookServiceHandler struct{}
+
+func (UnimplementedWebhookServiceHandler) Create(context.Context, *connect_go.Request[v1.WebhookServiceCreateRequest]) (*connect_go.Response[v1.WebhookServiceCreateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.WebhookService.Create is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) Get(context.Context, *connect_go.Request[v1.WebhookServiceGetRequest]) (*connect_go.Response[v1.WebhookServiceGetResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.WebhookService.Get is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) List(context.Context, *connect_go.Request[v1.WebhookServiceListRequest]) (*connect_go.Response[v1.WebhookServiceListResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.WebhookService.List is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) Update(context.Context, *connect_go.Request[v1.WebhookServiceUpdateRequest]) (*connect_go.Response[v1.WebhookServiceUpdateResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.WebhookService.Update is not implemented"))
+}
+
+func (UnimplementedWebhookServiceHandler) Delete(context.Context, *connect_go.Request[v1.WebhookServiceDeleteRequest]) (*connect_go.Response[v1.WebhookServiceDeleteResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("api.v1.WebhookService.Delete is not implemented"))
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.28.1
+// 	protoc-gen-go v1.31.0
 // 	protoc        (unknown)
 // source: api/v1/dns.proto
 
@@ -10,7 +10,8 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
-	_ "google.golang.org/protobuf/types/known/wrapperspb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -222,6 +223,63 @@ func (RecordType) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{0}
 }
 
+// EventType is the kind of change a watch event reports
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	EventType_EVENT_TYPE_CREATED     EventType = 1
+	EventType_EVENT_TYPE_UPDATED     EventType = 2
+	EventType_EVENT_TYPE_DELETED     EventType = 3
+	// EVENT_TYPE_BOOKMARK is sent when the watch starts, it carries the resume token of the current position without a change
+	EventType_EVENT_TYPE_BOOKMARK EventType = 4
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "EVENT_TYPE_CREATED",
+		2: "EVENT_TYPE_UPDATED",
+		3: "EVENT_TYPE_DELETED",
+		4: "EVENT_TYPE_BOOKMARK",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"EVENT_TYPE_CREATED":     1,
+		"EVENT_TYPE_UPDATED":     2,
+		"EVENT_TYPE_DELETED":     3,
+		"EVENT_TYPE_BOOKMARK":    4,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_dns_proto_enumTypes[1].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_api_v1_dns_proto_enumTypes[1]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{1}
+}
+
 // Tokens
 type TokenServiceCreateRequest struct {
 	state         protoimpl.MessageState
@@ -341,21 +399,19 @@ func (x *TokenServiceCreateResponse) GetToken() string {
 	return ""
 }
 
-type Domain struct {
+type AuthzServiceExplainRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name        string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Ttl         uint32   `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	ZoneFile    string   `protobuf:"bytes,4,opt,name=zone_file,json=zoneFile,proto3" json:"zone_file,omitempty"`
-	Url         string   `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
-	Nameservers []string `protobuf:"bytes,6,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	// method is the full procedure name to evaluate, e.g. /api.v1.DomainService/Get
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// request is the hypothetical request message in its json representation
+	Request *structpb.Struct `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
 }
 
-func (x *Domain) Reset() {
-	*x = Domain{}
+func (x *AuthzServiceExplainRequest) Reset() {
+	*x = AuthzServiceExplainRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -363,13 +419,13 @@ func (x *Domain) Reset() {
 	}
 }
 
-func (x *Domain) String() string {
+func (x *AuthzServiceExplainRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Domain) ProtoMessage() {}
+func (*AuthzServiceExplainRequest) ProtoMessage() {}
 
-func (x *Domain) ProtoReflect() protoreflect.Message {
+func (x *AuthzServiceExplainRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -381,63 +437,41 @@ func (x *Domain) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Domain.ProtoReflect.Descriptor instead.
-func (*Domain) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuthzServiceExplainRequest.ProtoReflect.Descriptor instead.
+func (*AuthzServiceExplainRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Domain) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-func (x *Domain) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-func (x *Domain) GetTtl() uint32 {
-	if x != nil {
-		return x.Ttl
-	}
-	return 0
-}
-
-func (x *Domain) GetZoneFile() string {
-	if x != nil {
-		return x.ZoneFile
-	}
-	return ""
-}
-
-func (x *Domain) GetUrl() string {
+func (x *AuthzServiceExplainRequest) GetMethod() string {
 	if x != nil {
-		return x.Url
+		return x.Method
 	}
 	return ""
 }
 
-func (x *Domain) GetNameservers() []string {
+func (x *AuthzServiceExplainRequest) GetRequest() *structpb.Struct {
 	if x != nil {
-		return x.Nameservers
+		return x.Request
 	}
 	return nil
 }
 
-type DomainServiceListRequest struct {
+type AuthzServiceExplainResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domains []string `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	Allow   bool   `protobuf:"varint,1,opt,name=allow,proto3" json:"allow,omitempty"`
+	IsAdmin bool   `protobuf:"varint,2,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// rules which evaluated successfully during the decision
+	Rules []string `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+	// output of print statements in the policies
+	Prints []string `protobuf:"bytes,5,rep,name=prints,proto3" json:"prints,omitempty"`
 }
 
-func (x *DomainServiceListRequest) Reset() {
-	*x = DomainServiceListRequest{}
+func (x *AuthzServiceExplainResponse) Reset() {
+	*x = AuthzServiceExplainResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -445,13 +479,13 @@ func (x *DomainServiceListRequest) Reset() {
 	}
 }
 
-func (x *DomainServiceListRequest) String() string {
+func (x *AuthzServiceExplainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceListRequest) ProtoMessage() {}
+func (*AuthzServiceExplainResponse) ProtoMessage() {}
 
-func (x *DomainServiceListRequest) ProtoReflect() protoreflect.Message {
+func (x *AuthzServiceExplainResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -463,28 +497,70 @@ func (x *DomainServiceListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceListRequest.ProtoReflect.Descriptor instead.
-func (*DomainServiceListRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuthzServiceExplainResponse.ProtoReflect.Descriptor instead.
+func (*AuthzServiceExplainResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *DomainServiceListRequest) GetDomains() []string {
+func (x *AuthzServiceExplainResponse) GetAllow() bool {
 	if x != nil {
-		return x.Domains
+		return x.Allow
+	}
+	return false
+}
+
+func (x *AuthzServiceExplainResponse) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+func (x *AuthzServiceExplainResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuthzServiceExplainResponse) GetRules() []string {
+	if x != nil {
+		return x.Rules
 	}
 	return nil
 }
 
-type DomainServiceGetRequest struct {
+func (x *AuthzServiceExplainResponse) GetPrints() []string {
+	if x != nil {
+		return x.Prints
+	}
+	return nil
+}
+
+type AuditEntry struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-}
-
-func (x *DomainServiceGetRequest) Reset() {
-	*x = DomainServiceGetRequest{}
+	Time      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	Procedure string                 `protobuf:"bytes,2,opt,name=procedure,proto3" json:"procedure,omitempty"`
+	Subject   string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Issuer    string                 `protobuf:"bytes,4,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Jti       string                 `protobuf:"bytes,5,opt,name=jti,proto3" json:"jti,omitempty"`
+	// domains affected by the call
+	Domains []string `protobuf:"bytes,6,rep,name=domains,proto3" json:"domains,omitempty"`
+	// request in its json representation
+	Request string `protobuf:"bytes,7,opt,name=request,proto3" json:"request,omitempty"`
+	// records of the affected rrsets before and after the call
+	Before []*Record `protobuf:"bytes,8,rep,name=before,proto3" json:"before,omitempty"`
+	After  []*Record `protobuf:"bytes,9,rep,name=after,proto3" json:"after,omitempty"`
+	// result is ok or the error code of the call
+	Result string `protobuf:"bytes,10,opt,name=result,proto3" json:"result,omitempty"`
+	Error  string `protobuf:"bytes,11,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -492,13 +568,13 @@ func (x *DomainServiceGetRequest) Reset() {
 	}
 }
 
-func (x *DomainServiceGetRequest) String() string {
+func (x *AuditEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceGetRequest) ProtoMessage() {}
+func (*AuditEntry) ProtoMessage() {}
 
-func (x *DomainServiceGetRequest) ProtoReflect() protoreflect.Message {
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -510,108 +586,116 @@ func (x *DomainServiceGetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceGetRequest.ProtoReflect.Descriptor instead.
-func (*DomainServiceGetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *DomainServiceGetRequest) GetName() string {
+func (x *AuditEntry) GetTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Name
+		return x.Time
 	}
-	return ""
+	return nil
 }
 
-type DomainServiceCreateRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Name        string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Nameservers []string `protobuf:"bytes,2,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
-	Url         *string  `protobuf:"bytes,5,opt,name=url,proto3,oneof" json:"url,omitempty"`
+func (x *AuditEntry) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
 }
 
-func (x *DomainServiceCreateRequest) Reset() {
-	*x = DomainServiceCreateRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[5]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *AuditEntry) GetSubject() string {
+	if x != nil {
+		return x.Subject
 	}
+	return ""
 }
 
-func (x *DomainServiceCreateRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *AuditEntry) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
 }
 
-func (*DomainServiceCreateRequest) ProtoMessage() {}
-
-func (x *DomainServiceCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[5]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *AuditEntry) GetJti() string {
+	if x != nil {
+		return x.Jti
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use DomainServiceCreateRequest.ProtoReflect.Descriptor instead.
-func (*DomainServiceCreateRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{5}
+func (x *AuditEntry) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
 }
 
-func (x *DomainServiceCreateRequest) GetName() string {
+func (x *AuditEntry) GetRequest() string {
 	if x != nil {
-		return x.Name
+		return x.Request
 	}
 	return ""
 }
 
-func (x *DomainServiceCreateRequest) GetNameservers() []string {
+func (x *AuditEntry) GetBefore() []*Record {
 	if x != nil {
-		return x.Nameservers
+		return x.Before
 	}
 	return nil
 }
 
-func (x *DomainServiceCreateRequest) GetUrl() string {
-	if x != nil && x.Url != nil {
-		return *x.Url
+func (x *AuditEntry) GetAfter() []*Record {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetResult() string {
+	if x != nil {
+		return x.Result
 	}
 	return ""
 }
 
-type DomainServiceUpdateRequest struct {
+func (x *AuditEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AuditServiceListRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Nameservers []string `protobuf:"bytes,2,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
-	Url         *string  `protobuf:"bytes,5,opt,name=url,proto3,oneof" json:"url,omitempty"`
+	Domain  *string                `protobuf:"bytes,1,opt,name=domain,proto3,oneof" json:"domain,omitempty"`
+	Subject *string                `protobuf:"bytes,2,opt,name=subject,proto3,oneof" json:"subject,omitempty"`
+	From    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
 }
 
-func (x *DomainServiceUpdateRequest) Reset() {
-	*x = DomainServiceUpdateRequest{}
+func (x *AuditServiceListRequest) Reset() {
+	*x = AuditServiceListRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[6]
+		mi := &file_api_v1_dns_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DomainServiceUpdateRequest) String() string {
+func (x *AuditServiceListRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceUpdateRequest) ProtoMessage() {}
+func (*AuditServiceListRequest) ProtoMessage() {}
 
-func (x *DomainServiceUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[6]
+func (x *AuditServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -622,57 +706,64 @@ func (x *DomainServiceUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceUpdateRequest.ProtoReflect.Descriptor instead.
-func (*DomainServiceUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use AuditServiceListRequest.ProtoReflect.Descriptor instead.
+func (*AuditServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DomainServiceUpdateRequest) GetName() string {
-	if x != nil {
-		return x.Name
+func (x *AuditServiceListRequest) GetDomain() string {
+	if x != nil && x.Domain != nil {
+		return *x.Domain
 	}
 	return ""
 }
 
-func (x *DomainServiceUpdateRequest) GetNameservers() []string {
+func (x *AuditServiceListRequest) GetSubject() string {
+	if x != nil && x.Subject != nil {
+		return *x.Subject
+	}
+	return ""
+}
+
+func (x *AuditServiceListRequest) GetFrom() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Nameservers
+		return x.From
 	}
 	return nil
 }
 
-func (x *DomainServiceUpdateRequest) GetUrl() string {
-	if x != nil && x.Url != nil {
-		return *x.Url
+func (x *AuditServiceListRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
 	}
-	return ""
+	return nil
 }
 
-type DomainServiceDeleteRequest struct {
+type AuditServiceListResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entries []*AuditEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 }
 
-func (x *DomainServiceDeleteRequest) Reset() {
-	*x = DomainServiceDeleteRequest{}
+func (x *AuditServiceListResponse) Reset() {
+	*x = AuditServiceListResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[7]
+		mi := &file_api_v1_dns_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DomainServiceDeleteRequest) String() string {
+func (x *AuditServiceListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceDeleteRequest) ProtoMessage() {}
+func (*AuditServiceListResponse) ProtoMessage() {}
 
-func (x *DomainServiceDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[7]
+func (x *AuditServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -683,43 +774,48 @@ func (x *DomainServiceDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceDeleteRequest.ProtoReflect.Descriptor instead.
-func (*DomainServiceDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use AuditServiceListResponse.ProtoReflect.Descriptor instead.
+func (*AuditServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *DomainServiceDeleteRequest) GetName() string {
+func (x *AuditServiceListResponse) GetEntries() []*AuditEntry {
 	if x != nil {
-		return x.Name
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-type DomainServiceListResponse struct {
+type Domain struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domains []*Domain `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ttl         uint32   `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	ZoneFile    string   `protobuf:"bytes,4,opt,name=zone_file,json=zoneFile,proto3" json:"zone_file,omitempty"`
+	Url         string   `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	Nameservers []string `protobuf:"bytes,6,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
 }
 
-func (x *DomainServiceListResponse) Reset() {
-	*x = DomainServiceListResponse{}
+func (x *Domain) Reset() {
+	*x = Domain{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[8]
+		mi := &file_api_v1_dns_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DomainServiceListResponse) String() string {
+func (x *Domain) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceListResponse) ProtoMessage() {}
+func (*Domain) ProtoMessage() {}
 
-func (x *DomainServiceListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[8]
+func (x *Domain) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -730,28 +826,114 @@ func (x *DomainServiceListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceListResponse.ProtoReflect.Descriptor instead.
-func (*DomainServiceListResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Domain.ProtoReflect.Descriptor instead.
+func (*Domain) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DomainServiceListResponse) GetDomains() []*Domain {
+func (x *Domain) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Domain) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Domain) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *Domain) GetZoneFile() string {
+	if x != nil {
+		return x.ZoneFile
+	}
+	return ""
+}
+
+func (x *Domain) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Domain) GetNameservers() []string {
+	if x != nil {
+		return x.Nameservers
+	}
+	return nil
+}
+
+type DomainServiceListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domains []string `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+}
+
+func (x *DomainServiceListRequest) Reset() {
+	*x = DomainServiceListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceListRequest) ProtoMessage() {}
+
+func (x *DomainServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceListRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DomainServiceListRequest) GetDomains() []string {
 	if x != nil {
 		return x.Domains
 	}
 	return nil
 }
 
-type DomainServiceGetResponse struct {
+// DomainServiceWatchRequest streams the changes of the domains
+type DomainServiceWatchRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// domains to watch, all domains of the token if empty
+	Domains []string `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+	// resume_token of the last received event, the events after it are sent first
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
-func (x *DomainServiceGetResponse) Reset() {
-	*x = DomainServiceGetResponse{}
+func (x *DomainServiceWatchRequest) Reset() {
+	*x = DomainServiceWatchRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -759,13 +941,13 @@ func (x *DomainServiceGetResponse) Reset() {
 	}
 }
 
-func (x *DomainServiceGetResponse) String() string {
+func (x *DomainServiceWatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceGetResponse) ProtoMessage() {}
+func (*DomainServiceWatchRequest) ProtoMessage() {}
 
-func (x *DomainServiceGetResponse) ProtoReflect() protoreflect.Message {
+func (x *DomainServiceWatchRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -777,28 +959,39 @@ func (x *DomainServiceGetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceGetResponse.ProtoReflect.Descriptor instead.
-func (*DomainServiceGetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DomainServiceWatchRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceWatchRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *DomainServiceGetResponse) GetDomain() *Domain {
+func (x *DomainServiceWatchRequest) GetDomains() []string {
 	if x != nil {
-		return x.Domain
+		return x.Domains
 	}
 	return nil
 }
 
-type DomainServiceUpdateResponse struct {
+func (x *DomainServiceWatchRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type DomainServiceWatchResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Type   EventType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.EventType" json:"type,omitempty"`
+	Domain *Domain   `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	// resume_token continues the watch after this event
+	ResumeToken string                 `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Timestamp   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
-func (x *DomainServiceUpdateResponse) Reset() {
-	*x = DomainServiceUpdateResponse{}
+func (x *DomainServiceWatchResponse) Reset() {
+	*x = DomainServiceWatchResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -806,13 +999,13 @@ func (x *DomainServiceUpdateResponse) Reset() {
 	}
 }
 
-func (x *DomainServiceUpdateResponse) String() string {
+func (x *DomainServiceWatchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceUpdateResponse) ProtoMessage() {}
+func (*DomainServiceWatchResponse) ProtoMessage() {}
 
-func (x *DomainServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+func (x *DomainServiceWatchResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -824,28 +1017,49 @@ func (x *DomainServiceUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceUpdateResponse.ProtoReflect.Descriptor instead.
-func (*DomainServiceUpdateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DomainServiceWatchResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceWatchResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *DomainServiceUpdateResponse) GetDomain() *Domain {
+func (x *DomainServiceWatchResponse) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *DomainServiceWatchResponse) GetDomain() *Domain {
 	if x != nil {
 		return x.Domain
 	}
 	return nil
 }
 
-type DomainServiceCreateResponse struct {
+func (x *DomainServiceWatchResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *DomainServiceWatchResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type DomainServiceGetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *DomainServiceCreateResponse) Reset() {
-	*x = DomainServiceCreateResponse{}
+func (x *DomainServiceGetRequest) Reset() {
+	*x = DomainServiceGetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -853,13 +1067,13 @@ func (x *DomainServiceCreateResponse) Reset() {
 	}
 }
 
-func (x *DomainServiceCreateResponse) String() string {
+func (x *DomainServiceGetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceCreateResponse) ProtoMessage() {}
+func (*DomainServiceGetRequest) ProtoMessage() {}
 
-func (x *DomainServiceCreateResponse) ProtoReflect() protoreflect.Message {
+func (x *DomainServiceGetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -871,28 +1085,30 @@ func (x *DomainServiceCreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceCreateResponse.ProtoReflect.Descriptor instead.
-func (*DomainServiceCreateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DomainServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceGetRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DomainServiceCreateResponse) GetDomain() *Domain {
+func (x *DomainServiceGetRequest) GetName() string {
 	if x != nil {
-		return x.Domain
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-type DomainServiceDeleteResponse struct {
+type DomainServiceCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Name        string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Nameservers []string `protobuf:"bytes,2,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	Url         *string  `protobuf:"bytes,5,opt,name=url,proto3,oneof" json:"url,omitempty"`
 }
 
-func (x *DomainServiceDeleteResponse) Reset() {
-	*x = DomainServiceDeleteResponse{}
+func (x *DomainServiceCreateRequest) Reset() {
+	*x = DomainServiceCreateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -900,13 +1116,13 @@ func (x *DomainServiceDeleteResponse) Reset() {
 	}
 }
 
-func (x *DomainServiceDeleteResponse) String() string {
+func (x *DomainServiceCreateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DomainServiceDeleteResponse) ProtoMessage() {}
+func (*DomainServiceCreateRequest) ProtoMessage() {}
 
-func (x *DomainServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+func (x *DomainServiceCreateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -918,36 +1134,44 @@ func (x *DomainServiceDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DomainServiceDeleteResponse.ProtoReflect.Descriptor instead.
-func (*DomainServiceDeleteResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DomainServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceCreateRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *DomainServiceDeleteResponse) GetDomain() *Domain {
+func (x *DomainServiceCreateRequest) GetName() string {
 	if x != nil {
-		return x.Domain
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainServiceCreateRequest) GetNameservers() []string {
+	if x != nil {
+		return x.Nameservers
 	}
 	return nil
 }
 
-type Record struct {
+func (x *DomainServiceCreateRequest) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
+	}
+	return ""
+}
+
+type DomainServiceUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type     RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
-	Name     string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Data     string     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Priority int32      `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
-	Port     uint32     `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
-	Ttl      uint32     `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Weight   int32      `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`
-	Flags    int32      `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
-	Tag      string     `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
+	Name        string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Nameservers []string `protobuf:"bytes,2,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	Url         *string  `protobuf:"bytes,5,opt,name=url,proto3,oneof" json:"url,omitempty"`
 }
 
-func (x *Record) Reset() {
-	*x = Record{}
+func (x *DomainServiceUpdateRequest) Reset() {
+	*x = DomainServiceUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_api_v1_dns_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -955,13 +1179,13 @@ func (x *Record) Reset() {
 	}
 }
 
-func (x *Record) String() string {
+func (x *DomainServiceUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Record) ProtoMessage() {}
+func (*DomainServiceUpdateRequest) ProtoMessage() {}
 
-func (x *Record) ProtoReflect() protoreflect.Message {
+func (x *DomainServiceUpdateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_dns_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -973,101 +1197,1900 @@ func (x *Record) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Record.ProtoReflect.Descriptor instead.
-func (*Record) Descriptor() ([]byte, []int) {
+// Deprecated: Use DomainServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceUpdateRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_dns_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *Record) GetType() RecordType {
-	if x != nil {
-		return x.Type
-	}
-	return RecordType_UNKNOWN
-}
-
-func (x *Record) GetName() string {
+func (x *DomainServiceUpdateRequest) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Record) GetData() string {
+func (x *DomainServiceUpdateRequest) GetNameservers() []string {
 	if x != nil {
-		return x.Data
+		return x.Nameservers
 	}
-	return ""
+	return nil
 }
 
-func (x *Record) GetPriority() int32 {
-	if x != nil {
-		return x.Priority
+func (x *DomainServiceUpdateRequest) GetUrl() string {
+	if x != nil && x.Url != nil {
+		return *x.Url
 	}
-	return 0
+	return ""
 }
 
-func (x *Record) GetPort() uint32 {
-	if x != nil {
-		return x.Port
-	}
-	return 0
+type DomainServiceDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 }
 
-func (x *Record) GetTtl() uint32 {
-	if x != nil {
+func (x *DomainServiceDeleteRequest) Reset() {
+	*x = DomainServiceDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceDeleteRequest) ProtoMessage() {}
+
+func (x *DomainServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DomainServiceDeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DomainServiceListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domains []*Domain `protobuf:"bytes,1,rep,name=domains,proto3" json:"domains,omitempty"`
+}
+
+func (x *DomainServiceListResponse) Reset() {
+	*x = DomainServiceListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceListResponse) ProtoMessage() {}
+
+func (x *DomainServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceListResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DomainServiceListResponse) GetDomains() []*Domain {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+type DomainServiceGetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+}
+
+func (x *DomainServiceGetResponse) Reset() {
+	*x = DomainServiceGetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceGetResponse) ProtoMessage() {}
+
+func (x *DomainServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DomainServiceGetResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+type DomainServiceUpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+}
+
+func (x *DomainServiceUpdateResponse) Reset() {
+	*x = DomainServiceUpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceUpdateResponse) ProtoMessage() {}
+
+func (x *DomainServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DomainServiceUpdateResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+type DomainServiceCreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+}
+
+func (x *DomainServiceCreateResponse) Reset() {
+	*x = DomainServiceCreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceCreateResponse) ProtoMessage() {}
+
+func (x *DomainServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DomainServiceCreateResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+type DomainServiceDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+}
+
+func (x *DomainServiceDeleteResponse) Reset() {
+	*x = DomainServiceDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceDeleteResponse) ProtoMessage() {}
+
+func (x *DomainServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DomainServiceDeleteResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+// Revision is the state of a zone before a mutating call
+type Revision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Domain string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// subject and procedure of the call which changed the zone after this revision
+	Subject   string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Procedure string `protobuf:"bytes,5,opt,name=procedure,proto3" json:"procedure,omitempty"`
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Revision) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Revision) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Revision) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Revision) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Revision) GetProcedure() string {
+	if x != nil {
+		return x.Procedure
+	}
+	return ""
+}
+
+// RecordSetChange is the difference of a single rrset between two states of a zone
+type RecordSetChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type RecordType `protobuf:"varint,2,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	// records are empty if the rrset does not exist in this state
+	Before []*Record `protobuf:"bytes,3,rep,name=before,proto3" json:"before,omitempty"`
+	After  []*Record `protobuf:"bytes,4,rep,name=after,proto3" json:"after,omitempty"`
+}
+
+func (x *RecordSetChange) Reset() {
+	*x = RecordSetChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordSetChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordSetChange) ProtoMessage() {}
+
+func (x *RecordSetChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordSetChange.ProtoReflect.Descriptor instead.
+func (*RecordSetChange) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RecordSetChange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordSetChange) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *RecordSetChange) GetBefore() []*Record {
+	if x != nil {
+		return x.Before
+	}
+	return nil
+}
+
+func (x *RecordSetChange) GetAfter() []*Record {
+	if x != nil {
+		return x.After
+	}
+	return nil
+}
+
+type DomainServiceListRevisionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DomainServiceListRevisionsRequest) Reset() {
+	*x = DomainServiceListRevisionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceListRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceListRevisionsRequest) ProtoMessage() {}
+
+func (x *DomainServiceListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DomainServiceListRevisionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DomainServiceListRevisionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revisions []*Revision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+}
+
+func (x *DomainServiceListRevisionsResponse) Reset() {
+	*x = DomainServiceListRevisionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceListRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceListRevisionsResponse) ProtoMessage() {}
+
+func (x *DomainServiceListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DomainServiceListRevisionsResponse) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type DomainServiceDiffRevisionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	From uint64 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to defaults to the current state of the zone
+	To *uint64 `protobuf:"varint,3,opt,name=to,proto3,oneof" json:"to,omitempty"`
+}
+
+func (x *DomainServiceDiffRevisionsRequest) Reset() {
+	*x = DomainServiceDiffRevisionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceDiffRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceDiffRevisionsRequest) ProtoMessage() {}
+
+func (x *DomainServiceDiffRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceDiffRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceDiffRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DomainServiceDiffRevisionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainServiceDiffRevisionsRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DomainServiceDiffRevisionsRequest) GetTo() uint64 {
+	if x != nil && x.To != nil {
+		return *x.To
+	}
+	return 0
+}
+
+type DomainServiceDiffRevisionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Changes []*RecordSetChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *DomainServiceDiffRevisionsResponse) Reset() {
+	*x = DomainServiceDiffRevisionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceDiffRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceDiffRevisionsResponse) ProtoMessage() {}
+
+func (x *DomainServiceDiffRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceDiffRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceDiffRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DomainServiceDiffRevisionsResponse) GetChanges() []*RecordSetChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type DomainServiceRollbackRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+}
+
+func (x *DomainServiceRollbackRequest) Reset() {
+	*x = DomainServiceRollbackRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceRollbackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceRollbackRequest) ProtoMessage() {}
+
+func (x *DomainServiceRollbackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceRollbackRequest.ProtoReflect.Descriptor instead.
+func (*DomainServiceRollbackRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DomainServiceRollbackRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DomainServiceRollbackRequest) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+type DomainServiceRollbackResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain *Domain `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// changes applied to the zone
+	Changes []*RecordSetChange `protobuf:"bytes,2,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (x *DomainServiceRollbackResponse) Reset() {
+	*x = DomainServiceRollbackResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DomainServiceRollbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DomainServiceRollbackResponse) ProtoMessage() {}
+
+func (x *DomainServiceRollbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DomainServiceRollbackResponse.ProtoReflect.Descriptor instead.
+func (*DomainServiceRollbackResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DomainServiceRollbackResponse) GetDomain() *Domain {
+	if x != nil {
+		return x.Domain
+	}
+	return nil
+}
+
+func (x *DomainServiceRollbackResponse) GetChanges() []*RecordSetChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type Record struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	Name     string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Data     string     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Priority int32      `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Port     uint32     `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
+	Ttl      uint32     `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Weight   int32      `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`
+	Flags    int32      `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
+	Tag      string     `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *Record) Reset() {
+	*x = Record{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Record) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Record) ProtoMessage() {}
+
+func (x *Record) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Record.ProtoReflect.Descriptor instead.
+func (*Record) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *Record) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *Record) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Record) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *Record) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Record) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Record) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *Record) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Record) GetFlags() int32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *Record) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type RecordServiceListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Domain string     `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Type   RecordType `protobuf:"varint,2,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	Name   *string    `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+}
+
+func (x *RecordServiceListRequest) Reset() {
+	*x = RecordServiceListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceListRequest) ProtoMessage() {}
+
+func (x *RecordServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceListRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RecordServiceListRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *RecordServiceListRequest) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *RecordServiceListRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+type RecordServiceCreateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	Name     string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Data     string     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Priority int32      `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Port     uint32     `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
+	Ttl      uint32     `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Weight   int32      `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`
+	Flags    int32      `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
+	Tag      string     `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
+	// wait_for_propagation returns after all authoritative nameservers serve the record or the propagation timeout passed
+	WaitForPropagation bool `protobuf:"varint,10,opt,name=wait_for_propagation,json=waitForPropagation,proto3" json:"wait_for_propagation,omitempty"`
+}
+
+func (x *RecordServiceCreateRequest) Reset() {
+	*x = RecordServiceCreateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceCreateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceCreateRequest) ProtoMessage() {}
+
+func (x *RecordServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RecordServiceCreateRequest) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *RecordServiceCreateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordServiceCreateRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *RecordServiceCreateRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *RecordServiceCreateRequest) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *RecordServiceCreateRequest) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *RecordServiceCreateRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RecordServiceCreateRequest) GetFlags() int32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *RecordServiceCreateRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *RecordServiceCreateRequest) GetWaitForPropagation() bool {
+	if x != nil {
+		return x.WaitForPropagation
+	}
+	return false
+}
+
+type RecordServiceUpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Uuid     string     `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Type     RecordType `protobuf:"varint,2,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	Name     string     `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Data     string     `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Priority int32      `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	Port     uint32     `protobuf:"varint,6,opt,name=port,proto3" json:"port,omitempty"`
+	Ttl      uint32     `protobuf:"varint,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	Weight   int32      `protobuf:"varint,8,opt,name=weight,proto3" json:"weight,omitempty"`
+	Flags    int32      `protobuf:"varint,9,opt,name=flags,proto3" json:"flags,omitempty"`
+	Tag      string     `protobuf:"bytes,10,opt,name=tag,proto3" json:"tag,omitempty"`
+	// wait_for_propagation returns after all authoritative nameservers serve the record or the propagation timeout passed
+	WaitForPropagation bool `protobuf:"varint,11,opt,name=wait_for_propagation,json=waitForPropagation,proto3" json:"wait_for_propagation,omitempty"`
+}
+
+func (x *RecordServiceUpdateRequest) Reset() {
+	*x = RecordServiceUpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceUpdateRequest) ProtoMessage() {}
+
+func (x *RecordServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RecordServiceUpdateRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *RecordServiceUpdateRequest) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *RecordServiceUpdateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordServiceUpdateRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *RecordServiceUpdateRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *RecordServiceUpdateRequest) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *RecordServiceUpdateRequest) GetTtl() uint32 {
+	if x != nil {
 		return x.Ttl
 	}
-	return 0
+	return 0
+}
+
+func (x *RecordServiceUpdateRequest) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RecordServiceUpdateRequest) GetFlags() int32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *RecordServiceUpdateRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *RecordServiceUpdateRequest) GetWaitForPropagation() bool {
+	if x != nil {
+		return x.WaitForPropagation
+	}
+	return false
+}
+
+type RecordServiceDeleteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	Name string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Data string     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// wait_for_propagation returns after no authoritative nameserver serves the record or the propagation timeout passed
+	WaitForPropagation bool `protobuf:"varint,4,opt,name=wait_for_propagation,json=waitForPropagation,proto3" json:"wait_for_propagation,omitempty"`
+}
+
+func (x *RecordServiceDeleteRequest) Reset() {
+	*x = RecordServiceDeleteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceDeleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceDeleteRequest) ProtoMessage() {}
+
+func (x *RecordServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RecordServiceDeleteRequest) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *RecordServiceDeleteRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordServiceDeleteRequest) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *RecordServiceDeleteRequest) GetWaitForPropagation() bool {
+	if x != nil {
+		return x.WaitForPropagation
+	}
+	return false
+}
+
+// RecordServiceVerifyRequest checks whether the authoritative nameservers of the zone serve the rrset
+type RecordServiceVerifyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
+	Name string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// data is the expected content of the rrset, if empty the rrset stored in the zone is expected
+	Data []string `protobuf:"bytes,3,rep,name=data,proto3" json:"data,omitempty"`
+	// timeout to wait for the propagation, if unset the nameservers are queried once
+	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *RecordServiceVerifyRequest) Reset() {
+	*x = RecordServiceVerifyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceVerifyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceVerifyRequest) ProtoMessage() {}
+
+func (x *RecordServiceVerifyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceVerifyRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceVerifyRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RecordServiceVerifyRequest) GetType() RecordType {
+	if x != nil {
+		return x.Type
+	}
+	return RecordType_UNKNOWN
+}
+
+func (x *RecordServiceVerifyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RecordServiceVerifyRequest) GetData() []string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *RecordServiceVerifyRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// RecordServiceWatchRequest streams the changes of the records
+type RecordServiceWatchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// domain to watch, all domains of the token if empty
+	Domain string `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	// resume_token of the last received event, the events after it are sent first
+	ResumeToken string `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+}
+
+func (x *RecordServiceWatchRequest) Reset() {
+	*x = RecordServiceWatchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceWatchRequest) ProtoMessage() {}
+
+func (x *RecordServiceWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceWatchRequest.ProtoReflect.Descriptor instead.
+func (*RecordServiceWatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RecordServiceWatchRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *RecordServiceWatchRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type RecordServiceWatchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type EventType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.EventType" json:"type,omitempty"`
+	// domain the record belongs to
+	Domain string  `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"`
+	Record *Record `protobuf:"bytes,3,opt,name=record,proto3" json:"record,omitempty"`
+	// resume_token continues the watch after this event
+	ResumeToken string                 `protobuf:"bytes,4,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	Timestamp   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+}
+
+func (x *RecordServiceWatchResponse) Reset() {
+	*x = RecordServiceWatchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceWatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceWatchResponse) ProtoMessage() {}
+
+func (x *RecordServiceWatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceWatchResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceWatchResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecordServiceWatchResponse) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *RecordServiceWatchResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *RecordServiceWatchResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordServiceWatchResponse) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *RecordServiceWatchResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type RecordServiceListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *RecordServiceListResponse) Reset() {
+	*x = RecordServiceListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceListResponse) ProtoMessage() {}
+
+func (x *RecordServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceListResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RecordServiceListResponse) GetRecords() []*Record {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type RecordServiceGetResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+}
+
+func (x *RecordServiceGetResponse) Reset() {
+	*x = RecordServiceGetResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceGetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceGetResponse) ProtoMessage() {}
+
+func (x *RecordServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RecordServiceGetResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+type RecordServiceDeleteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	// propagation is only set if wait_for_propagation was requested
+	Propagation *Propagation `protobuf:"bytes,2,opt,name=propagation,proto3" json:"propagation,omitempty"`
+}
+
+func (x *RecordServiceDeleteResponse) Reset() {
+	*x = RecordServiceDeleteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceDeleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceDeleteResponse) ProtoMessage() {}
+
+func (x *RecordServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RecordServiceDeleteResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordServiceDeleteResponse) GetPropagation() *Propagation {
+	if x != nil {
+		return x.Propagation
+	}
+	return nil
+}
+
+type RecordServiceUpdateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	// propagation is only set if wait_for_propagation was requested
+	Propagation *Propagation `protobuf:"bytes,2,opt,name=propagation,proto3" json:"propagation,omitempty"`
+}
+
+func (x *RecordServiceUpdateResponse) Reset() {
+	*x = RecordServiceUpdateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceUpdateResponse) ProtoMessage() {}
+
+func (x *RecordServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RecordServiceUpdateResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordServiceUpdateResponse) GetPropagation() *Propagation {
+	if x != nil {
+		return x.Propagation
+	}
+	return nil
+}
+
+type RecordServiceCreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	// propagation is only set if wait_for_propagation was requested
+	Propagation *Propagation `protobuf:"bytes,2,opt,name=propagation,proto3" json:"propagation,omitempty"`
+}
+
+func (x *RecordServiceCreateResponse) Reset() {
+	*x = RecordServiceCreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RecordServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceCreateResponse) ProtoMessage() {}
+
+func (x *RecordServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RecordServiceCreateResponse) GetRecord() *Record {
+	if x != nil {
+		return x.Record
+	}
+	return nil
+}
+
+func (x *RecordServiceCreateResponse) GetPropagation() *Propagation {
+	if x != nil {
+		return x.Propagation
+	}
+	return nil
+}
+
+type RecordServiceVerifyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Propagation *Propagation `protobuf:"bytes,1,opt,name=propagation,proto3" json:"propagation,omitempty"`
+}
+
+func (x *RecordServiceVerifyResponse) Reset() {
+	*x = RecordServiceVerifyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *Record) GetWeight() int32 {
+func (x *RecordServiceVerifyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordServiceVerifyResponse) ProtoMessage() {}
+
+func (x *RecordServiceVerifyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordServiceVerifyResponse.ProtoReflect.Descriptor instead.
+func (*RecordServiceVerifyResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RecordServiceVerifyResponse) GetPropagation() *Propagation {
 	if x != nil {
-		return x.Weight
+		return x.Propagation
 	}
-	return 0
+	return nil
 }
 
-func (x *Record) GetFlags() int32 {
+// Propagation is the state of a rrset on the authoritative nameservers of its zone
+type Propagation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// propagated is true if all nameservers serve the expected rrset
+	Propagated  bool                `protobuf:"varint,1,opt,name=propagated,proto3" json:"propagated,omitempty"`
+	Nameservers []*NameserverStatus `protobuf:"bytes,2,rep,name=nameservers,proto3" json:"nameservers,omitempty"`
+	// error is set if the propagation could not be verified, e.g. no nameservers were found or the wait was canceled.
+	// A write with wait_for_propagation has succeeded nevertheless.
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *Propagation) Reset() {
+	*x = Propagation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Propagation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Propagation) ProtoMessage() {}
+
+func (x *Propagation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Propagation.ProtoReflect.Descriptor instead.
+func (*Propagation) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Propagation) GetPropagated() bool {
 	if x != nil {
-		return x.Flags
+		return x.Propagated
 	}
-	return 0
+	return false
 }
 
-func (x *Record) GetTag() string {
+func (x *Propagation) GetNameservers() []*NameserverStatus {
 	if x != nil {
-		return x.Tag
+		return x.Nameservers
+	}
+	return nil
+}
+
+func (x *Propagation) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-type RecordServiceListRequest struct {
+type NameserverStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Domain string     `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
-	Type   RecordType `protobuf:"varint,2,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
-	Name   *string    `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// nameserver is the name of the nameserver as given in the NS records of the zone
+	Nameserver string `protobuf:"bytes,1,opt,name=nameserver,proto3" json:"nameserver,omitempty"`
+	// address which was queried, one status is reported per address of a nameserver
+	Address    string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Propagated bool   `protobuf:"varint,3,opt,name=propagated,proto3" json:"propagated,omitempty"`
+	// data is the content of the rrset served by this address
+	Data []string `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	// error of the last query, empty on success
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *RecordServiceListRequest) Reset() {
-	*x = RecordServiceListRequest{}
+func (x *NameserverStatus) Reset() {
+	*x = NameserverStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[14]
+		mi := &file_api_v1_dns_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceListRequest) String() string {
+func (x *NameserverStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceListRequest) ProtoMessage() {}
+func (*NameserverStatus) ProtoMessage() {}
 
-func (x *RecordServiceListRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[14]
+func (x *NameserverStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1078,65 +3101,303 @@ func (x *RecordServiceListRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceListRequest.ProtoReflect.Descriptor instead.
-func (*RecordServiceListRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use NameserverStatus.ProtoReflect.Descriptor instead.
+func (*NameserverStatus) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *RecordServiceListRequest) GetDomain() string {
+func (x *NameserverStatus) GetNameserver() string {
 	if x != nil {
-		return x.Domain
+		return x.Nameserver
 	}
 	return ""
 }
 
-func (x *RecordServiceListRequest) GetType() RecordType {
+func (x *NameserverStatus) GetAddress() string {
 	if x != nil {
-		return x.Type
+		return x.Address
 	}
-	return RecordType_UNKNOWN
+	return ""
 }
 
-func (x *RecordServiceListRequest) GetName() string {
-	if x != nil && x.Name != nil {
-		return *x.Name
+func (x *NameserverStatus) GetPropagated() bool {
+	if x != nil {
+		return x.Propagated
+	}
+	return false
+}
+
+func (x *NameserverStatus) GetData() []string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *NameserverStatus) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-type RecordServiceCreateRequest struct {
+// ChallengeServicePresentRequest adds a value to the TXT rrset of an ACME DNS-01 challenge,
+// values of other challenges in the same rrset are kept
+type ChallengeServicePresentRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type     RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
-	Name     string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Data     string     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Priority int32      `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
-	Port     uint32     `protobuf:"varint,5,opt,name=port,proto3" json:"port,omitempty"`
-	Ttl      uint32     `protobuf:"varint,6,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Weight   int32      `protobuf:"varint,7,opt,name=weight,proto3" json:"weight,omitempty"`
-	Flags    int32      `protobuf:"varint,8,opt,name=flags,proto3" json:"flags,omitempty"`
-	Tag      string     `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
+	// name of the TXT record, must start with _acme-challenge., e.g. _acme-challenge.www.example.com.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// value is the digest of the key authorization
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// ttl of the rrset, defaults to 60 seconds
+	Ttl uint32 `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *ChallengeServicePresentRequest) Reset() {
+	*x = ChallengeServicePresentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeServicePresentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeServicePresentRequest) ProtoMessage() {}
+
+func (x *ChallengeServicePresentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeServicePresentRequest.ProtoReflect.Descriptor instead.
+func (*ChallengeServicePresentRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ChallengeServicePresentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChallengeServicePresentRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ChallengeServicePresentRequest) GetTtl() uint32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+type ChallengeServicePresentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// values of the rrset after the value was added
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *ChallengeServicePresentResponse) Reset() {
+	*x = ChallengeServicePresentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeServicePresentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeServicePresentResponse) ProtoMessage() {}
+
+func (x *ChallengeServicePresentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeServicePresentResponse.ProtoReflect.Descriptor instead.
+func (*ChallengeServicePresentResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ChallengeServicePresentResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// ChallengeServiceCleanUpRequest removes a value from the TXT rrset of an ACME DNS-01 challenge,
+// the rrset is deleted with its last value
+type ChallengeServiceCleanUpRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ChallengeServiceCleanUpRequest) Reset() {
+	*x = ChallengeServiceCleanUpRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeServiceCleanUpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeServiceCleanUpRequest) ProtoMessage() {}
+
+func (x *ChallengeServiceCleanUpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeServiceCleanUpRequest.ProtoReflect.Descriptor instead.
+func (*ChallengeServiceCleanUpRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ChallengeServiceCleanUpRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ChallengeServiceCleanUpRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ChallengeServiceCleanUpResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// values of the rrset after the value was removed
+	Values []string `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *ChallengeServiceCleanUpResponse) Reset() {
+	*x = ChallengeServiceCleanUpResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeServiceCleanUpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeServiceCleanUpResponse) ProtoMessage() {}
+
+func (x *ChallengeServiceCleanUpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeServiceCleanUpResponse.ProtoReflect.Descriptor instead.
+func (*ChallengeServiceCleanUpResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ChallengeServiceCleanUpResponse) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
 }
 
-func (x *RecordServiceCreateRequest) Reset() {
-	*x = RecordServiceCreateRequest{}
+// Webhook posts the changes of the zones matching its domains as signed json to its url
+type Webhook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id  string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// domains are patterns of the zones whose changes are posted, e.g. example.com. or *.example.com.
+	Domains []string `protobuf:"bytes,3,rep,name=domains,proto3" json:"domains,omitempty"`
+	// event_types limit the posted events, all changes are posted if empty
+	EventTypes  []EventType `protobuf:"varint,4,rep,packed,name=event_types,json=eventTypes,proto3,enum=api.v1.EventType" json:"event_types,omitempty"`
+	Description string      `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// secret is the key of the HMAC-SHA256 signature of the events, it is only returned by create and by updates which set it
+	Secret    string                 `protobuf:"bytes,6,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[15]
+		mi := &file_api_v1_dns_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceCreateRequest) String() string {
+func (x *Webhook) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceCreateRequest) ProtoMessage() {}
+func (*Webhook) ProtoMessage() {}
 
-func (x *RecordServiceCreateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[15]
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1147,108 +3408,97 @@ func (x *RecordServiceCreateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceCreateRequest.ProtoReflect.Descriptor instead.
-func (*RecordServiceCreateRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{15}
-}
-
-func (x *RecordServiceCreateRequest) GetType() RecordType {
-	if x != nil {
-		return x.Type
-	}
-	return RecordType_UNKNOWN
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *RecordServiceCreateRequest) GetName() string {
+func (x *Webhook) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *RecordServiceCreateRequest) GetData() string {
+func (x *Webhook) GetUrl() string {
 	if x != nil {
-		return x.Data
+		return x.Url
 	}
 	return ""
 }
 
-func (x *RecordServiceCreateRequest) GetPriority() int32 {
+func (x *Webhook) GetDomains() []string {
 	if x != nil {
-		return x.Priority
+		return x.Domains
 	}
-	return 0
+	return nil
 }
 
-func (x *RecordServiceCreateRequest) GetPort() uint32 {
+func (x *Webhook) GetEventTypes() []EventType {
 	if x != nil {
-		return x.Port
+		return x.EventTypes
 	}
-	return 0
+	return nil
 }
 
-func (x *RecordServiceCreateRequest) GetTtl() uint32 {
+func (x *Webhook) GetDescription() string {
 	if x != nil {
-		return x.Ttl
+		return x.Description
 	}
-	return 0
+	return ""
 }
 
-func (x *RecordServiceCreateRequest) GetWeight() int32 {
+func (x *Webhook) GetSecret() string {
 	if x != nil {
-		return x.Weight
+		return x.Secret
 	}
-	return 0
+	return ""
 }
 
-func (x *RecordServiceCreateRequest) GetFlags() int32 {
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Flags
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-func (x *RecordServiceCreateRequest) GetTag() string {
+func (x *Webhook) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Tag
+		return x.UpdatedAt
 	}
-	return ""
+	return nil
 }
 
-type RecordServiceUpdateRequest struct {
+type WebhookServiceCreateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Uuid     string     `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
-	Type     RecordType `protobuf:"varint,2,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
-	Name     string     `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Data     string     `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	Priority int32      `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	Port     uint32     `protobuf:"varint,6,opt,name=port,proto3" json:"port,omitempty"`
-	Ttl      uint32     `protobuf:"varint,7,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	Weight   int32      `protobuf:"varint,8,opt,name=weight,proto3" json:"weight,omitempty"`
-	Flags    int32      `protobuf:"varint,9,opt,name=flags,proto3" json:"flags,omitempty"`
-	Tag      string     `protobuf:"bytes,10,opt,name=tag,proto3" json:"tag,omitempty"`
+	Url         string      `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Domains     []string    `protobuf:"bytes,2,rep,name=domains,proto3" json:"domains,omitempty"`
+	EventTypes  []EventType `protobuf:"varint,3,rep,packed,name=event_types,json=eventTypes,proto3,enum=api.v1.EventType" json:"event_types,omitempty"`
+	Description string      `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// secret signs the events, a random secret is generated if empty
+	Secret string `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
 }
 
-func (x *RecordServiceUpdateRequest) Reset() {
-	*x = RecordServiceUpdateRequest{}
+func (x *WebhookServiceCreateRequest) Reset() {
+	*x = WebhookServiceCreateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[16]
+		mi := &file_api_v1_dns_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceUpdateRequest) String() string {
+func (x *WebhookServiceCreateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceUpdateRequest) ProtoMessage() {}
+func (*WebhookServiceCreateRequest) ProtoMessage() {}
 
-func (x *RecordServiceUpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[16]
+func (x *WebhookServiceCreateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1259,108 +3509,165 @@ func (x *RecordServiceUpdateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceUpdateRequest.ProtoReflect.Descriptor instead.
-func (*RecordServiceUpdateRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use WebhookServiceCreateRequest.ProtoReflect.Descriptor instead.
+func (*WebhookServiceCreateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *RecordServiceUpdateRequest) GetUuid() string {
+func (x *WebhookServiceCreateRequest) GetUrl() string {
 	if x != nil {
-		return x.Uuid
+		return x.Url
 	}
 	return ""
 }
 
-func (x *RecordServiceUpdateRequest) GetType() RecordType {
+func (x *WebhookServiceCreateRequest) GetDomains() []string {
 	if x != nil {
-		return x.Type
+		return x.Domains
 	}
-	return RecordType_UNKNOWN
+	return nil
 }
 
-func (x *RecordServiceUpdateRequest) GetName() string {
+func (x *WebhookServiceCreateRequest) GetEventTypes() []EventType {
 	if x != nil {
-		return x.Name
+		return x.EventTypes
 	}
-	return ""
+	return nil
 }
 
-func (x *RecordServiceUpdateRequest) GetData() string {
+func (x *WebhookServiceCreateRequest) GetDescription() string {
 	if x != nil {
-		return x.Data
+		return x.Description
 	}
 	return ""
 }
 
-func (x *RecordServiceUpdateRequest) GetPriority() int32 {
+func (x *WebhookServiceCreateRequest) GetSecret() string {
 	if x != nil {
-		return x.Priority
+		return x.Secret
 	}
-	return 0
+	return ""
 }
 
-func (x *RecordServiceUpdateRequest) GetPort() uint32 {
-	if x != nil {
-		return x.Port
+type WebhookServiceCreateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Webhook *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+}
+
+func (x *WebhookServiceCreateResponse) Reset() {
+	*x = WebhookServiceCreateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *RecordServiceUpdateRequest) GetTtl() uint32 {
-	if x != nil {
-		return x.Ttl
+func (x *WebhookServiceCreateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookServiceCreateResponse) ProtoMessage() {}
+
+func (x *WebhookServiceCreateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *RecordServiceUpdateRequest) GetWeight() int32 {
+// Deprecated: Use WebhookServiceCreateResponse.ProtoReflect.Descriptor instead.
+func (*WebhookServiceCreateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WebhookServiceCreateResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.Weight
+		return x.Webhook
 	}
-	return 0
+	return nil
 }
 
-func (x *RecordServiceUpdateRequest) GetFlags() int32 {
-	if x != nil {
-		return x.Flags
+type WebhookServiceGetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *WebhookServiceGetRequest) Reset() {
+	*x = WebhookServiceGetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *RecordServiceUpdateRequest) GetTag() string {
+func (x *WebhookServiceGetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookServiceGetRequest) ProtoMessage() {}
+
+func (x *WebhookServiceGetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookServiceGetRequest.ProtoReflect.Descriptor instead.
+func (*WebhookServiceGetRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *WebhookServiceGetRequest) GetId() string {
 	if x != nil {
-		return x.Tag
+		return x.Id
 	}
 	return ""
 }
 
-type RecordServiceDeleteRequest struct {
+type WebhookServiceGetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type RecordType `protobuf:"varint,1,opt,name=type,proto3,enum=api.v1.RecordType" json:"type,omitempty"`
-	Name string     `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Data string     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Webhook *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
 }
 
-func (x *RecordServiceDeleteRequest) Reset() {
-	*x = RecordServiceDeleteRequest{}
+func (x *WebhookServiceGetResponse) Reset() {
+	*x = WebhookServiceGetResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[17]
+		mi := &file_api_v1_dns_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceDeleteRequest) String() string {
+func (x *WebhookServiceGetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceDeleteRequest) ProtoMessage() {}
+func (*WebhookServiceGetResponse) ProtoMessage() {}
 
-func (x *RecordServiceDeleteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[17]
+func (x *WebhookServiceGetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1371,57 +3678,81 @@ func (x *RecordServiceDeleteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceDeleteRequest.ProtoReflect.Descriptor instead.
-func (*RecordServiceDeleteRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use WebhookServiceGetResponse.ProtoReflect.Descriptor instead.
+func (*WebhookServiceGetResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *RecordServiceDeleteRequest) GetType() RecordType {
+func (x *WebhookServiceGetResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.Type
+		return x.Webhook
 	}
-	return RecordType_UNKNOWN
+	return nil
 }
 
-func (x *RecordServiceDeleteRequest) GetName() string {
-	if x != nil {
-		return x.Name
+type WebhookServiceListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *WebhookServiceListRequest) Reset() {
+	*x = WebhookServiceListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_v1_dns_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *RecordServiceDeleteRequest) GetData() string {
-	if x != nil {
-		return x.Data
+func (x *WebhookServiceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookServiceListRequest) ProtoMessage() {}
+
+func (x *WebhookServiceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type RecordServiceListResponse struct {
+// Deprecated: Use WebhookServiceListRequest.ProtoReflect.Descriptor instead.
+func (*WebhookServiceListRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{53}
+}
+
+type WebhookServiceListResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Records []*Record `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+	Webhooks []*Webhook `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
 }
 
-func (x *RecordServiceListResponse) Reset() {
-	*x = RecordServiceListResponse{}
+func (x *WebhookServiceListResponse) Reset() {
+	*x = WebhookServiceListResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[18]
+		mi := &file_api_v1_dns_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceListResponse) String() string {
+func (x *WebhookServiceListResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceListResponse) ProtoMessage() {}
+func (*WebhookServiceListResponse) ProtoMessage() {}
 
-func (x *RecordServiceListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[18]
+func (x *WebhookServiceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1432,43 +3763,50 @@ func (x *RecordServiceListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceListResponse.ProtoReflect.Descriptor instead.
-func (*RecordServiceListResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use WebhookServiceListResponse.ProtoReflect.Descriptor instead.
+func (*WebhookServiceListResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *RecordServiceListResponse) GetRecords() []*Record {
+func (x *WebhookServiceListResponse) GetWebhooks() []*Webhook {
 	if x != nil {
-		return x.Records
+		return x.Webhooks
 	}
 	return nil
 }
 
-type RecordServiceGetResponse struct {
+// WebhookServiceUpdateRequest replaces the url, domains, event types and description of a webhook
+type WebhookServiceUpdateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	Id          string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url         string      `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Domains     []string    `protobuf:"bytes,3,rep,name=domains,proto3" json:"domains,omitempty"`
+	EventTypes  []EventType `protobuf:"varint,4,rep,packed,name=event_types,json=eventTypes,proto3,enum=api.v1.EventType" json:"event_types,omitempty"`
+	Description string      `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// secret replaces the secret if set, an empty secret generates a new one
+	Secret *string `protobuf:"bytes,6,opt,name=secret,proto3,oneof" json:"secret,omitempty"`
 }
 
-func (x *RecordServiceGetResponse) Reset() {
-	*x = RecordServiceGetResponse{}
+func (x *WebhookServiceUpdateRequest) Reset() {
+	*x = WebhookServiceUpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[19]
+		mi := &file_api_v1_dns_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceGetResponse) String() string {
+func (x *WebhookServiceUpdateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceGetResponse) ProtoMessage() {}
+func (*WebhookServiceUpdateRequest) ProtoMessage() {}
 
-func (x *RecordServiceGetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[19]
+func (x *WebhookServiceUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1479,43 +3817,78 @@ func (x *RecordServiceGetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceGetResponse.ProtoReflect.Descriptor instead.
-func (*RecordServiceGetResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use WebhookServiceUpdateRequest.ProtoReflect.Descriptor instead.
+func (*WebhookServiceUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *WebhookServiceUpdateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookServiceUpdateRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookServiceUpdateRequest) GetDomains() []string {
+	if x != nil {
+		return x.Domains
+	}
+	return nil
+}
+
+func (x *WebhookServiceUpdateRequest) GetEventTypes() []EventType {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *WebhookServiceUpdateRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
 }
 
-func (x *RecordServiceGetResponse) GetRecord() *Record {
-	if x != nil {
-		return x.Record
+func (x *WebhookServiceUpdateRequest) GetSecret() string {
+	if x != nil && x.Secret != nil {
+		return *x.Secret
 	}
-	return nil
+	return ""
 }
 
-type RecordServiceDeleteResponse struct {
+type WebhookServiceUpdateResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	Webhook *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
 }
 
-func (x *RecordServiceDeleteResponse) Reset() {
-	*x = RecordServiceDeleteResponse{}
+func (x *WebhookServiceUpdateResponse) Reset() {
+	*x = WebhookServiceUpdateResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[20]
+		mi := &file_api_v1_dns_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceDeleteResponse) String() string {
+func (x *WebhookServiceUpdateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceDeleteResponse) ProtoMessage() {}
+func (*WebhookServiceUpdateResponse) ProtoMessage() {}
 
-func (x *RecordServiceDeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[20]
+func (x *WebhookServiceUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1526,43 +3899,43 @@ func (x *RecordServiceDeleteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceDeleteResponse.ProtoReflect.Descriptor instead.
-func (*RecordServiceDeleteResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use WebhookServiceUpdateResponse.ProtoReflect.Descriptor instead.
+func (*WebhookServiceUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *RecordServiceDeleteResponse) GetRecord() *Record {
+func (x *WebhookServiceUpdateResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.Record
+		return x.Webhook
 	}
 	return nil
 }
 
-type RecordServiceUpdateResponse struct {
+type WebhookServiceDeleteRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *RecordServiceUpdateResponse) Reset() {
-	*x = RecordServiceUpdateResponse{}
+func (x *WebhookServiceDeleteRequest) Reset() {
+	*x = WebhookServiceDeleteRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[21]
+		mi := &file_api_v1_dns_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceUpdateResponse) String() string {
+func (x *WebhookServiceDeleteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceUpdateResponse) ProtoMessage() {}
+func (*WebhookServiceDeleteRequest) ProtoMessage() {}
 
-func (x *RecordServiceUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[21]
+func (x *WebhookServiceDeleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1573,43 +3946,43 @@ func (x *RecordServiceUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceUpdateResponse.ProtoReflect.Descriptor instead.
-func (*RecordServiceUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use WebhookServiceDeleteRequest.ProtoReflect.Descriptor instead.
+func (*WebhookServiceDeleteRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *RecordServiceUpdateResponse) GetRecord() *Record {
+func (x *WebhookServiceDeleteRequest) GetId() string {
 	if x != nil {
-		return x.Record
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type RecordServiceCreateResponse struct {
+type WebhookServiceDeleteResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Record *Record `protobuf:"bytes,1,opt,name=record,proto3" json:"record,omitempty"`
+	Webhook *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
 }
 
-func (x *RecordServiceCreateResponse) Reset() {
-	*x = RecordServiceCreateResponse{}
+func (x *WebhookServiceDeleteResponse) Reset() {
+	*x = WebhookServiceDeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_v1_dns_proto_msgTypes[22]
+		mi := &file_api_v1_dns_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RecordServiceCreateResponse) String() string {
+func (x *WebhookServiceDeleteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordServiceCreateResponse) ProtoMessage() {}
+func (*WebhookServiceDeleteResponse) ProtoMessage() {}
 
-func (x *RecordServiceCreateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_dns_proto_msgTypes[22]
+func (x *WebhookServiceDeleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_dns_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1620,14 +3993,14 @@ func (x *RecordServiceCreateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordServiceCreateResponse.ProtoReflect.Descriptor instead.
-func (*RecordServiceCreateResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_dns_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use WebhookServiceDeleteResponse.ProtoReflect.Descriptor instead.
+func (*WebhookServiceDeleteResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_dns_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *RecordServiceCreateResponse) GetRecord() *Record {
+func (x *WebhookServiceDeleteResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.Record
+		return x.Webhook
 	}
 	return nil
 }
@@ -1638,256 +4011,636 @@ var file_api_v1_dns_proto_rawDesc = []byte{
 	0x0a, 0x10, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x64, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x12, 0x06, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
 	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70,
-	0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa4, 0x01, 0x0a, 0x19, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75,
-	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
-	0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x07,
-	0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
-	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65,
-	0x73, 0x22, 0x32, 0x0a, 0x1a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75,
+	0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa4, 0x01, 0x0a, 0x19, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
+	0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x65,
+	0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x22, 0x32, 0x0a, 0x1a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x67, 0x0a, 0x1a, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x31, 0x0a, 0x07, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x94, 0x01,
+	0x0a, 0x1b, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x78,
+	0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x73, 0x22, 0xce, 0x02, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x69,
+	0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x73, 0x73,
+	0x75, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6a, 0x74, 0x69, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6a, 0x74, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72,
+	0x65, 0x12, 0x24, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xc8, 0x01, 0x0a, 0x17, 0x41, 0x75, 0x64, 0x69, 0x74, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x1d,
+	0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x01, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2e, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a,
+	0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x22, 0x48, 0x0a, 0x18, 0x41, 0x75, 0x64, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x22, 0x8f, 0x01, 0x0a, 0x06, 0x44,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x7a,
+	0x6f, 0x6e, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x7a, 0x6f, 0x6e, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x34, 0x0a, 0x18,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x73, 0x22, 0x58, 0x0a, 0x19, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xc8, 0x01, 0x0a,
+	0x1a, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x38, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x2d, 0x0a, 0x17, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x71, 0x0a, 0x1a, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x88, 0x01,
+	0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75, 0x72, 0x6c, 0x22, 0x71, 0x0a, 0x1a, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x12, 0x15, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75, 0x72, 0x6c, 0x22, 0x30, 0x0a, 0x1a,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x45,
+	0x0a, 0x19, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x07, 0x64, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x42, 0x0a, 0x18, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x45, 0x0a, 0x1b, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x22, 0x45, 0x0a, 0x1b, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
 	0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x8f, 0x01, 0x0a, 0x06, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x7a, 0x6f, 0x6e, 0x65, 0x5f, 0x66,
-	0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x7a, 0x6f, 0x6e, 0x65, 0x46,
-	0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x22, 0x34, 0x0a, 0x18, 0x44, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x2d, 0x0a,
-	0x17, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x71, 0x0a, 0x1a,
-	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20,
-	0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
-	0x12, 0x15, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
-	0x03, 0x75, 0x72, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75, 0x72, 0x6c, 0x22,
-	0x71, 0x0a, 0x1a, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52,
+	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x45, 0x0a, 0x1b, 0x44, 0x6f, 0x6d, 0x61, 0x69,
+	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x9a,
+	0x01, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x64, 0x75, 0x72, 0x65, 0x22, 0x9b, 0x01, 0x0a, 0x0f,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x62,
+	0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x62, 0x65, 0x66,
+	0x6f, 0x72, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x22, 0x37, 0x0a, 0x21, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x54, 0x0a, 0x22, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x67, 0x0a, 0x21, 0x44, 0x6f, 0x6d, 0x61,
+	0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x76,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
 	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x73,
-	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x73, 0x12, 0x15, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x48, 0x00, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75,
-	0x72, 0x6c, 0x22, 0x30, 0x0a, 0x1a, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x22, 0x45, 0x0a, 0x19, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x28, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61,
-	0x69, 0x6e, 0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x22, 0x42, 0x0a, 0x18, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22,
-	0x45, 0x0a, 0x1b, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26,
-	0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06,
-	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x45, 0x0a, 0x1b, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x22, 0x45, 0x0a,
-	0x1b, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06,
-	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x22, 0xda, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
-	0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12,
-	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12,
-	0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74,
-	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61,
-	0x67, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12,
-	0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61,
-	0x67, 0x22, 0x7c, 0x0a, 0x18, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x13, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x48, 0x00, 0x52, 0x02, 0x74, 0x6f, 0x88, 0x01, 0x01, 0x42, 0x05, 0x0a, 0x03, 0x5f, 0x74,
+	0x6f, 0x22, 0x57, 0x0a, 0x22, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x4e, 0x0a, 0x1c, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x7a, 0x0a, 0x1d, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x64,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x64, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xda, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03,
+	0x74, 0x74, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x74, 0x61, 0x67, 0x22, 0x7c, 0x0a, 0x18, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x22, 0xa0, 0x02, 0x0a, 0x1a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74,
+	0x74, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c,
+	0x61, 0x67, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73,
+	0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74,
+	0x61, 0x67, 0x12, 0x30, 0x0a, 0x14, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x70,
+	0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x12, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb4, 0x02, 0x0a, 0x1a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x75, 0x75, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x30, 0x0a, 0x14, 0x77, 0x61, 0x69,
+	0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72,
+	0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9e, 0x01, 0x0a, 0x1a,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x30, 0x0a, 0x14, 0x77, 0x61,
+	0x69, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x77, 0x61, 0x69, 0x74, 0x46, 0x6f,
+	0x72, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa1, 0x01, 0x0a,
+	0x1a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x56, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x33, 0x0a, 0x07, 0x74,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x22, 0x56, 0x0a, 0x19, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
 	0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64,
-	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x22,
-	0xee, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1a,
-	0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c,
-	0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
-	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x10,
-	0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67,
-	0x22, 0x82, 0x02, 0x0a, 0x1a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x75, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75,
-	0x75, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12,
-	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70,
-	0x6f, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x6c,
-	0x61, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x6c, 0x0a, 0x1a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64,
-	0x61, 0x74, 0x61, 0x22, 0x45, 0x0a, 0x19, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xe0, 0x01, 0x0a, 0x1a, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x38, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x45, 0x0a, 0x19, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x73, 0x22, 0x42, 0x0a, 0x18, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26,
+	0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06,
+	0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x7c, 0x0a, 0x1b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x35, 0x0a,
+	0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70,
+	0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x1b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x35, 0x0a, 0x0b, 0x70,
+	0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x7c, 0x0a, 0x1b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x26, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x42, 0x0a, 0x18, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x45,
-	0x0a, 0x1b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a,
-	0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x45, 0x0a, 0x1b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
-	0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x22, 0x45, 0x0a, 0x1b,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x72,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x06, 0x72, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x2a, 0xa6, 0x04, 0x0a, 0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
-	0x05, 0x0a, 0x01, 0x41, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x41, 0x36, 0x10, 0x02, 0x12, 0x08,
-	0x0a, 0x04, 0x41, 0x41, 0x41, 0x41, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x46, 0x53, 0x44,
-	0x42, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4c, 0x49, 0x41, 0x53, 0x10, 0x05, 0x12, 0x07,
-	0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x41, 0x41, 0x10, 0x07,
-	0x12, 0x0b, 0x0a, 0x07, 0x43, 0x44, 0x4e, 0x53, 0x4b, 0x45, 0x59, 0x10, 0x08, 0x12, 0x07, 0x0a,
-	0x03, 0x43, 0x44, 0x53, 0x10, 0x09, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x45, 0x52, 0x54, 0x10, 0x0a,
-	0x12, 0x09, 0x0a, 0x05, 0x43, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x0b, 0x12, 0x09, 0x0a, 0x05, 0x44,
-	0x48, 0x43, 0x49, 0x44, 0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x44, 0x4c, 0x56, 0x10, 0x0d, 0x12,
-	0x09, 0x0a, 0x05, 0x44, 0x4e, 0x41, 0x4d, 0x45, 0x10, 0x0e, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4e,
-	0x53, 0x4b, 0x45, 0x59, 0x10, 0x0f, 0x12, 0x06, 0x0a, 0x02, 0x44, 0x53, 0x10, 0x10, 0x12, 0x09,
-	0x0a, 0x05, 0x45, 0x55, 0x49, 0x34, 0x38, 0x10, 0x11, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x55, 0x49,
-	0x36, 0x34, 0x10, 0x12, 0x12, 0x09, 0x0a, 0x05, 0x48, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x13, 0x12,
-	0x0c, 0x0a, 0x08, 0x49, 0x50, 0x53, 0x45, 0x43, 0x4b, 0x45, 0x59, 0x10, 0x14, 0x12, 0x07, 0x0a,
-	0x03, 0x4b, 0x45, 0x59, 0x10, 0x15, 0x12, 0x06, 0x0a, 0x02, 0x4b, 0x58, 0x10, 0x16, 0x12, 0x07,
-	0x0a, 0x03, 0x4c, 0x4f, 0x43, 0x10, 0x17, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x55, 0x41, 0x10, 0x18,
-	0x12, 0x09, 0x0a, 0x05, 0x4d, 0x41, 0x49, 0x4c, 0x41, 0x10, 0x19, 0x12, 0x09, 0x0a, 0x05, 0x4d,
-	0x41, 0x49, 0x4c, 0x42, 0x10, 0x1a, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x49, 0x4e, 0x46, 0x4f, 0x10,
-	0x1b, 0x12, 0x06, 0x0a, 0x02, 0x4d, 0x52, 0x10, 0x1c, 0x12, 0x06, 0x0a, 0x02, 0x4d, 0x58, 0x10,
-	0x1d, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x41, 0x50, 0x54, 0x52, 0x10, 0x1e, 0x12, 0x06, 0x0a, 0x02,
-	0x4e, 0x53, 0x10, 0x1f, 0x12, 0x08, 0x0a, 0x04, 0x4e, 0x53, 0x45, 0x43, 0x10, 0x20, 0x12, 0x09,
-	0x0a, 0x05, 0x4e, 0x53, 0x45, 0x43, 0x33, 0x10, 0x21, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x53, 0x45,
-	0x43, 0x33, 0x50, 0x41, 0x52, 0x41, 0x4d, 0x10, 0x22, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x45,
-	0x4e, 0x50, 0x47, 0x50, 0x4b, 0x45, 0x59, 0x10, 0x23, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x54, 0x52,
-	0x10, 0x24, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x4b, 0x45, 0x59, 0x10, 0x25, 0x12, 0x06, 0x0a, 0x02,
-	0x52, 0x50, 0x10, 0x26, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x52, 0x53, 0x49, 0x47, 0x10, 0x27, 0x12,
-	0x07, 0x0a, 0x03, 0x53, 0x49, 0x47, 0x10, 0x28, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4d, 0x49, 0x4d,
-	0x45, 0x41, 0x10, 0x29, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x4f, 0x41, 0x10, 0x2a, 0x12, 0x07, 0x0a,
-	0x03, 0x53, 0x50, 0x46, 0x10, 0x2b, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x52, 0x56, 0x10, 0x2c, 0x12,
-	0x09, 0x0a, 0x05, 0x53, 0x53, 0x48, 0x46, 0x50, 0x10, 0x2d, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x4b,
-	0x45, 0x59, 0x10, 0x2e, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x4c, 0x53, 0x41, 0x10, 0x2f, 0x12, 0x08,
-	0x0a, 0x04, 0x54, 0x53, 0x49, 0x47, 0x10, 0x30, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x58, 0x54, 0x10,
-	0x31, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x52, 0x49, 0x10, 0x32, 0x12, 0x07, 0x0a, 0x03, 0x57, 0x4b,
-	0x53, 0x10, 0x33, 0x12, 0x07, 0x0a, 0x03, 0x5a, 0x5a, 0x5a, 0x10, 0x34, 0x32, 0x5f, 0x0a, 0x0c,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4f, 0x0a, 0x06,
-	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x9f, 0x03,
-	0x0a, 0x0d, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x4b, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69,
-	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x03,
-	0x47, 0x65, 0x74, 0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f,
-	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x55, 0x70, 0x64,
+	0x64, 0x52, 0x06, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x35, 0x0a, 0x0b, 0x70, 0x72, 0x6f,
+	0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x54, 0x0a, 0x1b, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x35, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x6f, 0x70, 0x61, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x70, 0x61,
+	0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x7f, 0x0a, 0x0b, 0x50, 0x72, 0x6f, 0x70, 0x61, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67, 0x61,
+	0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x61,
+	0x67, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3a, 0x0a, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x96, 0x01, 0x0a, 0x10, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x61, 0x67,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70,
+	0x61, 0x67, 0x61, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0x5c, 0x0a, 0x1e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x74, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x22, 0x39,
+	0x0a, 0x1f, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x4a, 0x0a, 0x1e, 0x43, 0x68, 0x61,
+	0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x65,
+	0x61, 0x6e, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x39, 0x0a, 0x1f, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x55, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73,
+	0x22, 0xa9, 0x02, 0x0a, 0x07, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xb7, 0x01, 0x0a,
+	0x1b, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18,
+	0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
+	0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x49, 0x0a, 0x1c, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x07, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f,
+	0x6b, 0x22, 0x2a, 0x0a, 0x18, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x46, 0x0a,
+	0x19, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x07, 0x77, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x22, 0x1b, 0x0a, 0x19, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x22, 0x49, 0x0a, 0x1a, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2b, 0x0a, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x52, 0x08, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x73, 0x22, 0xd7, 0x01,
+	0x0a, 0x1b, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x07, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x11,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1b, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x48,
+	0x00, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x88, 0x01, 0x01, 0x42, 0x09, 0x0a, 0x07,
+	0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x49, 0x0a, 0x1c, 0x57, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x77, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x52, 0x07, 0x77, 0x65, 0x62, 0x68, 0x6f,
+	0x6f, 0x6b, 0x22, 0x2d, 0x0a, 0x1b, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x49, 0x0a, 0x1c, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x29, 0x0a, 0x07, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x52, 0x07, 0x77, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x2a, 0xa6, 0x04, 0x0a,
+	0x0a, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x05, 0x0a, 0x01, 0x41, 0x10, 0x01, 0x12,
+	0x06, 0x0a, 0x02, 0x41, 0x36, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x41, 0x41, 0x41, 0x41, 0x10,
+	0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x46, 0x53, 0x44, 0x42, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05,
+	0x41, 0x4c, 0x49, 0x41, 0x53, 0x10, 0x05, 0x12, 0x07, 0x0a, 0x03, 0x41, 0x4e, 0x59, 0x10, 0x06,
+	0x12, 0x07, 0x0a, 0x03, 0x43, 0x41, 0x41, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x44, 0x4e,
+	0x53, 0x4b, 0x45, 0x59, 0x10, 0x08, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x44, 0x53, 0x10, 0x09, 0x12,
+	0x08, 0x0a, 0x04, 0x43, 0x45, 0x52, 0x54, 0x10, 0x0a, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x4e, 0x41,
+	0x4d, 0x45, 0x10, 0x0b, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x48, 0x43, 0x49, 0x44, 0x10, 0x0c, 0x12,
+	0x07, 0x0a, 0x03, 0x44, 0x4c, 0x56, 0x10, 0x0d, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x4e, 0x41, 0x4d,
+	0x45, 0x10, 0x0e, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x4e, 0x53, 0x4b, 0x45, 0x59, 0x10, 0x0f, 0x12,
+	0x06, 0x0a, 0x02, 0x44, 0x53, 0x10, 0x10, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x55, 0x49, 0x34, 0x38,
+	0x10, 0x11, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x55, 0x49, 0x36, 0x34, 0x10, 0x12, 0x12, 0x09, 0x0a,
+	0x05, 0x48, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x13, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x50, 0x53, 0x45,
+	0x43, 0x4b, 0x45, 0x59, 0x10, 0x14, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x59, 0x10, 0x15, 0x12,
+	0x06, 0x0a, 0x02, 0x4b, 0x58, 0x10, 0x16, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x43, 0x10, 0x17,
+	0x12, 0x07, 0x0a, 0x03, 0x4c, 0x55, 0x41, 0x10, 0x18, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x41, 0x49,
+	0x4c, 0x41, 0x10, 0x19, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x41, 0x49, 0x4c, 0x42, 0x10, 0x1a, 0x12,
+	0x09, 0x0a, 0x05, 0x4d, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x1b, 0x12, 0x06, 0x0a, 0x02, 0x4d, 0x52,
+	0x10, 0x1c, 0x12, 0x06, 0x0a, 0x02, 0x4d, 0x58, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x41,
+	0x50, 0x54, 0x52, 0x10, 0x1e, 0x12, 0x06, 0x0a, 0x02, 0x4e, 0x53, 0x10, 0x1f, 0x12, 0x08, 0x0a,
+	0x04, 0x4e, 0x53, 0x45, 0x43, 0x10, 0x20, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x53, 0x45, 0x43, 0x33,
+	0x10, 0x21, 0x12, 0x0e, 0x0a, 0x0a, 0x4e, 0x53, 0x45, 0x43, 0x33, 0x50, 0x41, 0x52, 0x41, 0x4d,
+	0x10, 0x22, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x50, 0x45, 0x4e, 0x50, 0x47, 0x50, 0x4b, 0x45, 0x59,
+	0x10, 0x23, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x54, 0x52, 0x10, 0x24, 0x12, 0x08, 0x0a, 0x04, 0x52,
+	0x4b, 0x45, 0x59, 0x10, 0x25, 0x12, 0x06, 0x0a, 0x02, 0x52, 0x50, 0x10, 0x26, 0x12, 0x09, 0x0a,
+	0x05, 0x52, 0x52, 0x53, 0x49, 0x47, 0x10, 0x27, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x49, 0x47, 0x10,
+	0x28, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4d, 0x49, 0x4d, 0x45, 0x41, 0x10, 0x29, 0x12, 0x07, 0x0a,
+	0x03, 0x53, 0x4f, 0x41, 0x10, 0x2a, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x50, 0x46, 0x10, 0x2b, 0x12,
+	0x07, 0x0a, 0x03, 0x53, 0x52, 0x56, 0x10, 0x2c, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x53, 0x48, 0x46,
+	0x50, 0x10, 0x2d, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x4b, 0x45, 0x59, 0x10, 0x2e, 0x12, 0x08, 0x0a,
+	0x04, 0x54, 0x4c, 0x53, 0x41, 0x10, 0x2f, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x53, 0x49, 0x47, 0x10,
+	0x30, 0x12, 0x07, 0x0a, 0x03, 0x54, 0x58, 0x54, 0x10, 0x31, 0x12, 0x07, 0x0a, 0x03, 0x55, 0x52,
+	0x49, 0x10, 0x32, 0x12, 0x07, 0x0a, 0x03, 0x57, 0x4b, 0x53, 0x10, 0x33, 0x12, 0x07, 0x0a, 0x03,
+	0x5a, 0x5a, 0x5a, 0x10, 0x34, 0x2a, 0x88, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x52,
+	0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12,
+	0x16, 0x0a, 0x12, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45,
+	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x45, 0x56, 0x45, 0x4e, 0x54,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4b, 0x4d, 0x41, 0x52, 0x4b, 0x10, 0x04,
+	0x32, 0x5f, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x4f, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x32, 0x62, 0x0a, 0x0c, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x52, 0x0a, 0x07, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x12, 0x22, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x7a, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x78, 0x70, 0x6c, 0x61, 0x69, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x59, 0x0a, 0x0c, 0x41, 0x75, 0x64, 0x69, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x49, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x32, 0x9a, 0x06, 0x0a, 0x0d, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x4b, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x48, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x1f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d,
-	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
-	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70,
-	0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06,
+	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x51, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x66, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d,
+	0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65,
+	0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x66, 0x0a, 0x0d, 0x44, 0x69,
+	0x66, 0x66, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x29, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x44, 0x69, 0x66, 0x66, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x69, 0x66,
+	0x66, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x57, 0x0a, 0x08, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x24,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x6f, 0x6c, 0x6c, 0x62,
+	0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x05, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f,
+	0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x32, 0xfa, 0x03,
+	0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x4b, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06,
 	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
-	0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c,
 	0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69,
-	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0xd5, 0x02, 0x0a, 0x0d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x12, 0x4b, 0x0a, 0x04, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x51, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e,
 	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51,
-	0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61,
 	0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x51, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x22, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x22,
-	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x7a, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x2e, 0x61,
-	0x70, 0x69, 0x2e, 0x76, 0x31, 0x42, 0x08, 0x44, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50,
-	0x01, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x61,
-	0x6a, 0x73, 0x74, 0x30, 0x31, 0x2f, 0x6d, 0x65, 0x74, 0x61, 0x6c, 0x2d, 0x64, 0x6e, 0x73, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x70, 0x69, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41,
-	0x58, 0x58, 0xaa, 0x02, 0x06, 0x41, 0x70, 0x69, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x06, 0x41, 0x70,
-	0x69, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x12, 0x41, 0x70, 0x69, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50,
-	0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x41, 0x70, 0x69, 0x3a,
-	0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x06, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12,
+	0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x32, 0xca, 0x01, 0x0a, 0x10, 0x43,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x5a, 0x0a, 0x07, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c,
+	0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x50, 0x72, 0x65, 0x73,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5a, 0x0a, 0x07, 0x43,
+	0x6c, 0x65, 0x61, 0x6e, 0x55, 0x70, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x43, 0x6c, 0x65, 0x61, 0x6e, 0x55, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67,
+	0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x55, 0x70, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xaa, 0x03, 0x0a, 0x0e, 0x57, 0x65, 0x62, 0x68,
+	0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x06, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4a, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x20, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x04, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62,
+	0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x06, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65,
+	0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x53, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x65, 0x62, 0x68, 0x6f, 0x6f, 0x6b, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1902,77 +4655,184 @@ func file_api_v1_dns_proto_rawDescGZIP() []byte {
 	return file_api_v1_dns_proto_rawDescData
 }
 
-var file_api_v1_dns_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_v1_dns_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_api_v1_dns_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_v1_dns_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
 var file_api_v1_dns_proto_goTypes = []interface{}{
-	(RecordType)(0),                     // 0: api.v1.RecordType
-	(*TokenServiceCreateRequest)(nil),   // 1: api.v1.TokenServiceCreateRequest
-	(*TokenServiceCreateResponse)(nil),  // 2: api.v1.TokenServiceCreateResponse
-	(*Domain)(nil),                      // 3: api.v1.Domain
-	(*DomainServiceListRequest)(nil),    // 4: api.v1.DomainServiceListRequest
-	(*DomainServiceGetRequest)(nil),     // 5: api.v1.DomainServiceGetRequest
-	(*DomainServiceCreateRequest)(nil),  // 6: api.v1.DomainServiceCreateRequest
-	(*DomainServiceUpdateRequest)(nil),  // 7: api.v1.DomainServiceUpdateRequest
-	(*DomainServiceDeleteRequest)(nil),  // 8: api.v1.DomainServiceDeleteRequest
-	(*DomainServiceListResponse)(nil),   // 9: api.v1.DomainServiceListResponse
-	(*DomainServiceGetResponse)(nil),    // 10: api.v1.DomainServiceGetResponse
-	(*DomainServiceUpdateResponse)(nil), // 11: api.v1.DomainServiceUpdateResponse
-	(*DomainServiceCreateResponse)(nil), // 12: api.v1.DomainServiceCreateResponse
-	(*DomainServiceDeleteResponse)(nil), // 13: api.v1.DomainServiceDeleteResponse
-	(*Record)(nil),                      // 14: api.v1.Record
-	(*RecordServiceListRequest)(nil),    // 15: api.v1.RecordServiceListRequest
-	(*RecordServiceCreateRequest)(nil),  // 16: api.v1.RecordServiceCreateRequest
-	(*RecordServiceUpdateRequest)(nil),  // 17: api.v1.RecordServiceUpdateRequest
-	(*RecordServiceDeleteRequest)(nil),  // 18: api.v1.RecordServiceDeleteRequest
-	(*RecordServiceListResponse)(nil),   // 19: api.v1.RecordServiceListResponse
-	(*RecordServiceGetResponse)(nil),    // 20: api.v1.RecordServiceGetResponse
-	(*RecordServiceDeleteResponse)(nil), // 21: api.v1.RecordServiceDeleteResponse
-	(*RecordServiceUpdateResponse)(nil), // 22: api.v1.RecordServiceUpdateResponse
-	(*RecordServiceCreateResponse)(nil), // 23: api.v1.RecordServiceCreateResponse
-	(*durationpb.Duration)(nil),         // 24: google.protobuf.Duration
+	(RecordType)(0),                            // 0: api.v1.RecordType
+	(EventType)(0),                             // 1: api.v1.EventType
+	(*TokenServiceCreateRequest)(nil),          // 2: api.v1.TokenServiceCreateRequest
+	(*TokenServiceCreateResponse)(nil),         // 3: api.v1.TokenServiceCreateResponse
+	(*AuthzServiceExplainRequest)(nil),         // 4: api.v1.AuthzServiceExplainRequest
+	(*AuthzServiceExplainResponse)(nil),        // 5: api.v1.AuthzServiceExplainResponse
+	(*AuditEntry)(nil),                         // 6: api.v1.AuditEntry
+	(*AuditServiceListRequest)(nil),            // 7: api.v1.AuditServiceListRequest
+	(*AuditServiceListResponse)(nil),           // 8: api.v1.AuditServiceListResponse
+	(*Domain)(nil),                             // 9: api.v1.Domain
+	(*DomainServiceListRequest)(nil),           // 10: api.v1.DomainServiceListRequest
+	(*DomainServiceWatchRequest)(nil),          // 11: api.v1.DomainServiceWatchRequest
+	(*DomainServiceWatchResponse)(nil),         // 12: api.v1.DomainServiceWatchResponse
+	(*DomainServiceGetRequest)(nil),            // 13: api.v1.DomainServiceGetRequest
+	(*DomainServiceCreateRequest)(nil),         // 14: api.v1.DomainServiceCreateRequest
+	(*DomainServiceUpdateRequest)(nil),         // 15: api.v1.DomainServiceUpdateRequest
+	(*DomainServiceDeleteRequest)(nil),         // 16: api.v1.DomainServiceDeleteRequest
+	(*DomainServiceListResponse)(nil),          // 17: api.v1.DomainServiceListResponse
+	(*DomainServiceGetResponse)(nil),           // 18: api.v1.DomainServiceGetResponse
+	(*DomainServiceUpdateResponse)(nil),        // 19: api.v1.DomainServiceUpdateResponse
+	(*DomainServiceCreateResponse)(nil),        // 20: api.v1.DomainServiceCreateResponse
+	(*DomainServiceDeleteResponse)(nil),        // 21: api.v1.DomainServiceDeleteResponse
+	(*Revision)(nil),                           // 22: api.v1.Revision
+	(*RecordSetChange)(nil),                    // 23: api.v1.RecordSetChange
+	(*DomainServiceListRevisionsRequest)(nil),  // 24: api.v1.DomainServiceListRevisionsRequest
+	(*DomainServiceListRevisionsResponse)(nil), // 25: api.v1.DomainServiceListRevisionsResponse
+	(*DomainServiceDiffRevisionsRequest)(nil),  // 26: api.v1.DomainServiceDiffRevisionsRequest
+	(*DomainServiceDiffRevisionsResponse)(nil), // 27: api.v1.DomainServiceDiffRevisionsResponse
+	(*DomainServiceRollbackRequest)(nil),       // 28: api.v1.DomainServiceRollbackRequest
+	(*DomainServiceRollbackResponse)(nil),      // 29: api.v1.DomainServiceRollbackResponse
+	(*Record)(nil),                             // 30: api.v1.Record
+	(*RecordServiceListRequest)(nil),           // 31: api.v1.RecordServiceListRequest
+	(*RecordServiceCreateRequest)(nil),         // 32: api.v1.RecordServiceCreateRequest
+	(*RecordServiceUpdateRequest)(nil),         // 33: api.v1.RecordServiceUpdateRequest
+	(*RecordServiceDeleteRequest)(nil),         // 34: api.v1.RecordServiceDeleteRequest
+	(*RecordServiceVerifyRequest)(nil),         // 35: api.v1.RecordServiceVerifyRequest
+	(*RecordServiceWatchRequest)(nil),          // 36: api.v1.RecordServiceWatchRequest
+	(*RecordServiceWatchResponse)(nil),         // 37: api.v1.RecordServiceWatchResponse
+	(*RecordServiceListResponse)(nil),          // 38: api.v1.RecordServiceListResponse
+	(*RecordServiceGetResponse)(nil),           // 39: api.v1.RecordServiceGetResponse
+	(*RecordServiceDeleteResponse)(nil),        // 40: api.v1.RecordServiceDeleteResponse
+	(*RecordServiceUpdateResponse)(nil),        // 41: api.v1.RecordServiceUpdateResponse
+	(*RecordServiceCreateResponse)(nil),        // 42: api.v1.RecordServiceCreateResponse
+	(*RecordServiceVerifyResponse)(nil),        // 43: api.v1.RecordServiceVerifyResponse
+	(*Propagation)(nil),                        // 44: api.v1.Propagation
+	(*NameserverStatus)(nil),                   // 45: api.v1.NameserverStatus
+	(*ChallengeServicePresentRequest)(nil),     // 46: api.v1.ChallengeServicePresentRequest
+	(*ChallengeServicePresentResponse)(nil),    // 47: api.v1.ChallengeServicePresentResponse
+	(*ChallengeServiceCleanUpRequest)(nil),     // 48: api.v1.ChallengeServiceCleanUpRequest
+	(*ChallengeServiceCleanUpResponse)(nil),    // 49: api.v1.ChallengeServiceCleanUpResponse
+	(*Webhook)(nil),                            // 50: api.v1.Webhook
+	(*WebhookServiceCreateRequest)(nil),        // 51: api.v1.WebhookServiceCreateRequest
+	(*WebhookServiceCreateResponse)(nil),       // 52: api.v1.WebhookServiceCreateResponse
+	(*WebhookServiceGetRequest)(nil),           // 53: api.v1.WebhookServiceGetRequest
+	(*WebhookServiceGetResponse)(nil),          // 54: api.v1.WebhookServiceGetResponse
+	(*WebhookServiceListRequest)(nil),          // 55: api.v1.WebhookServiceListRequest
+	(*WebhookServiceListResponse)(nil),         // 56: api.v1.WebhookServiceListResponse
+	(*WebhookServiceUpdateRequest)(nil),        // 57: api.v1.WebhookServiceUpdateRequest
+	(*WebhookServiceUpdateResponse)(nil),       // 58: api.v1.WebhookServiceUpdateResponse
+	(*WebhookServiceDeleteRequest)(nil),        // 59: api.v1.WebhookServiceDeleteRequest
+	(*WebhookServiceDeleteResponse)(nil),       // 60: api.v1.WebhookServiceDeleteResponse
+	(*durationpb.Duration)(nil),                // 61: google.protobuf.Duration
+	(*structpb.Struct)(nil),                    // 62: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),              // 63: google.protobuf.Timestamp
 }
 var file_api_v1_dns_proto_depIdxs = []int32{
-	24, // 0: api.v1.TokenServiceCreateRequest.expires:type_name -> google.protobuf.Duration
-	3,  // 1: api.v1.DomainServiceListResponse.domains:type_name -> api.v1.Domain
-	3,  // 2: api.v1.DomainServiceGetResponse.domain:type_name -> api.v1.Domain
-	3,  // 3: api.v1.DomainServiceUpdateResponse.domain:type_name -> api.v1.Domain
-	3,  // 4: api.v1.DomainServiceCreateResponse.domain:type_name -> api.v1.Domain
-	3,  // 5: api.v1.DomainServiceDeleteResponse.domain:type_name -> api.v1.Domain
-	0,  // 6: api.v1.Record.type:type_name -> api.v1.RecordType
-	0,  // 7: api.v1.RecordServiceListRequest.type:type_name -> api.v1.RecordType
-	0,  // 8: api.v1.RecordServiceCreateRequest.type:type_name -> api.v1.RecordType
-	0,  // 9: api.v1.RecordServiceUpdateRequest.type:type_name -> api.v1.RecordType
-	0,  // 10: api.v1.RecordServiceDeleteRequest.type:type_name -> api.v1.RecordType
-	14, // 11: api.v1.RecordServiceListResponse.records:type_name -> api.v1.Record
-	14, // 12: api.v1.RecordServiceGetResponse.record:type_name -> api.v1.Record
-	14, // 13: api.v1.RecordServiceDeleteResponse.record:type_name -> api.v1.Record
-	14, // 14: api.v1.RecordServiceUpdateResponse.record:type_name -> api.v1.Record
-	14, // 15: api.v1.RecordServiceCreateResponse.record:type_name -> api.v1.Record
-	1,  // 16: api.v1.TokenService.Create:input_type -> api.v1.TokenServiceCreateRequest
-	4,  // 17: api.v1.DomainService.List:input_type -> api.v1.DomainServiceListRequest
-	5,  // 18: api.v1.DomainService.Get:input_type -> api.v1.DomainServiceGetRequest
-	6,  // 19: api.v1.DomainService.Create:input_type -> api.v1.DomainServiceCreateRequest
-	7,  // 20: api.v1.DomainService.Update:input_type -> api.v1.DomainServiceUpdateRequest
-	8,  // 21: api.v1.DomainService.Delete:input_type -> api.v1.DomainServiceDeleteRequest
-	15, // 22: api.v1.RecordService.List:input_type -> api.v1.RecordServiceListRequest
-	18, // 23: api.v1.RecordService.Delete:input_type -> api.v1.RecordServiceDeleteRequest
-	17, // 24: api.v1.RecordService.Update:input_type -> api.v1.RecordServiceUpdateRequest
-	16, // 25: api.v1.RecordService.Create:input_type -> api.v1.RecordServiceCreateRequest
-	2,  // 26: api.v1.TokenService.Create:output_type -> api.v1.TokenServiceCreateResponse
-	9,  // 27: api.v1.DomainService.List:output_type -> api.v1.DomainServiceListResponse
-	10, // 28: api.v1.DomainService.Get:output_type -> api.v1.DomainServiceGetResponse
-	12, // 29: api.v1.DomainService.Create:output_type -> api.v1.DomainServiceCreateResponse
-	11, // 30: api.v1.DomainService.Update:output_type -> api.v1.DomainServiceUpdateResponse
-	13, // 31: api.v1.DomainService.Delete:output_type -> api.v1.DomainServiceDeleteResponse
-	19, // 32: api.v1.RecordService.List:output_type -> api.v1.RecordServiceListResponse
-	21, // 33: api.v1.RecordService.Delete:output_type -> api.v1.RecordServiceDeleteResponse
-	22, // 34: api.v1.RecordService.Update:output_type -> api.v1.RecordServiceUpdateResponse
-	23, // 35: api.v1.RecordService.Create:output_type -> api.v1.RecordServiceCreateResponse
-	26, // [26:36] is the sub-list for method output_type
-	16, // [16:26] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	61, // 0: api.v1.TokenServiceCreateRequest.expires:type_name -> google.protobuf.Duration
+	62, // 1: api.v1.AuthzServiceExplainRequest.request:type_name -> google.protobuf.Struct
+	63, // 2: api.v1.AuditEntry.time:type_name -> google.protobuf.Timestamp
+	30, // 3: api.v1.AuditEntry.before:type_name -> api.v1.Record
+	30, // 4: api.v1.AuditEntry.after:type_name -> api.v1.Record
+	63, // 5: api.v1.AuditServiceListRequest.from:type_name -> google.protobuf.Timestamp
+	63, // 6: api.v1.AuditServiceListRequest.to:type_name -> google.protobuf.Timestamp
+	6,  // 7: api.v1.AuditServiceListResponse.entries:type_name -> api.v1.AuditEntry
+	1,  // 8: api.v1.DomainServiceWatchResponse.type:type_name -> api.v1.EventType
+	9,  // 9: api.v1.DomainServiceWatchResponse.domain:type_name -> api.v1.Domain
+	63, // 10: api.v1.DomainServiceWatchResponse.timestamp:type_name -> google.protobuf.Timestamp
+	9,  // 11: api.v1.DomainServiceListResponse.domains:type_name -> api.v1.Domain
+	9,  // 12: api.v1.DomainServiceGetResponse.domain:type_name -> api.v1.Domain
+	9,  // 13: api.v1.DomainServiceUpdateResponse.domain:type_name -> api.v1.Domain
+	9,  // 14: api.v1.DomainServiceCreateResponse.domain:type_name -> api.v1.Domain
+	9,  // 15: api.v1.DomainServiceDeleteResponse.domain:type_name -> api.v1.Domain
+	63, // 16: api.v1.Revision.time:type_name -> google.protobuf.Timestamp
+	0,  // 17: api.v1.RecordSetChange.type:type_name -> api.v1.RecordType
+	30, // 18: api.v1.RecordSetChange.before:type_name -> api.v1.Record
+	30, // 19: api.v1.RecordSetChange.after:type_name -> api.v1.Record
+	22, // 20: api.v1.DomainServiceListRevisionsResponse.revisions:type_name -> api.v1.Revision
+	23, // 21: api.v1.DomainServiceDiffRevisionsResponse.changes:type_name -> api.v1.RecordSetChange
+	9,  // 22: api.v1.DomainServiceRollbackResponse.domain:type_name -> api.v1.Domain
+	23, // 23: api.v1.DomainServiceRollbackResponse.changes:type_name -> api.v1.RecordSetChange
+	0,  // 24: api.v1.Record.type:type_name -> api.v1.RecordType
+	0,  // 25: api.v1.RecordServiceListRequest.type:type_name -> api.v1.RecordType
+	0,  // 26: api.v1.RecordServiceCreateRequest.type:type_name -> api.v1.RecordType
+	0,  // 27: api.v1.RecordServiceUpdateRequest.type:type_name -> api.v1.RecordType
+	0,  // 28: api.v1.RecordServiceDeleteRequest.type:type_name -> api.v1.RecordType
+	0,  // 29: api.v1.RecordServiceVerifyRequest.type:type_name -> api.v1.RecordType
+	61, // 30: api.v1.RecordServiceVerifyRequest.timeout:type_name -> google.protobuf.Duration
+	1,  // 31: api.v1.RecordServiceWatchResponse.type:type_name -> api.v1.EventType
+	30, // 32: api.v1.RecordServiceWatchResponse.record:type_name -> api.v1.Record
+	63, // 33: api.v1.RecordServiceWatchResponse.timestamp:type_name -> google.protobuf.Timestamp
+	30, // 34: api.v1.RecordServiceListResponse.records:type_name -> api.v1.Record
+	30, // 35: api.v1.RecordServiceGetResponse.record:type_name -> api.v1.Record
+	30, // 36: api.v1.RecordServiceDeleteResponse.record:type_name -> api.v1.Record
+	44, // 37: api.v1.RecordServiceDeleteResponse.propagation:type_name -> api.v1.Propagation
+	30, // 38: api.v1.RecordServiceUpdateResponse.record:type_name -> api.v1.Record
+	44, // 39: api.v1.RecordServiceUpdateResponse.propagation:type_name -> api.v1.Propagation
+	30, // 40: api.v1.RecordServiceCreateResponse.record:type_name -> api.v1.Record
+	44, // 41: api.v1.RecordServiceCreateResponse.propagation:type_name -> api.v1.Propagation
+	44, // 42: api.v1.RecordServiceVerifyResponse.propagation:type_name -> api.v1.Propagation
+	45, // 43: api.v1.Propagation.nameservers:type_name -> api.v1.NameserverStatus
+	1,  // 44: api.v1.Webhook.event_types:type_name -> api.v1.EventType
+	63, // 45: api.v1.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	63, // 46: api.v1.Webhook.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 47: api.v1.WebhookServiceCreateRequest.event_types:type_name -> api.v1.EventType
+	50, // 48: api.v1.WebhookServiceCreateResponse.webhook:type_name -> api.v1.Webhook
+	50, // 49: api.v1.WebhookServiceGetResponse.webhook:type_name -> api.v1.Webhook
+	50, // 50: api.v1.WebhookServiceListResponse.webhooks:type_name -> api.v1.Webhook
+	1,  // 51: api.v1.WebhookServiceUpdateRequest.event_types:type_name -> api.v1.EventType
+	50, // 52: api.v1.WebhookServiceUpdateResponse.webhook:type_name -> api.v1.Webhook
+	50, // 53: api.v1.WebhookServiceDeleteResponse.webhook:type_name -> api.v1.Webhook
+	2,  // 54: api.v1.TokenService.Create:input_type -> api.v1.TokenServiceCreateRequest
+	4,  // 55: api.v1.AuthzService.Explain:input_type -> api.v1.AuthzServiceExplainRequest
+	7,  // 56: api.v1.AuditService.List:input_type -> api.v1.AuditServiceListRequest
+	10, // 57: api.v1.DomainService.List:input_type -> api.v1.DomainServiceListRequest
+	13, // 58: api.v1.DomainService.Get:input_type -> api.v1.DomainServiceGetRequest
+	14, // 59: api.v1.DomainService.Create:input_type -> api.v1.DomainServiceCreateRequest
+	15, // 60: api.v1.DomainService.Update:input_type -> api.v1.DomainServiceUpdateRequest
+	16, // 61: api.v1.DomainService.Delete:input_type -> api.v1.DomainServiceDeleteRequest
+	24, // 62: api.v1.DomainService.ListRevisions:input_type -> api.v1.DomainServiceListRevisionsRequest
+	26, // 63: api.v1.DomainService.DiffRevisions:input_type -> api.v1.DomainServiceDiffRevisionsRequest
+	28, // 64: api.v1.DomainService.Rollback:input_type -> api.v1.DomainServiceRollbackRequest
+	11, // 65: api.v1.DomainService.Watch:input_type -> api.v1.DomainServiceWatchRequest
+	31, // 66: api.v1.RecordService.List:input_type -> api.v1.RecordServiceListRequest
+	34, // 67: api.v1.RecordService.Delete:input_type -> api.v1.RecordServiceDeleteRequest
+	33, // 68: api.v1.RecordService.Update:input_type -> api.v1.RecordServiceUpdateRequest
+	32, // 69: api.v1.RecordService.Create:input_type -> api.v1.RecordServiceCreateRequest
+	35, // 70: api.v1.RecordService.Verify:input_type -> api.v1.RecordServiceVerifyRequest
+	36, // 71: api.v1.RecordService.Watch:input_type -> api.v1.RecordServiceWatchRequest
+	46, // 72: api.v1.ChallengeService.Present:input_type -> api.v1.ChallengeServicePresentRequest
+	48, // 73: api.v1.ChallengeService.CleanUp:input_type -> api.v1.ChallengeServiceCleanUpRequest
+	51, // 74: api.v1.WebhookService.Create:input_type -> api.v1.WebhookServiceCreateRequest
+	53, // 75: api.v1.WebhookService.Get:input_type -> api.v1.WebhookServiceGetRequest
+	55, // 76: api.v1.WebhookService.List:input_type -> api.v1.WebhookServiceListRequest
+	57, // 77: api.v1.WebhookService.Update:input_type -> api.v1.WebhookServiceUpdateRequest
+	59, // 78: api.v1.WebhookService.Delete:input_type -> api.v1.WebhookServiceDeleteRequest
+	3,  // 79: api.v1.TokenService.Create:output_type -> api.v1.TokenServiceCreateResponse
+	5,  // 80: api.v1.AuthzService.Explain:output_type -> api.v1.AuthzServiceExplainResponse
+	8,  // 81: api.v1.AuditService.List:output_type -> api.v1.AuditServiceListResponse
+	17, // 82: api.v1.DomainService.List:output_type -> api.v1.DomainServiceListResponse
+	18, // 83: api.v1.DomainService.Get:output_type -> api.v1.DomainServiceGetResponse
+	20, // 84: api.v1.DomainService.Create:output_type -> api.v1.DomainServiceCreateResponse
+	19, // 85: api.v1.DomainService.Update:output_type -> api.v1.DomainServiceUpdateResponse
+	21, // 86: api.v1.DomainService.Delete:output_type -> api.v1.DomainServiceDeleteResponse
+	25, // 87: api.v1.DomainService.ListRevisions:output_type -> api.v1.DomainServiceListRevisionsResponse
+	27, // 88: api.v1.DomainService.DiffRevisions:output_type -> api.v1.DomainServiceDiffRevisionsResponse
+	29, // 89: api.v1.DomainService.Rollback:output_type -> api.v1.DomainServiceRollbackResponse
+	12, // 90: api.v1.DomainService.Watch:output_type -> api.v1.DomainServiceWatchResponse
+	38, // 91: api.v1.RecordService.List:output_type -> api.v1.RecordServiceListResponse
+	40, // 92: api.v1.RecordService.Delete:output_type -> api.v1.RecordServiceDeleteResponse
+	41, // 93: api.v1.RecordService.Update:output_type -> api.v1.RecordServiceUpdateResponse
+	42, // 94: api.v1.RecordService.Create:output_type -> api.v1.RecordServiceCreateResponse
+	43, // 95: api.v1.RecordService.Verify:output_type -> api.v1.RecordServiceVerifyResponse
+	37, // 96: api.v1.RecordService.Watch:output_type -> api.v1.RecordServiceWatchResponse
+	47, // 97: api.v1.ChallengeService.Present:output_type -> api.v1.ChallengeServicePresentResponse
+	49, // 98: api.v1.ChallengeService.CleanUp:output_type -> api.v1.ChallengeServiceCleanUpResponse
+	52, // 99: api.v1.WebhookService.Create:output_type -> api.v1.WebhookServiceCreateResponse
+	54, // 100: api.v1.WebhookService.Get:output_type -> api.v1.WebhookServiceGetResponse
+	56, // 101: api.v1.WebhookService.List:output_type -> api.v1.WebhookServiceListResponse
+	58, // 102: api.v1.WebhookService.Update:output_type -> api.v1.WebhookServiceUpdateResponse
+	60, // 103: api.v1.WebhookService.Delete:output_type -> api.v1.WebhookServiceDeleteResponse
+	79, // [79:104] is the sub-list for method output_type
+	54, // [54:79] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_dns_proto_init() }
@@ -1993,8 +4853,188 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenServiceCreateResponse); i {
+		file_api_v1_dns_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TokenServiceCreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthzServiceExplainRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuthzServiceExplainResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditServiceListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditServiceListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Domain); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceWatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceWatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceCreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceGetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2005,8 +5045,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Domain); i {
+		file_api_v1_dns_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceUpdateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2017,8 +5057,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceListRequest); i {
+		file_api_v1_dns_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceCreateResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2029,8 +5069,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceGetRequest); i {
+		file_api_v1_dns_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceDeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2041,8 +5081,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceCreateRequest); i {
+		file_api_v1_dns_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Revision); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2053,8 +5093,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceUpdateRequest); i {
+		file_api_v1_dns_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordSetChange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2065,8 +5105,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceDeleteRequest); i {
+		file_api_v1_dns_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceListRevisionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2077,8 +5117,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceListResponse); i {
+		file_api_v1_dns_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceListRevisionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2089,8 +5129,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceGetResponse); i {
+		file_api_v1_dns_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceDiffRevisionsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2101,8 +5141,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceUpdateResponse); i {
+		file_api_v1_dns_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceDiffRevisionsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2113,8 +5153,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceCreateResponse); i {
+		file_api_v1_dns_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceRollbackRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2125,8 +5165,8 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DomainServiceDeleteResponse); i {
+		file_api_v1_dns_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DomainServiceRollbackResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2137,7 +5177,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Record); i {
 			case 0:
 				return &v.state
@@ -2149,7 +5189,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceListRequest); i {
 			case 0:
 				return &v.state
@@ -2161,7 +5201,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceCreateRequest); i {
 			case 0:
 				return &v.state
@@ -2173,7 +5213,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceUpdateRequest); i {
 			case 0:
 				return &v.state
@@ -2185,7 +5225,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceDeleteRequest); i {
 			case 0:
 				return &v.state
@@ -2197,7 +5237,43 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordServiceVerifyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordServiceWatchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordServiceWatchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceListResponse); i {
 			case 0:
 				return &v.state
@@ -2209,7 +5285,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceGetResponse); i {
 			case 0:
 				return &v.state
@@ -2221,7 +5297,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceDeleteResponse); i {
 			case 0:
 				return &v.state
@@ -2233,7 +5309,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceUpdateResponse); i {
 			case 0:
 				return &v.state
@@ -2245,7 +5321,7 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
-		file_api_v1_dns_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+		file_api_v1_dns_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RecordServiceCreateResponse); i {
 			case 0:
 				return &v.state
@@ -2257,19 +5333,238 @@ func file_api_v1_dns_proto_init() {
 				return nil
 			}
 		}
+		file_api_v1_dns_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RecordServiceVerifyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Propagation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NameserverStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChallengeServicePresentRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChallengeServicePresentResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChallengeServiceCleanUpRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChallengeServiceCleanUpResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Webhook); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceCreateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceCreateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceGetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceGetResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceUpdateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceUpdateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceDeleteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_v1_dns_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WebhookServiceDeleteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_api_v1_dns_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_api_v1_dns_proto_msgTypes[6].OneofWrappers = []interface{}{}
-	file_api_v1_dns_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_api_v1_dns_proto_msgTypes[12].OneofWrappers = []interface{}{}
+	file_api_v1_dns_proto_msgTypes[13].OneofWrappers = []interface{}{}
+	file_api_v1_dns_proto_msgTypes[24].OneofWrappers = []interface{}{}
+	file_api_v1_dns_proto_msgTypes[29].OneofWrappers = []interface{}{}
+	file_api_v1_dns_proto_msgTypes[55].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_v1_dns_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   23,
+			NumEnums:      2,
+			NumMessages:   59,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   7,
 		},
 		GoTypes:           file_api_v1_dns_proto_goTypes,
 		DependencyIndexes: file_api_v1_dns_proto_depIdxs,
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newApplyCmd() *cobra.Command {
+	applyCmd := &cobra.Command{
+		Use:   "apply -f FILE",
+		Short: "bring domains and their rrsets to the state of a zone file",
+		Long: `bring domains and their rrsets to the state of a zone file.
+
+The changes are printed as a plan before they are applied. Every rrset of the zone file is marked as owned
+by --owner with a TXT record at _owner.<name>, --prune deletes rrsets which are not in the zone file
+but only if they carry such a marker of the same owner.`,
+		Example: `domains:
+  - name: example.com.
+    nameservers: [ns1.example.com.]
+    rrsets:
+      - name: www
+        type: A
+        ttl: 600
+        data: 1.2.3.4`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			file, err := flags.GetString("file")
+			if err != nil {
+				return err
+			}
+			owner, err := flags.GetString("owner")
+			if err != nil {
+				return err
+			}
+			prune, err := flags.GetBool("prune")
+			if err != nil {
+				return err
+			}
+			dryRun, err := flags.GetBool("dry-run")
+			if err != nil {
+				return err
+			}
+
+			zf, err := readZoneFile(file, cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+
+			// the timeout applies to the whole plan and apply
+			ctx, cancel := callContext()
+			defer cancel()
+			plan, err := client.PlanZoneFile(ctx, c, zf, client.ApplyOptions{Owner: owner, Prune: prune})
+			if err != nil {
+				return err
+			}
+			if err := printPlan(cmd, plan); err != nil {
+				return err
+			}
+			if dryRun || plan.Empty() {
+				return nil
+			}
+			if err := client.ApplyPlan(ctx, c, plan); err != nil {
+				return err
+			}
+			if viper.GetString("output") == "table" {
+				fmt.Fprintln(cmd.OutOrStdout(), "applied")
+			}
+			return nil
+		},
+	}
+	applyCmd.Flags().StringP("file", "f", "", "zone file to apply, - reads from stdin")
+	applyCmd.Flags().String("owner", client.DefaultOwner, "owner written to the ownership markers")
+	applyCmd.Flags().Bool("prune", false, "delete rrsets owned by --owner which are not in the zone file")
+	applyCmd.Flags().Bool("dry-run", false, "only print the plan")
+	must(applyCmd.MarkFlagRequired("file"))
+	must(applyCmd.MarkFlagFilename("file", "yaml", "yml"))
+
+	return applyCmd
+}
+
+func readZoneFile(file string, stdin io.Reader) (*client.ZoneFile, error) {
+	if file == "-" {
+		return client.ReadZoneFile(stdin)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open zone file %w", err)
+	}
+	defer f.Close()
+	return client.ReadZoneFile(f)
+}
+
+// printPlan prints the plan as text for the table format
+func printPlan(cmd *cobra.Command, plan *client.Plan) error {
+	p, err := newPrinter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	if p.format != "table" {
+		return p.print(plan, nil, nil)
+	}
+	_, err = io.WriteString(p.out, plan.String())
+	return err
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the config file of metal-dnsctl
+type Config struct {
+	CurrentContext string              `yaml:"current-context"`
+	Contexts       map[string]*Context `yaml:"contexts"`
+}
+
+// Context is a metal-dns api and the credentials to access it
+type Context struct {
+	URL   string `yaml:"url"`
+	Token string `yaml:"token,omitempty"`
+	// CA, Cert and Key are paths to pem files, see client.DialConfig
+	CA   string `yaml:"ca,omitempty"`
+	Cert string `yaml:"cert,omitempty"`
+	Key  string `yaml:"key,omitempty"`
+}
+
+func configPath() (string, error) {
+	if path := viper.GetString("config"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the config file %w", err)
+	}
+	return filepath.Join(home, ".metal-dnsctl", "config.yaml"), nil
+}
+
+// loadConfig reads the config file at path, a missing file is an empty config
+func loadConfig(path string) (*Config, error) {
+	config := &Config{Contexts: map[string]*Context{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse config file %s %w", path, err)
+	}
+	if config.Contexts == nil {
+		config.Contexts = map[string]*Context{}
+	}
+	return config, nil
+}
+
+// save writes the config file, it contains tokens and is only readable by the user
+func (c *Config) save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("unable to create config directory %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// context returns the context name, or the current context if name is empty
+func (c *Config) context(name string) (*Context, error) {
+	if name == "" {
+		name = c.CurrentContext
+	}
+	if name == "" {
+		return &Context{}, nil
+	}
+	ctx, ok := c.Contexts[name]
+	if !ok {
+		return nil, fmt.Errorf("context %q not found", name)
+	}
+	return ctx, nil
+}
+
+func (c *Config) names() []string {
+	names := make([]string, 0, len(c.Contexts))
+	for name := range c.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// currentContext returns a copy of the context selected by --context or the current context
+func currentContext() (Context, error) {
+	_, config, err := readConfig()
+	if err != nil {
+		return Context{}, err
+	}
+	c, err := config.context(viper.GetString("context"))
+	if err != nil {
+		return Context{}, err
+	}
+	return *c, nil
+}
+
+func newContextCmd() *cobra.Command {
+	contextCmd := &cobra.Command{
+		Use:   "context",
+		Short: "manage the contexts of the config file",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list all contexts",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, config, err := readConfig()
+			if err != nil {
+				return err
+			}
+			var rows [][]string
+			for _, name := range config.names() {
+				current := ""
+				if name == config.CurrentContext {
+					current = "*"
+				}
+				rows = append(rows, []string{current, name, config.Contexts[name].URL})
+			}
+			p, err := newPrinter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			return p.print(contextList(config), []string{"", "name", "url"}, rows)
+		},
+	}
+
+	useCmd := &cobra.Command{
+		Use:               "use NAME",
+		Short:             "switch the current context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, config, err := readConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := config.Contexts[args[0]]; !ok {
+				return fmt.Errorf("context %q not found", args[0])
+			}
+			config.CurrentContext = args[0]
+			if err := config.save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "switched to context %q\n", args[0])
+			return nil
+		},
+	}
+
+	setCmd := &cobra.Command{
+		Use:               "set NAME",
+		Short:             "create or update a context, the first context becomes the current context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, config, err := readConfig()
+			if err != nil {
+				return err
+			}
+			c, ok := config.Contexts[args[0]]
+			if !ok {
+				c = &Context{}
+				config.Contexts[args[0]] = c
+			}
+			flags := cmd.Flags()
+			for flag, field := range map[string]*string{"url": &c.URL, "token": &c.Token, "ca": &c.CA, "cert": &c.Cert, "key": &c.Key} {
+				// persistent flags are shared with all commands, read them from the command line only
+				if f := flags.Lookup(flag); f != nil && f.Changed {
+					*field = f.Value.String()
+				}
+			}
+			if c.URL == "" {
+				return errors.New("a context requires --url")
+			}
+			if config.CurrentContext == "" {
+				config.CurrentContext = args[0]
+			}
+			if err := config.save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "context %q saved\n", args[0])
+			return nil
+		},
+	}
+	setCmd.Flags().String("ca", "", "ca to verify the server certificate")
+	setCmd.Flags().String("cert", "", "client certificate")
+	setCmd.Flags().String("key", "", "key of the client certificate")
+
+	deleteCmd := &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "delete a context",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeContexts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, config, err := readConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := config.Contexts[args[0]]; !ok {
+				return fmt.Errorf("context %q not found", args[0])
+			}
+			delete(config.Contexts, args[0])
+			if config.CurrentContext == args[0] {
+				config.CurrentContext = ""
+			}
+			if err := config.save(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "context %q deleted\n", args[0])
+			return nil
+		},
+	}
+
+	contextCmd.AddCommand(listCmd, useCmd, setCmd, deleteCmd)
+	return contextCmd
+}
+
+func readConfig() (string, *Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", nil, err
+	}
+	config, err := loadConfig(path)
+	if err != nil {
+		return "", nil, err
+	}
+	return path, config, nil
+}
+
+// contextList is the json and yaml output of context list, tokens are not printed
+func contextList(config *Config) map[string]any {
+	contexts := map[string]any{}
+	for name, c := range config.Contexts {
+		contexts[name] = map[string]string{"url": c.URL}
+	}
+	return map[string]any{"current-context": config.CurrentContext, "contexts": contexts}
+}
+
+func completeContexts(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	_, config, err := readConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	return config.names(), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metal-dnsctl", "config.yaml")
+
+	config, err := loadConfig(path)
+	require.NoError(t, err)
+	require.Empty(t, config.Contexts)
+
+	c, err := config.context("")
+	require.NoError(t, err)
+	require.Equal(t, &Context{}, c)
+
+	config.CurrentContext = "prod"
+	config.Contexts["prod"] = &Context{URL: "https://dns.example.com", Token: "secret"}
+	config.Contexts["dev"] = &Context{URL: "http://localhost:50051"}
+	require.NoError(t, config.save(path))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	config, err = loadConfig(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{"dev", "prod"}, config.names())
+
+	c, err = config.context("")
+	require.NoError(t, err)
+	require.Equal(t, "https://dns.example.com", c.URL)
+	require.Equal(t, "secret", c.Token)
+
+	c, err = config.context("dev")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:50051", c.URL)
+
+	_, err = config.context("staging")
+	require.EqualError(t, err, `context "staging" not found`)
+
+	require.NotContains(t, contextList(config), "secret")
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			content: "",
+		},
+		{
+			name:    "valid",
+			content: "current-context: prod\ncontexts:\n  prod:\n    url: https://dns.example.com\n",
+		},
+		{
+			name:    "unknown field",
+			content: "contexts:\n  prod:\n    uri: https://dns.example.com\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			config, err := loadConfig(path)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, config.Contexts)
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/spf13/cobra"
+)
+
+func newDomainCmd() *cobra.Command {
+	domainCmd := &cobra.Command{
+		Use:     "domain",
+		Aliases: []string{"domains"},
+		Short:   "manage domains",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the domains the token is allowed for",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domains, err := cmd.Flags().GetStringSlice("domains")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Domain().List(ctx, connect.NewRequest(&v1.DomainServiceListRequest{Domains: domains}))
+			if err != nil {
+				return err
+			}
+			return printDomains(cmd, resp.Msg, resp.Msg.Domains...)
+		},
+	}
+	listCmd.Flags().StringSlice("domains", nil, "only list these domains")
+	must(listCmd.RegisterFlagCompletionFunc("domains", completeDomains))
+
+	getCmd := &cobra.Command{
+		Use:               "get NAME",
+		Short:             "get a domain",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomains,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Domain().Get(ctx, connect.NewRequest(&v1.DomainServiceGetRequest{Name: args[0]}))
+			if err != nil {
+				return err
+			}
+			return printDomains(cmd, resp.Msg, resp.Msg.Domain)
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "create a domain",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nameservers, url, err := domainFlags(cmd)
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Domain().Create(ctx, connect.NewRequest(&v1.DomainServiceCreateRequest{Name: args[0], Nameservers: nameservers, Url: url}))
+			if err != nil {
+				return err
+			}
+			return printDomains(cmd, resp.Msg, resp.Msg.Domain)
+		},
+	}
+
+	updateCmd := &cobra.Command{
+		Use:               "update NAME",
+		Short:             "update the nameservers of a domain",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomains,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nameservers, url, err := domainFlags(cmd)
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Domain().Update(ctx, connect.NewRequest(&v1.DomainServiceUpdateRequest{Name: args[0], Nameservers: nameservers, Url: url}))
+			if err != nil {
+				return err
+			}
+			return printDomains(cmd, resp.Msg, resp.Msg.Domain)
+		},
+	}
+
+	for _, cmd := range []*cobra.Command{createCmd, updateCmd} {
+		cmd.Flags().StringSlice("nameservers", nil, "nameservers of the domain")
+		cmd.Flags().String("url", "", "url of the domain")
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "delete a domain with all its records",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomains,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Domain().Delete(ctx, connect.NewRequest(&v1.DomainServiceDeleteRequest{Name: args[0]}))
+			if err != nil {
+				return err
+			}
+			return printDomains(cmd, resp.Msg, resp.Msg.Domain)
+		},
+	}
+
+	watchCmd := &cobra.Command{
+		Use:   "watch",
+		Short: "watch the changes of the domains the token is allowed for",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domains, err := cmd.Flags().GetStringSlice("domains")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			return watchEvents(cmd,
+				func(ctx context.Context, resumeToken string) (*connect.ServerStreamForClient[v1.DomainServiceWatchResponse], error) {
+					return c.Domain().Watch(ctx, connect.NewRequest(&v1.DomainServiceWatchRequest{Domains: domains, ResumeToken: resumeToken}))
+				},
+				func(e *v1.DomainServiceWatchResponse) []string {
+					return []string{e.Timestamp.AsTime().Format(time.RFC3339), eventType(e.Type), e.Domain.GetName(), strings.Join(e.Domain.GetNameservers(), ",")}
+				},
+			)
+		},
+	}
+	watchCmd.Flags().StringSlice("domains", nil, "only watch these domains")
+	watchCmd.Flags().String("resume-token", "", "continue after the event of this resume token")
+	must(watchCmd.RegisterFlagCompletionFunc("domains", completeDomains))
+
+	domainCmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, deleteCmd, watchCmd)
+	return domainCmd
+}
+
+func domainFlags(cmd *cobra.Command) ([]string, *string, error) {
+	nameservers, err := cmd.Flags().GetStringSlice("nameservers")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !cmd.Flags().Changed("url") {
+		return nameservers, nil, nil
+	}
+	url, err := cmd.Flags().GetString("url")
+	if err != nil {
+		return nil, nil, err
+	}
+	return nameservers, &url, nil
+}
+
+func printDomains(cmd *cobra.Command, msg any, domains ...*v1.Domain) error {
+	p, err := newPrinter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, d := range domains {
+		if d == nil {
+			continue
+		}
+		rows = append(rows, []string{d.Name, strconv.FormatUint(uint64(d.Ttl), 10), strings.Join(d.Nameservers, ",")})
+	}
+	return p.print(msg, []string{"name", "ttl", "nameservers"}, rows)
+}
+
+// completeDomains completes the names of the domains the token is allowed for
+func completeDomains(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	c, err := newClient()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	ctx, cancel := callContext()
+	defer cancel()
+	resp, err := c.Domain().List(ctx, connect.NewRequest(&v1.DomainServiceListRequest{}))
+	if err != nil {
+		cobra.CompErrorln(fmt.Sprintf("unable to list domains %v", err))
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var names []string
+	for _, d := range resp.Msg.Domains {
+		if strings.HasPrefix(d.Name, toComplete) {
+			names = append(names, d.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -2,112 +2,87 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
-	"github.com/bufbuild/connect-go"
-	v1 "github.com/majst01/metal-dns/api/v1"
 	"github.com/majst01/metal-dns/pkg/client"
-	"go.uber.org/zap"
+	"github.com/metal-stack/v"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
-const grpcRequestTimeout = 5 * time.Second
+const binaryName = "metal-dnsctl"
 
-func main() {
-
-	logger, _ := zap.NewProduction()
-	logger.Info("Starting Client")
+var rootCmd = &cobra.Command{
+	Use:          binaryName,
+	Short:        "manage domains, records and tokens of metal-dns",
+	Version:      v.V.String(),
+	SilenceUsage: true,
+}
 
-	token := os.Getenv("JWT_TOKEN")
-	if token == "" {
-		// nolint:gosec
-		token = "unknowntoken"
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
 	}
+}
 
-	c := client.New(context.TODO(), client.DialConfig{
-		Token:   token,
-		BaseURL: "http://localhost:8080",
-	})
-	run(c, logger.Sugar())
-
-	logger.Info("Success")
+func initConfig() {
+	viper.SetEnvPrefix("METAL_DNSCTL")
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+	viper.AutomaticEnv()
 }
 
-func run(c client.Client, log *zap.SugaredLogger) {
-	ctx, cancel := context.WithTimeout(context.Background(), grpcRequestTimeout)
-	defer cancel()
+func init() {
+	cobra.OnInitialize(initConfig)
 
-	token, err := c.Token().Create(ctx, connect.NewRequest(&v1.TokenServiceCreateRequest{
-		Issuer: "John Doe",
-		Domains: []string{
-			"example.com.",
-			"a.example.com.",
-		},
-		Permissions: []string{
-			"/api.v1.DomainService/Get",
-			"/api.v1.DomainService/List",
-			"/api.v1.DomainService/Create",
-			"/api.v1.DomainService/Update",
-			"/api.v1.DomainService/Delete",
-			"/api.v1.RecordService/List",
-			"/api.v1.RecordService/Create",
-			"/api.v1.RecordService/Update",
-			"/api.v1.RecordService/Delete",
-		},
-	}))
-	if err != nil {
-		log.Error("could not create token", zap.Error(err))
-	}
-	log.Infow("create token", "token", token)
+	rootCmd.PersistentFlags().String("config", "", "config file with the contexts, defaults to ~/.metal-dnsctl/config.yaml")
+	rootCmd.PersistentFlags().String("context", "", "context to use instead of the current context of the config file")
+	rootCmd.PersistentFlags().String("url", "", "url of the api, overrides the url of the context")
+	rootCmd.PersistentFlags().String("token", "", "token to authenticate with, overrides the token of the context")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "output format, one of table, json or yaml")
+	rootCmd.PersistentFlags().Duration("timeout", 10*time.Second, "timeout of a call to the api")
 
-	c = client.New(context.TODO(), client.DialConfig{
-		Token:   token.Msg.Token,
-		BaseURL: "http://localhost:8080",
-	})
+	must(rootCmd.RegisterFlagCompletionFunc("context", completeContexts))
+	must(rootCmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{"table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp)))
+	must(viper.BindPFlags(rootCmd.PersistentFlags()))
 
-	ds, err := c.Domain().List(ctx, connect.NewRequest(&v1.DomainServiceListRequest{Domains: []string{"example.com."}}))
-	if err != nil {
-		log.Fatal("could not list domain", zap.Error(err))
-	}
-	log.Infow("list domains", "domains", ds.Msg.Domains)
+	rootCmd.AddCommand(newDomainCmd(), newRecordCmd(), newWebhookCmd(), newTokenCmd(), newApplyCmd(), newContextCmd())
+}
 
-	rs, err := c.Record().List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: "example.com."}))
+// newClient creates a client for the selected context, --url and --token take precedence
+func newClient() (client.Client, error) {
+	c, err := currentContext()
 	if err != nil {
-		log.Fatal("could not list records", zap.Error(err))
+		return nil, err
 	}
-
-	log.Infow("list records", "records", rs.Msg.Records)
-	// create
-	dcr := &v1.DomainServiceCreateRequest{
-		Name:        "a.example.com.",
-		Nameservers: []string{"ns1.example.com."},
+	if url := viper.GetString("url"); url != "" {
+		c.URL = url
 	}
-	d, err := c.Domain().Create(ctx, connect.NewRequest(dcr))
-	if err != nil {
-		log.Error("could not create domain", zap.Error(err))
-	} else {
-		log.Infow("created domain", "domain", d.Msg.Domain)
+	if token := viper.GetString("token"); token != "" {
+		c.Token = token
 	}
-
-	// create record
-	rcr := &v1.RecordServiceCreateRequest{
-		Name: "www.a.example.com.",
-		Type: v1.RecordType_A,
-		Ttl:  3600,
-		Data: "1.2.3.4",
-	}
-	r, err := c.Record().Create(ctx, connect.NewRequest(rcr))
-	if err != nil {
-		log.Error("could not create record", zap.Error(err))
-	} else {
-		log.Infow("created record", "record", r.Msg.Record)
+	if c.URL == "" {
+		return nil, fmt.Errorf("no api url given, use --url or create a context with %s context set", binaryName)
 	}
+	return client.New(context.Background(), client.DialConfig{
+		BaseURL:   c.URL,
+		Token:     c.Token,
+		CA:        c.CA,
+		Cert:      c.Cert,
+		Key:       c.Key,
+		UserAgent: binaryName,
+	}), nil
+}
+
+// callContext limits a call to the api to --timeout
+func callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
+}
 
-	rlr := &v1.RecordServiceListRequest{Domain: "example.com.", Type: v1.RecordType_A}
-	records, err := c.Record().List(ctx, connect.NewRequest(rlr))
+func must(err error) {
 	if err != nil {
-		log.Error("could not list records", zap.Error(err))
-	} else {
-		log.Infow("list records", "records", records.Msg.Records)
+		panic(err)
 	}
 }
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+func newPrinter(out io.Writer) (*printer, error) {
+	format := viper.GetString("output")
+	switch format {
+	case "table", "json", "yaml":
+	default:
+		return nil, fmt.Errorf("unsupported output format %q, must be one of table, json or yaml", format)
+	}
+	return &printer{format: format, out: out}, nil
+}
+
+// print writes v as json or yaml, for the table format the rows are written instead.
+// Protobuf messages are encoded with protojson, like the rest gateway does.
+func (p *printer) print(v any, header []string, rows [][]string) error {
+	if p.format == "table" {
+		return p.table(header, rows)
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if msg, ok := v.(proto.Message); ok {
+		data, err = protojson.Marshal(msg)
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return err
+	}
+	// protojson randomizes its whitespace, indent it here to get a stable output
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return err
+	}
+	data = indented.Bytes()
+
+	if p.format == "json" {
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	}
+	data, err = jsonToYAML(data)
+	if err != nil {
+		return err
+	}
+	_, err = p.out.Write(data)
+	return err
+}
+
+func (p *printer) table(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	upper := make([]string, 0, len(header))
+	for _, h := range header {
+		upper = append(upper, strings.ToUpper(h))
+	}
+	fmt.Fprintln(w, strings.Join(upper, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+// jsonToYAML converts json to yaml and keeps the order of the keys
+func jsonToYAML(data []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// resetStyle removes the flow and quoting style of json, strings which would be read as other types stay quoted
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, c := range node.Content {
+		resetStyle(c)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrinter(t *testing.T) {
+	msg := &v1.RecordServiceListResponse{Records: []*v1.Record{
+		{Type: v1.RecordType_A, Name: "www.example.com.", Data: "1.2.3.4", Ttl: 3600},
+	}}
+	header := []string{"name", "type", "ttl", "data"}
+	rows := [][]string{{"www.example.com.", "A", "3600", "1.2.3.4"}}
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr string
+	}{
+		{
+			format: "table",
+			want:   "NAME              TYPE  TTL   DATA\nwww.example.com.  A     3600  1.2.3.4\n",
+		},
+		{
+			format: "json",
+			want:   "{\n  \"records\": [\n    {\n      \"type\": \"A\",\n      \"name\": \"www.example.com.\",\n      \"data\": \"1.2.3.4\",\n      \"ttl\": 3600\n    }\n  ]\n}\n",
+		},
+		{
+			format: "yaml",
+			want:   "records:\n    - type: A\n      name: www.example.com.\n      data: 1.2.3.4\n      ttl: 3600\n",
+		},
+		{
+			format:  "xml",
+			wantErr: `unsupported output format "xml", must be one of table, json or yaml`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.format, func(t *testing.T) {
+			viper.Set("output", tt.format)
+			defer viper.Set("output", "table")
+
+			var out bytes.Buffer
+			p, err := newPrinter(&out)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, p.print(msg, header, rows))
+			require.Equal(t, tt.want, out.String())
+		})
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newRecordCmd() *cobra.Command {
+	recordCmd := &cobra.Command{
+		Use:     "record",
+		Aliases: []string{"records"},
+		Short:   "manage the records of domains",
+	}
+
+	listCmd := &cobra.Command{
+		Use:               "list DOMAIN",
+		Short:             "list the records of a domain",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeDomains,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &v1.RecordServiceListRequest{Domain: args[0], Type: v1.RecordType_ANY}
+			if cmd.Flags().Changed("type") {
+				t, err := recordType(cmd)
+				if err != nil {
+					return err
+				}
+				req.Type = t
+			}
+			if cmd.Flags().Changed("name") {
+				name, err := cmd.Flags().GetString("name")
+				if err != nil {
+					return err
+				}
+				req.Name = &name
+			}
+			records, err := listRecords(req)
+			if err != nil {
+				return err
+			}
+			return printRecords(cmd, &v1.RecordServiceListResponse{Records: records}, records...)
+		},
+	}
+	listCmd.Flags().String("type", "", "only list records of this type")
+	listCmd.Flags().String("name", "", "only list records with this name")
+
+	getCmd := &cobra.Command{
+		Use:               "get DOMAIN NAME",
+		Short:             "get the records of a name, of a single type with --type",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeDomains,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &v1.RecordServiceListRequest{Domain: args[0], Type: v1.RecordType_ANY, Name: &args[1]}
+			if cmd.Flags().Changed("type") {
+				t, err := recordType(cmd)
+				if err != nil {
+					return err
+				}
+				req.Type = t
+			}
+			records, err := listRecords(req)
+			if err != nil {
+				return err
+			}
+			if len(records) == 0 {
+				return fmt.Errorf("no records found for %s in %s", args[1], args[0])
+			}
+			return printRecords(cmd, &v1.RecordServiceListResponse{Records: records}, records...)
+		},
+	}
+	getCmd.Flags().String("type", "", "only get records of this type")
+
+	createCmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "create a record, the domain is derived from the name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := recordFlags(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			wait, err := cmd.Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Record().Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{
+				Type: r.Type, Name: r.Name, Data: r.Data, Ttl: r.Ttl,
+				Priority: r.Priority, Port: r.Port, Weight: r.Weight, Flags: r.Flags, Tag: r.Tag,
+				WaitForPropagation: wait,
+			}))
+			if err != nil {
+				return err
+			}
+			return printWritten(cmd, resp.Msg, resp.Msg.Record, resp.Msg.Propagation)
+		},
+	}
+
+	updateCmd := &cobra.Command{
+		Use:   "update NAME",
+		Short: "update a record",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := recordFlags(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			wait, err := cmd.Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Record().Update(ctx, connect.NewRequest(&v1.RecordServiceUpdateRequest{
+				Type: r.Type, Name: r.Name, Data: r.Data, Ttl: r.Ttl,
+				Priority: r.Priority, Port: r.Port, Weight: r.Weight, Flags: r.Flags, Tag: r.Tag,
+				WaitForPropagation: wait,
+			}))
+			if err != nil {
+				return err
+			}
+			return printWritten(cmd, resp.Msg, resp.Msg.Record, resp.Msg.Propagation)
+		},
+	}
+
+	for _, cmd := range []*cobra.Command{createCmd, updateCmd} {
+		cmd.Flags().Uint32("ttl", 3600, "ttl of the record in seconds")
+		cmd.Flags().Int32("priority", 0, "priority of MX and SRV records")
+		cmd.Flags().Uint32("port", 0, "port of SRV records")
+		cmd.Flags().Int32("weight", 0, "weight of SRV records")
+		cmd.Flags().Int32("flags", 0, "flags of CAA records")
+		cmd.Flags().String("tag", "", "tag of CAA records")
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete NAME",
+		Short: "delete a record",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := recordType(cmd)
+			if err != nil {
+				return err
+			}
+			data, err := cmd.Flags().GetString("data")
+			if err != nil {
+				return err
+			}
+			wait, err := cmd.Flags().GetBool("wait")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Record().Delete(ctx, connect.NewRequest(&v1.RecordServiceDeleteRequest{Type: t, Name: args[0], Data: data, WaitForPropagation: wait}))
+			if err != nil {
+				return err
+			}
+			return printWritten(cmd, resp.Msg, resp.Msg.Record, resp.Msg.Propagation)
+		},
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify NAME",
+		Short: "verify that the authoritative nameservers serve a rrset, fails if one of them does not",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			t, err := recordType(cmd)
+			if err != nil {
+				return err
+			}
+			data, err := cmd.Flags().GetStringSlice("data")
+			if err != nil {
+				return err
+			}
+			wait, err := cmd.Flags().GetDuration("wait")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Record().Verify(ctx, connect.NewRequest(&v1.RecordServiceVerifyRequest{
+				Type: t, Name: args[0], Data: data, Timeout: durationpb.New(wait),
+			}))
+			if err != nil {
+				return err
+			}
+			if err := printPropagation(cmd, resp.Msg, resp.Msg.Propagation); err != nil {
+				return err
+			}
+			if !resp.Msg.Propagation.GetPropagated() {
+				return fmt.Errorf("%s %s is not served by all nameservers", args[0], t)
+			}
+			return nil
+		},
+	}
+	verifyCmd.Flags().String("type", "", "type of the rrset, e.g. A, AAAA or CNAME")
+	verifyCmd.Flags().StringSlice("data", nil, "expected data of the rrset, defaults to the records stored in the zone")
+	verifyCmd.Flags().Duration("wait", 0, "wait up to this duration for the propagation, --timeout must be longer")
+	must(verifyCmd.MarkFlagRequired("type"))
+
+	for _, cmd := range []*cobra.Command{createCmd, updateCmd, deleteCmd} {
+		cmd.Flags().String("type", "", "type of the record, e.g. A, AAAA or CNAME")
+		cmd.Flags().String("data", "", "data of the record, e.g. the ip address of an A record")
+		must(cmd.MarkFlagRequired("type"))
+		must(cmd.MarkFlagRequired("data"))
+		cmd.Flags().Bool("wait", false, "wait until the authoritative nameservers serve the change, --timeout must be longer than the propagation timeout of the server")
+	}
+	for _, cmd := range []*cobra.Command{listCmd, getCmd, createCmd, updateCmd, deleteCmd, verifyCmd} {
+		must(cmd.RegisterFlagCompletionFunc("type", completeRecordTypes))
+	}
+
+	watchCmd := &cobra.Command{
+		Use:               "watch [DOMAIN]",
+		Short:             "watch the changes of the records of a domain, or of all domains of the token",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completeDomains,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req := &v1.RecordServiceWatchRequest{}
+			if len(args) > 0 {
+				req.Domain = args[0]
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			return watchEvents(cmd,
+				func(ctx context.Context, resumeToken string) (*connect.ServerStreamForClient[v1.RecordServiceWatchResponse], error) {
+					req.ResumeToken = resumeToken
+					return c.Record().Watch(ctx, connect.NewRequest(req))
+				},
+				func(e *v1.RecordServiceWatchResponse) []string {
+					return []string{e.Timestamp.AsTime().Format(time.RFC3339), eventType(e.Type), e.Domain, e.Record.GetName(), e.Record.GetType().String(), e.Record.GetData()}
+				},
+			)
+		},
+	}
+	watchCmd.Flags().String("resume-token", "", "continue after the event of this resume token")
+
+	recordCmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, deleteCmd, verifyCmd, watchCmd)
+	return recordCmd
+}
+
+// recordType parses --type, the name of a record type is required
+func recordType(cmd *cobra.Command) (v1.RecordType, error) {
+	name, err := cmd.Flags().GetString("type")
+	if err != nil {
+		return v1.RecordType_UNKNOWN, err
+	}
+	t := client.ToV1RecordType(strings.ToUpper(name))
+	if t == v1.RecordType_UNKNOWN {
+		return t, fmt.Errorf("unknown record type %q", name)
+	}
+	return t, nil
+}
+
+func recordFlags(cmd *cobra.Command, name string) (*v1.Record, error) {
+	t, err := recordType(cmd)
+	if err != nil {
+		return nil, err
+	}
+	r := &v1.Record{Type: t, Name: name}
+	flags := cmd.Flags()
+	if r.Data, err = flags.GetString("data"); err != nil {
+		return nil, err
+	}
+	if r.Ttl, err = flags.GetUint32("ttl"); err != nil {
+		return nil, err
+	}
+	if r.Priority, err = flags.GetInt32("priority"); err != nil {
+		return nil, err
+	}
+	if r.Port, err = flags.GetUint32("port"); err != nil {
+		return nil, err
+	}
+	if r.Weight, err = flags.GetInt32("weight"); err != nil {
+		return nil, err
+	}
+	if r.Flags, err = flags.GetInt32("flags"); err != nil {
+		return nil, err
+	}
+	if r.Tag, err = flags.GetString("tag"); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func listRecords(req *v1.RecordServiceListRequest) ([]*v1.Record, error) {
+	c, err := newClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := callContext()
+	defer cancel()
+	resp, err := c.Record().List(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Records, nil
+}
+
+func printRecords(cmd *cobra.Command, msg any, records ...*v1.Record) error {
+	p, err := newPrinter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, r := range records {
+		if r == nil {
+			continue
+		}
+		rows = append(rows, []string{r.Name, r.Type.String(), strconv.FormatUint(uint64(r.Ttl), 10), r.Data})
+	}
+	return p.print(msg, []string{"name", "type", "ttl", "data"}, rows)
+}
+
+// printWritten writes the record of a create, update or delete, in the table format followed by the propagation if it was waited for
+func printWritten(cmd *cobra.Command, msg any, record *v1.Record, propagation *v1.Propagation) error {
+	if err := printRecords(cmd, msg, record); err != nil {
+		return err
+	}
+	if propagation == nil || viper.GetString("output") != "table" {
+		return nil
+	}
+	fmt.Fprintln(cmd.OutOrStdout())
+	if propagation.Error != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "the propagation could not be verified: %s\n", propagation.Error)
+		return nil
+	}
+	return printPropagation(cmd, propagation, propagation)
+}
+
+func printPropagation(cmd *cobra.Command, msg any, propagation *v1.Propagation) error {
+	p, err := newPrinter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, ns := range propagation.GetNameservers() {
+		propagated := "no"
+		if ns.Propagated {
+			propagated = "yes"
+		}
+		rows = append(rows, []string{ns.Nameserver, ns.Address, propagated, strings.Join(ns.Data, ", "), ns.Error})
+	}
+	return p.print(msg, []string{"nameserver", "address", "propagated", "data", "error"}, rows)
+}
+
+// completeRecordTypes completes all record types which are accepted by client.ToV1RecordType
+func completeRecordTypes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var types []string
+	for t := v1.RecordType_UNKNOWN + 1; t < v1.RecordType_ZZZ; t++ {
+		if client.ToV1RecordType(t.String()) == t && strings.HasPrefix(t.String(), strings.ToUpper(toComplete)) {
+			types = append(types, t.String())
+		}
+	}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
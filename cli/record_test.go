@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordType(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    v1.RecordType
+		wantErr bool
+	}{
+		{name: "A", want: v1.RecordType_A},
+		{name: "txt", want: v1.RecordType_TXT},
+		{name: "Aaaa", want: v1.RecordType_AAAA},
+		{name: "unknown", want: v1.RecordType_UNKNOWN, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("type", "", "")
+			require.NoError(t, cmd.Flags().Set("type", tt.name))
+			got, err := recordType(cmd)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/golang-jwt/jwt/v4"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func newTokenCmd() *cobra.Command {
+	tokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "create and inspect tokens",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "create a token for domains with permissions",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags := cmd.Flags()
+			issuer, err := flags.GetString("issuer")
+			if err != nil {
+				return err
+			}
+			domains, err := flags.GetStringSlice("domains")
+			if err != nil {
+				return err
+			}
+			permissions, err := flags.GetStringSlice("permissions")
+			if err != nil {
+				return err
+			}
+			expires, err := flags.GetDuration("expires")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Token().Create(ctx, connect.NewRequest(&v1.TokenServiceCreateRequest{
+				Issuer:      issuer,
+				Domains:     domains,
+				Permissions: permissions,
+				Expires:     durationpb.New(expires),
+			}))
+			if err != nil {
+				return err
+			}
+			p, err := newPrinter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			return p.print(resp.Msg, []string{"token"}, [][]string{{resp.Msg.Token}})
+		},
+	}
+	createCmd.Flags().String("issuer", "", "issuer of the token")
+	createCmd.Flags().StringSlice("domains", nil, "domains the token is allowed for")
+	createCmd.Flags().StringSlice("permissions", nil, "permissions of the token, e.g. /api.v1.DomainService/List")
+	createCmd.Flags().Duration("expires", 8*time.Hour, "duration until the token expires")
+	must(createCmd.MarkFlagRequired("issuer"))
+	must(createCmd.RegisterFlagCompletionFunc("domains", completeDomains))
+
+	inspectCmd := &cobra.Command{
+		Use:   "inspect [TOKEN]",
+		Short: "print the claims of a token, defaults to the token of the context",
+		Long:  "print the claims of a token, defaults to the token of the context. The signature of the token is not verified.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			raw := viper.GetString("token")
+			if len(args) > 0 {
+				raw = args[0]
+			}
+			if raw == "" {
+				c, err := currentContext()
+				if err != nil {
+					return err
+				}
+				raw = c.Token
+			}
+			if raw == "" {
+				return errors.New("no token given, pass it as argument, with --token or set it in the context")
+			}
+			claims, err := token.ParseJWTToken(raw)
+			if err != nil {
+				return err
+			}
+			p, err := newPrinter(cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			rows := [][]string{
+				{"subject", claims.Subject},
+				{"issuer", claims.Issuer},
+				{"id", claims.ID},
+				{"issued", formatTime(claims.IssuedAt)},
+				{"expires", formatTime(claims.ExpiresAt)},
+				{"domains", strings.Join(claims.Domains, ",")},
+				{"permissions", strings.Join(claims.Permissions, ",")},
+			}
+			return p.print(claims, []string{"claim", "value"}, rows)
+		},
+	}
+
+	tokenCmd.AddCommand(createCmd, inspectCmd)
+	return tokenCmd
+}
+
+func formatTime(t *jwt.NumericDate) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
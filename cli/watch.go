@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+)
+
+type watchResponse interface {
+	proto.Message
+	GetType() v1.EventType
+	GetResumeToken() string
+}
+
+// watchEvents prints the events of the streams opened by open until it is interrupted,
+// a stream which breaks is resumed with the resume token of the last event.
+func watchEvents[T any, PT interface {
+	*T
+	watchResponse
+}](cmd *cobra.Command, open func(ctx context.Context, resumeToken string) (*connect.ServerStreamForClient[T], error), row func(PT) []string) error {
+	resumeToken, err := cmd.Flags().GetString("resume-token")
+	if err != nil {
+		return err
+	}
+	p, err := newPrinter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	for {
+		stream, err := open(ctx, resumeToken)
+		if err != nil {
+			return err
+		}
+		for stream.Receive() {
+			msg := PT(stream.Msg())
+			resumeToken = msg.GetResumeToken()
+			if msg.GetType() == v1.EventType_EVENT_TYPE_BOOKMARK {
+				continue
+			}
+			if err := printEvent(p, msg, row(msg)); err != nil {
+				_ = stream.Close()
+				return err
+			}
+		}
+		err = stream.Err()
+		_ = stream.Close()
+		if ctx.Err() != nil {
+			return nil
+		}
+		// the server ends streams on shutdown and closes them if they fall behind
+		if err != nil && connect.CodeOf(err) != connect.CodeUnavailable {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "watch interrupted, resuming at %s\n", resumeToken)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// printEvent writes a line per event for the table format, the event is written as a document otherwise
+func printEvent(p *printer, msg proto.Message, row []string) error {
+	if p.format == "table" {
+		_, err := fmt.Fprintln(p.out, strings.Join(row, "\t"))
+		return err
+	}
+	return p.print(msg, nil, nil)
+}
+
+func eventType(t v1.EventType) string {
+	return strings.TrimPrefix(t.String(), "EVENT_TYPE_")
+}
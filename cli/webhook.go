@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/spf13/cobra"
+)
+
+func newWebhookCmd() *cobra.Command {
+	webhookCmd := &cobra.Command{
+		Use:     "webhook",
+		Aliases: []string{"webhooks"},
+		Short:   "manage the webhooks which are notified about changes",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "list the webhooks of the domains the token is allowed for",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Webhook().List(ctx, connect.NewRequest(&v1.WebhookServiceListRequest{}))
+			if err != nil {
+				return err
+			}
+			return printWebhooks(cmd, resp.Msg, resp.Msg.Webhooks...)
+		},
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get ID",
+		Short: "get a webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Webhook().Get(ctx, connect.NewRequest(&v1.WebhookServiceGetRequest{Id: args[0]}))
+			if err != nil {
+				return err
+			}
+			return printWebhooks(cmd, resp.Msg, resp.Msg.Webhook)
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create URL",
+		Short: "create a webhook, a secret is generated unless --secret is given",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domains, eventTypes, description, err := webhookFlags(cmd)
+			if err != nil {
+				return err
+			}
+			secret, err := cmd.Flags().GetString("secret")
+			if err != nil {
+				return err
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Webhook().Create(ctx, connect.NewRequest(&v1.WebhookServiceCreateRequest{
+				Url: args[0], Domains: domains, EventTypes: eventTypes, Description: description, Secret: secret,
+			}))
+			if err != nil {
+				return err
+			}
+			return printWebhooks(cmd, resp.Msg, resp.Msg.Webhook)
+		},
+	}
+
+	updateCmd := &cobra.Command{
+		Use:   "update ID URL",
+		Short: "replace the url, domains, event types and description of a webhook",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domains, eventTypes, description, err := webhookFlags(cmd)
+			if err != nil {
+				return err
+			}
+			req := &v1.WebhookServiceUpdateRequest{
+				Id: args[0], Url: args[1], Domains: domains, EventTypes: eventTypes, Description: description,
+			}
+			rotate, err := cmd.Flags().GetBool("rotate-secret")
+			if err != nil {
+				return err
+			}
+			if rotate {
+				empty := ""
+				req.Secret = &empty
+			}
+			if cmd.Flags().Changed("secret") {
+				secret, err := cmd.Flags().GetString("secret")
+				if err != nil {
+					return err
+				}
+				req.Secret = &secret
+			}
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Webhook().Update(ctx, connect.NewRequest(req))
+			if err != nil {
+				return err
+			}
+			return printWebhooks(cmd, resp.Msg, resp.Msg.Webhook)
+		},
+	}
+	updateCmd.Flags().Bool("rotate-secret", false, "generate a new secret")
+
+	for _, cmd := range []*cobra.Command{createCmd, updateCmd} {
+		cmd.Flags().StringSlice("domains", nil, "patterns of the domains whose changes are posted, e.g. example.com. or *.example.com.")
+		cmd.Flags().StringSlice("event-types", nil, "only post these event types, one of created, updated or deleted, all if empty")
+		cmd.Flags().String("description", "", "description of the webhook")
+		cmd.Flags().String("secret", "", "secret the deliveries are signed with")
+		must(cmd.MarkFlagRequired("domains"))
+		must(cmd.RegisterFlagCompletionFunc("event-types", cobra.FixedCompletions([]string{"created", "updated", "deleted"}, cobra.ShellCompDirectiveNoFileComp)))
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete ID",
+		Short: "delete a webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient()
+			if err != nil {
+				return err
+			}
+			ctx, cancel := callContext()
+			defer cancel()
+			resp, err := c.Webhook().Delete(ctx, connect.NewRequest(&v1.WebhookServiceDeleteRequest{Id: args[0]}))
+			if err != nil {
+				return err
+			}
+			return printWebhooks(cmd, resp.Msg, resp.Msg.Webhook)
+		},
+	}
+
+	webhookCmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, deleteCmd)
+	return webhookCmd
+}
+
+func webhookFlags(cmd *cobra.Command) (domains []string, eventTypes []v1.EventType, description string, err error) {
+	domains, err = cmd.Flags().GetStringSlice("domains")
+	if err != nil {
+		return nil, nil, "", err
+	}
+	names, err := cmd.Flags().GetStringSlice("event-types")
+	if err != nil {
+		return nil, nil, "", err
+	}
+	for _, name := range names {
+		t, ok := v1.EventType_value["EVENT_TYPE_"+strings.ToUpper(name)]
+		if !ok {
+			return nil, nil, "", fmt.Errorf("unknown event type %q", name)
+		}
+		eventTypes = append(eventTypes, v1.EventType(t))
+	}
+	description, err = cmd.Flags().GetString("description")
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return domains, eventTypes, description, nil
+}
+
+// printWebhooks prints the webhooks, the secret is only shown by create and by updates which set it
+func printWebhooks(cmd *cobra.Command, msg any, webhooks ...*v1.Webhook) error {
+	p, err := newPrinter(cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	var (
+		rows   [][]string
+		secret bool
+	)
+	for _, w := range webhooks {
+		if w == nil {
+			continue
+		}
+		var types []string
+		for _, t := range w.EventTypes {
+			types = append(types, strings.ToLower(eventType(t)))
+		}
+		rows = append(rows, []string{w.Id, w.Url, strings.Join(w.Domains, ","), strings.Join(types, ","), w.Description, w.Secret})
+		secret = secret || w.Secret != ""
+	}
+	header := []string{"id", "url", "domains", "event types", "description"}
+	if secret {
+		header = append(header, "secret")
+	} else {
+		for i := range rows {
+			rows[i] = rows[i][:len(header)]
+		}
+	}
+	return p.print(msg, header, rows)
+}
@@ -2,12 +2,20 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/config"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/mirror"
+	"github.com/majst01/metal-dns/pkg/notify"
 	"github.com/majst01/metal-dns/pkg/server"
 
 	"github.com/metal-stack/v"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -25,14 +33,39 @@ var rootCmd = &cobra.Command{
 	Use:     moduleName,
 	Short:   "an api manage dns for metal cloud components",
 	Version: v.V.String(),
-	Run: func(cmd *cobra.Command, args []string) {
-		run()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return run(cmd)
+	},
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "inspect the configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "validate the configuration given by --config and environment without starting the server",
+	// errors are reported by the validation, usage would hide them
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := loadConfig(cmd, cmd.Root().Flags()); err != nil {
+			return err
+		}
+		if _, err := zap.ParseAtomicLevel(viper.GetString("log-level")); err != nil {
+			return fmt.Errorf("invalid configuration:\nlog-level %w", err)
+		}
+		if err := newDialConfig().Validate(); err != nil {
+			return fmt.Errorf("invalid configuration:\n%w", err)
+		}
+		fmt.Println("configuration is valid")
+		return nil
 	},
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		logger.Error("failed executing root command", zap.Error(err))
+		os.Exit(1)
 	}
 }
 
@@ -42,26 +75,113 @@ func initConfig() {
 	viper.AutomaticEnv()
 }
 
+// loadConfig reads the config file given with --config, flags and environment take precedence over it.
+// The keys of the file must be flags of the command which runs or global flags.
+func loadConfig(cmd *cobra.Command, flags *pflag.FlagSet) error {
+	path, err := cmd.Flags().GetString("config")
+	if err != nil || path == "" {
+		return err
+	}
+	known := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	known.AddFlagSet(flags)
+	known.AddFlagSet(cmd.Root().PersistentFlags())
+	return config.Load(viper.GetViper(), known, path)
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentFlags().StringP("config", "c", "", "yaml, toml or json config file, keys are the names of the flags")
+	rootCmd.PersistentFlags().StringP("log-level", "", "info", "log level to use")
+	configCmd.AddCommand(configValidateCmd)
+	rootCmd.AddCommand(configCmd, newWebhookCmd(), newMirrorCmd())
+
 	rootCmd.Flags().StringP("http-endpoint", "", "localhost:8080", "the host/ip to serve on")
+	rootCmd.Flags().StringP("metrics-endpoint", "", "", "the host/ip to serve /metrics on, if empty /metrics is served on the http-endpoint")
+
+	rootCmd.Flags().StringSliceP("cors-allowed-origins", "", nil, "origins browsers may call the api from, e.g. https://app.example.com, CORS is disabled if empty")
+	rootCmd.Flags().StringSliceP("cors-allowed-headers", "", nil, "request headers browsers may send, defaults to the headers of connect and grpc-web clients")
+	rootCmd.Flags().DurationP("cors-max-age", "", 2*time.Hour, "duration browsers may cache the result of a preflight request")
 
 	rootCmd.Flags().StringP("secret", "", "secret", "jwt signing secret")
 
+	rootCmd.Flags().StringP("tls-cert", "", "", "server certificate, enables tls together with --tls-key, reloaded on change")
+	rootCmd.Flags().StringP("tls-key", "", "", "private key of the server certificate")
+	rootCmd.Flags().StringP("tls-client-ca", "", "", "if set, client certificates are verified against this ca")
+	rootCmd.Flags().StringP("tls-client-subjects", "", "", "yaml file which maps client certificate subjects to domains and permissions")
+
+	rootCmd.Flags().StringP("backend", "", server.BackendPowerDNS, "backend the domains and records are stored in, powerdns, rfc2136 or zonefile")
+
 	rootCmd.Flags().StringP("pdns-api-url", "", "http://localhost:8081", "powerdns api url")
 	rootCmd.Flags().StringP("pdns-api-password", "", "apipw", "powerdns api password")
 	rootCmd.Flags().StringP("pdns-api-vhost", "", "localhost", "powerdns vhost")
+	rootCmd.Flags().StringP("pdns-servers", "", "", "yaml file of named powerdns servers and the zone suffixes they store, replaces the other pdns flags")
+
+	rootCmd.Flags().StringP("rfc2136-nameserver", "", "", "nameserver in the form host:port which receives the dynamic updates and zone transfers of the rfc2136 backend")
+	rootCmd.Flags().StringSliceP("rfc2136-zones", "", nil, "zones of the nameserver which are managed by the rfc2136 backend")
+	rootCmd.Flags().StringP("rfc2136-tsig-key-name", "", "", "name of the TSIG key which signs updates and zone transfers, they are not signed if empty")
+	rootCmd.Flags().StringP("rfc2136-tsig-algorithm", "", "hmac-sha256", "algorithm of the TSIG key, one of hmac-sha1, hmac-sha224, hmac-sha256, hmac-sha384 or hmac-sha512")
+	rootCmd.Flags().StringP("rfc2136-tsig-secret", "", "", "base64 encoded secret of the TSIG key")
+	rootCmd.Flags().DurationP("rfc2136-timeout", "", backend.DefaultRFC2136Timeout, "timeout of dynamic updates and zone transfers")
+
+	rootCmd.Flags().StringP("zonefile-directory", "", "", "directory of the zone files of the zonefile backend, one file per zone named like example.com.zone")
+	rootCmd.Flags().StringP("zonefile-reload-command", "", "", "command which is run after each change of a zone file, with the environment variables ZONE and ZONE_FILE")
+	rootCmd.Flags().DurationP("zonefile-reload-timeout", "", backend.DefaultReloadTimeout, "timeout of the reload command")
+
+	rootCmd.Flags().StringP("mirrors", "", "", "yaml file of backends all changes are replicated to asynchronously, replication is disabled if empty")
+	rootCmd.Flags().StringP("mirror-queue-dir", "", "", "directory of the queue of the changes which are not yet replicated to the mirrors")
+	rootCmd.Flags().DurationP("mirror-backoff", "", mirror.DefaultBackoff, "wait before the first retry of a failed replication, doubled after every retry")
+
+	rootCmd.Flags().StringP("rate-limit-read", "", "50:100", "calls per second and burst of reads per key in the form rate:burst, 0 is unlimited")
+	rootCmd.Flags().StringP("rate-limit-write", "", "10:20", "calls per second and burst of writes per key in the form rate:burst, 0 is unlimited")
+	rootCmd.Flags().StringSliceP("rate-limit-procedure", "", nil, "limit of a single procedure in the form /api.v1.RecordService/Create=rate:burst")
+	rootCmd.Flags().StringSliceP("rate-limit-by", "", nil, "keys calls are limited by, token and/or ip, calls are not limited if empty")
+
+	rootCmd.Flags().DurationP("health-check-interval", "", 10*time.Second, "interval in which powerdns is probed for the health and readiness checks")
+	rootCmd.Flags().DurationP("health-check-timeout", "", 5*time.Second, "time after which a single probe of powerdns fails")
+
+	rootCmd.Flags().StringSliceP("propagation-nameservers", "", nil, "nameservers in the form host:port which are queried to verify the propagation of records, defaults to the nameservers of the zone")
+	rootCmd.Flags().DurationP("propagation-timeout", "", time.Minute, "longest duration to wait for a record to propagate to the nameservers")
+	rootCmd.Flags().DurationP("propagation-interval", "", 2*time.Second, "interval in which the nameservers are queried while waiting for the propagation")
 
-	rootCmd.Flags().StringP("log-level", "", "info", "log level to use")
+	rootCmd.Flags().IntP("watch-buffer-size", "", events.DefaultBufferSize, "number of events which are kept to resume watches")
+
+	rootCmd.Flags().StringP("webhook-dir", "", "", "if set, webhooks are stored in this directory and changes are posted to them, failed deliveries are appended to dead-letters.jsonl")
+	rootCmd.Flags().IntP("webhook-attempts", "", notify.DefaultAttempts, "number of posts of a webhook delivery before it is written to the dead letter log")
+	rootCmd.Flags().DurationP("webhook-backoff", "", notify.DefaultBackoff, "wait before the first retry of a webhook delivery, doubled after every retry")
+	rootCmd.Flags().DurationP("webhook-timeout", "", notify.DefaultTimeout, "timeout of a single post of a webhook delivery")
+	rootCmd.Flags().StringSliceP("webhook-allowed-networks", "", nil, "loopback, private or link-local networks webhooks may post to, e.g. 10.0.0.0/8, all are refused by default")
+
+	rootCmd.Flags().StringP("dns-update-endpoint", "", "", "if set, RFC 2136 dynamic updates signed with the --dns-update-keys are accepted on this address over udp and tcp, e.g. :53")
+	rootCmd.Flags().StringP("dns-update-keys", "", "", "yaml file with the TSIG keys of dynamic updates and their domains and permissions")
+
+	rootCmd.Flags().StringP("otlp-endpoint", "", "", "OTLP/HTTP collector to send traces to, e.g. localhost:4318, tracing is disabled if empty")
+	rootCmd.Flags().BoolP("otlp-insecure", "", false, "connect to the OTLP collector without TLS")
+	rootCmd.Flags().Float64P("trace-sample-ratio", "", 1.0, "fraction of traces to sample if the caller did not decide already")
+
+	rootCmd.Flags().StringP("decision-log-file", "", "", "if set, authorization decisions are additionally appended to this file")
+	rootCmd.Flags().StringP("decision-log-url", "", "", "if set, authorization decisions are additionally posted to this http endpoint")
+	rootCmd.Flags().StringP("audit-log-file", "", "", "if set, all mutating calls are recorded in this file and can be listed with the AuditService")
+	rootCmd.Flags().StringP("history-dir", "", "", "if set, a revision of every zone is stored in this directory before it is modified, which enables rollback")
+	rootCmd.Flags().StringP("policy-path", "", "", "directory or opa bundle tarball with additional rego policies and data, reloaded on change")
 
 	err := viper.BindPFlags(rootCmd.Flags())
+	if err == nil {
+		err = viper.BindPFlags(rootCmd.PersistentFlags())
+	}
 	if err != nil {
 		logger.Error("unable to construct root command", zap.Error(err))
 	}
 }
 
-func run() {
-	logger, _ = createLogger()
+func run(cmd *cobra.Command) error {
+	if err := loadConfig(cmd, cmd.Flags()); err != nil {
+		return err
+	}
+	var err error
+	logger, err = createLogger()
+	if err != nil {
+		return err
+	}
 	defer func() {
 		err := logger.Sync() // flushes buffer, if any
 		if err != nil {
@@ -69,21 +189,87 @@ func run() {
 		}
 	}()
 
-	config := server.DialConfig{
-		HttpServerEndpoint: viper.GetString("http-endpoint"),
-		Secret:             viper.GetString("secret"),
-
-		PdnsApiUrl:      viper.GetString("pdns-api-url"),
-		PdnsApiPassword: viper.GetString("pdns-api-password"),
-		PdnsApiVHost:    viper.GetString("pdns-api-vhost"),
-	}
-	s, err := server.New(logger, config)
+	s, err := server.New(logger, newDialConfig())
 	if err != nil {
 		logger.Fatal("failed to create server %v", zap.Error(err))
 	}
 	if err := s.Serve(); err != nil {
 		logger.Fatal("failed to serve", zap.Error(err))
 	}
+	return nil
+}
+
+func newDialConfig() server.DialConfig {
+	return server.DialConfig{
+		HttpServerEndpoint:    viper.GetString("http-endpoint"),
+		MetricsServerEndpoint: viper.GetString("metrics-endpoint"),
+		Secret:                viper.GetString("secret"),
+
+		CORSAllowedOrigins: viper.GetStringSlice("cors-allowed-origins"),
+		CORSAllowedHeaders: viper.GetStringSlice("cors-allowed-headers"),
+		CORSMaxAge:         viper.GetDuration("cors-max-age"),
+
+		TLSCert:           viper.GetString("tls-cert"),
+		TLSKey:            viper.GetString("tls-key"),
+		TLSClientCA:       viper.GetString("tls-client-ca"),
+		TLSClientSubjects: viper.GetString("tls-client-subjects"),
+
+		DecisionLogFile: viper.GetString("decision-log-file"),
+		DecisionLogURL:  viper.GetString("decision-log-url"),
+		PolicyPath:      viper.GetString("policy-path"),
+		AuditLogFile:    viper.GetString("audit-log-file"),
+		HistoryDir:      viper.GetString("history-dir"),
+
+		OtlpEndpoint:     viper.GetString("otlp-endpoint"),
+		OtlpInsecure:     viper.GetBool("otlp-insecure"),
+		TraceSampleRatio: viper.GetFloat64("trace-sample-ratio"),
+
+		RateLimitRead:       viper.GetString("rate-limit-read"),
+		RateLimitWrite:      viper.GetString("rate-limit-write"),
+		RateLimitProcedures: viper.GetStringSlice("rate-limit-procedure"),
+		RateLimitBy:         viper.GetStringSlice("rate-limit-by"),
+
+		HealthCheckInterval: viper.GetDuration("health-check-interval"),
+		HealthCheckTimeout:  viper.GetDuration("health-check-timeout"),
+
+		PropagationNameservers: viper.GetStringSlice("propagation-nameservers"),
+		PropagationTimeout:     viper.GetDuration("propagation-timeout"),
+		PropagationInterval:    viper.GetDuration("propagation-interval"),
+
+		WatchBufferSize: viper.GetInt("watch-buffer-size"),
+
+		WebhookDir:      viper.GetString("webhook-dir"),
+		WebhookAttempts: viper.GetInt("webhook-attempts"),
+		WebhookBackoff:  viper.GetDuration("webhook-backoff"),
+		WebhookTimeout:  viper.GetDuration("webhook-timeout"),
+
+		WebhookAllowedNetworks: viper.GetStringSlice("webhook-allowed-networks"),
+
+		DNSUpdateEndpoint: viper.GetString("dns-update-endpoint"),
+		DNSUpdateKeys:     viper.GetString("dns-update-keys"),
+
+		Backend: viper.GetString("backend"),
+
+		PdnsApiUrl:      viper.GetString("pdns-api-url"),
+		PdnsApiPassword: viper.GetString("pdns-api-password"),
+		PdnsApiVHost:    viper.GetString("pdns-api-vhost"),
+		PdnsServers:     viper.GetString("pdns-servers"),
+
+		RFC2136Nameserver:    viper.GetString("rfc2136-nameserver"),
+		RFC2136Zones:         viper.GetStringSlice("rfc2136-zones"),
+		RFC2136TSIGKeyName:   viper.GetString("rfc2136-tsig-key-name"),
+		RFC2136TSIGAlgorithm: viper.GetString("rfc2136-tsig-algorithm"),
+		RFC2136TSIGSecret:    viper.GetString("rfc2136-tsig-secret"),
+		RFC2136Timeout:       viper.GetDuration("rfc2136-timeout"),
+
+		ZoneFileDirectory:     viper.GetString("zonefile-directory"),
+		ZoneFileReloadCommand: viper.GetString("zonefile-reload-command"),
+		ZoneFileReloadTimeout: viper.GetDuration("zonefile-reload-timeout"),
+
+		Mirrors:        viper.GetString("mirrors"),
+		MirrorQueueDir: viper.GetString("mirror-queue-dir"),
+		MirrorBackoff:  viper.GetDuration("mirror-backoff"),
+	}
 }
 
 func createLogger() (*zap.SugaredLogger, error) {
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/signal"
+	"syscall"
+
+	"github.com/majst01/metal-dns/pkg/mirror"
+	"github.com/spf13/cobra"
+)
+
+func newMirrorCmd() *cobra.Command {
+	mirrorCmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "inspect and repair the mirrors of the backend",
+	}
+	reconcileCmd := &cobra.Command{
+		Use:   "reconcile [ZONE...]",
+		Short: "compare the zones of the backend with its mirrors and repair them with --apply, the server configuration is read from --config and environment",
+		// differences and errors are reported by the reconciliation, usage would hide them
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := loadConfig(cmd, cmd.Root().Flags()); err != nil {
+				return err
+			}
+			apply, err := cmd.Flags().GetBool("apply")
+			if err != nil {
+				return err
+			}
+			prune, err := cmd.Flags().GetBool("prune")
+			if err != nil {
+				return err
+			}
+			names, err := cmd.Flags().GetStringSlice("mirror")
+			if err != nil {
+				return err
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			return reconcile(ctx, cmd.OutOrStdout(), args, names, mirror.Options{Apply: apply, Prune: prune})
+		},
+	}
+	reconcileCmd.Flags().Bool("apply", false, "repair the mirrors, otherwise the differences are only printed and the command fails if a zone differs")
+	reconcileCmd.Flags().Bool("prune", false, "delete zones from the mirrors which do not exist in the backend, the mirror may have zones of other sources")
+	reconcileCmd.Flags().StringSlice("mirror", nil, "names of the mirrors to reconcile, defaults to all")
+
+	mirrorCmd.AddCommand(reconcileCmd)
+	return mirrorCmd
+}
+
+// reconcile syncs the zones, all zones if empty, to the mirrors with names, all mirrors if empty, and prints the differences
+func reconcile(ctx context.Context, out io.Writer, zones, names []string, opts mirror.Options) error {
+	c := newDialConfig()
+	if c.Mirrors == "" {
+		return errors.New("no mirrors are configured")
+	}
+	client := &http.Client{}
+	primary, err := c.NewBackend(client)
+	if err != nil {
+		return err
+	}
+	mirrors, err := mirror.Load(c.Mirrors, client)
+	if err != nil {
+		return err
+	}
+	selected := mirrors
+	if len(names) > 0 {
+		selected = nil
+		for _, name := range names {
+			found := false
+			for _, m := range mirrors {
+				if m.Backend.Name == name {
+					selected = append(selected, m)
+					found = true
+				}
+			}
+			if !found {
+				return fmt.Errorf("mirror %s is not configured in %s", name, c.Mirrors)
+			}
+		}
+	}
+
+	var (
+		differ int
+		errs   []error
+	)
+	for _, m := range selected {
+		var results []*mirror.Result
+		if len(zones) == 0 {
+			results, err = mirror.Reconcile(ctx, primary, m, opts)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("mirror %s %w", m.Backend.Name, err))
+			}
+		} else {
+			for _, zone := range zones {
+				if !m.Mirrors(zone) {
+					continue
+				}
+				result, err := mirror.Sync(ctx, primary, m.Backend, zone, opts)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				results = append(results, result)
+			}
+		}
+		for _, r := range results {
+			if r.Kept {
+				fmt.Fprintf(out, "%s on mirror %s: not in the backend, kept without --prune\n", r.Zone, r.Mirror)
+			}
+			if r.InSync() {
+				continue
+			}
+			differ++
+			printResult(out, r)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if !opts.Apply && differ > 0 {
+		return fmt.Errorf("%d zones differ, repair them with --apply", differ)
+	}
+	if opts.Apply {
+		fmt.Fprintf(out, "%d zones repaired\n", differ)
+	} else {
+		fmt.Fprintln(out, "all zones are in sync")
+	}
+	return nil
+}
+
+// printResult prints the changes of a zone, + for rrsets added to the mirror, - for removed and ~ for changed ones
+func printResult(out io.Writer, r *mirror.Result) {
+	action := "update"
+	switch {
+	case r.Created:
+		action = "create"
+	case r.Deleted:
+		action = "delete"
+	}
+	fmt.Fprintf(out, "%s on mirror %s: %s zone\n", r.Zone, r.Mirror, action)
+	for _, c := range r.Changes {
+		sign := "~"
+		switch {
+		case c.Before == nil:
+			sign = "+"
+		case c.After == nil:
+			sign = "-"
+		}
+		fmt.Fprintf(out, "  %s %s %s\n", sign, c.Name, c.Type)
+	}
+}
@@ -0,0 +1,200 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var errNoState = errors.New("call has no rrset state")
+
+// mutatingProcedures are recorded in the audit log
+var mutatingProcedures = map[string]bool{
+	apiv1connect.DomainServiceCreateProcedure:     true,
+	apiv1connect.DomainServiceUpdateProcedure:     true,
+	apiv1connect.DomainServiceDeleteProcedure:     true,
+	apiv1connect.DomainServiceRollbackProcedure:   true,
+	apiv1connect.RecordServiceCreateProcedure:     true,
+	apiv1connect.RecordServiceUpdateProcedure:     true,
+	apiv1connect.RecordServiceDeleteProcedure:     true,
+	apiv1connect.ChallengeServicePresentProcedure: true,
+	apiv1connect.ChallengeServiceCleanUpProcedure: true,
+	apiv1connect.TokenServiceCreateProcedure:      true,
+}
+
+// Entry is a single call recorded in the audit log
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Procedure string    `json:"procedure"`
+	Subject   string    `json:"subject,omitempty"`
+	Issuer    string    `json:"issuer,omitempty"`
+	JTI       string    `json:"jti,omitempty"`
+	Domains   []string  `json:"domains,omitempty"`
+	// Names are the records affected by the call, empty if it affected whole domains
+	Names   []string     `json:"names,omitempty"`
+	Request string       `json:"request,omitempty"`
+	Before  []*v1.Record `json:"before,omitempty"`
+	After   []*v1.Record `json:"after,omitempty"`
+	Result  string       `json:"result"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// Filter selects entries from the audit log, empty fields match all entries
+type Filter struct {
+	// Domain matches entries which affected this domain or a name below it, and entries of whole domains containing it
+	Domain  string
+	Subject string
+	From    time.Time
+	To      time.Time
+}
+
+// Matches returns true if e is selected by f
+func (f Filter) Matches(e *Entry) bool {
+	if f.Subject != "" && e.Subject != f.Subject {
+		return false
+	}
+	if !f.From.IsZero() && e.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && e.Time.After(f.To) {
+		return false
+	}
+	if f.Domain == "" {
+		return true
+	}
+	domain := dns.CanonicalName(f.Domain)
+	for _, name := range append(e.Domains, e.Names...) {
+		if dns.IsSubDomain(domain, dns.CanonicalName(name)) {
+			return true
+		}
+	}
+	// a call without names affected all records of its domains
+	if len(e.Names) == 0 {
+		for _, d := range e.Domains {
+			if dns.IsSubDomain(dns.CanonicalName(d), domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Store is a append only storage of audit entries
+type Store interface {
+	Append(ctx context.Context, e *Entry) error
+	List(ctx context.Context, f Filter) ([]*Entry, error)
+}
+
+// Snapshot is the state of the rrsets affected by a call
+type Snapshot struct {
+	Domains []string
+	// Names of the affected records, empty if all records of the domains are affected
+	Names   []string
+	Records []*v1.Record
+}
+
+// Snapshotter reads the state of the rrsets which are affected by a mutating request
+type Snapshotter interface {
+	Snapshot(ctx context.Context, req any) (*Snapshot, error)
+}
+
+// Interceptor records all mutating calls in the store.
+// It must be installed after the authorizer to have access to the claims of the caller.
+type Interceptor struct {
+	log         *zap.SugaredLogger
+	store       Store
+	snapshotter Snapshotter
+}
+
+// NewInterceptor creates a interceptor which records mutating calls in store,
+// the state of the affected rrsets is read with snapshotter.
+func NewInterceptor(log *zap.SugaredLogger, store Store, snapshotter Snapshotter) *Interceptor {
+	return &Interceptor{
+		log:         log.Named("audit"),
+		store:       store,
+		snapshotter: snapshotter,
+	}
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if req.Spec().IsClient || !mutatingProcedures[procedure] {
+			return next(ctx, req)
+		}
+
+		e := &Entry{
+			Time:      time.Now(),
+			Procedure: procedure,
+			Result:    "ok",
+		}
+		if claims, ok := ctx.Value(token.DNSClaimsKey{}).(*token.DNSClaims); ok && claims != nil {
+			e.Subject = claims.Subject
+			e.Issuer = claims.Issuer
+			e.JTI = claims.ID
+		}
+		if msg, ok := req.Any().(proto.Message); ok {
+			request, err := protojson.Marshal(msg)
+			if err == nil {
+				e.Request = string(request)
+			}
+		}
+		if r, ok := req.Any().(*v1.TokenServiceCreateRequest); ok {
+			e.Domains = r.Domains
+		}
+
+		before, err := i.snapshot(ctx, req.Any())
+		if err == nil {
+			e.Domains = before.Domains
+			e.Names = before.Names
+			e.Before = before.Records
+		}
+
+		resp, callErr := next(ctx, req)
+		if callErr != nil {
+			e.Result = connect.CodeOf(callErr).String()
+			e.Error = callErr.Error()
+		}
+
+		after, err := i.snapshot(ctx, req.Any())
+		if err == nil {
+			e.Domains = after.Domains
+			e.Names = after.Names
+			e.After = after.Records
+		}
+
+		if err := i.store.Append(ctx, e); err != nil {
+			i.log.Errorw("unable to write audit log entry", "procedure", procedure, "subject", e.Subject, "error", err)
+		}
+		return resp, callErr
+	})
+}
+
+func (i *Interceptor) snapshot(ctx context.Context, req any) (*Snapshot, error) {
+	if _, ok := req.(*v1.TokenServiceCreateRequest); ok {
+		return nil, errNoState
+	}
+	s, err := i.snapshotter.Snapshot(ctx, req)
+	if err != nil {
+		i.log.Warnw("unable to read state for audit log", "error", err)
+		return nil, err
+	}
+	return s, nil
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
@@ -0,0 +1,186 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFilter(t *testing.T) {
+	now := time.Now()
+	e := &Entry{Time: now, Subject: "alice", Domains: []string{"a.example.com."}, Names: []string{"www.a.example.com."}}
+	domain := &Entry{Time: now, Subject: "alice", Domains: []string{"a.example.com."}}
+	tests := []struct {
+		name   string
+		entry  *Entry
+		filter Filter
+		want   bool
+	}{
+		{name: "empty", filter: Filter{}, want: true},
+		{name: "subject", filter: Filter{Subject: "alice"}, want: true},
+		{name: "other subject", filter: Filter{Subject: "bob"}, want: false},
+		{name: "domain", filter: Filter{Domain: "a.example.com."}, want: true},
+		{name: "record", filter: Filter{Domain: "www.a.example.com."}, want: true},
+		{name: "other record", filter: Filter{Domain: "mail.a.example.com."}, want: false},
+		{name: "parent domain", filter: Filter{Domain: "example.com."}, want: true},
+		{name: "other domain", filter: Filter{Domain: "b.example.com."}, want: false},
+		{name: "domain with the same suffix", filter: Filter{Domain: "xa.example.com."}, want: false},
+		{name: "record of a domain call", entry: domain, filter: Filter{Domain: "www.a.example.com."}, want: true},
+		{name: "parent of a domain call", entry: domain, filter: Filter{Domain: "example.com."}, want: true},
+		{name: "suffix of a domain call", entry: domain, filter: Filter{Domain: "example.com"}, want: true},
+		{name: "label suffix of a domain call", entry: domain, filter: Filter{Domain: "e.com."}, want: false},
+		{name: "in range", filter: Filter{From: now.Add(-time.Minute), To: now.Add(time.Minute)}, want: true},
+		{name: "before range", filter: Filter{From: now.Add(time.Minute)}, want: false},
+		{name: "after range", filter: Filter{To: now.Add(-time.Minute)}, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			entry := e
+			if tt.entry != nil {
+				entry = tt.entry
+			}
+			require.Equal(t, tt.want, tt.filter.Matches(entry))
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(t, err)
+	defer s.Close()
+
+	require.NoError(t, s.Append(ctx, &Entry{Time: time.Now(), Procedure: "a", Subject: "alice", Domains: []string{"a.example.com."}, Result: "ok"}))
+	require.NoError(t, s.Append(ctx, &Entry{Time: time.Now(), Procedure: "b", Subject: "bob", Domains: []string{"b.example.com."}, Result: "ok",
+		After: []*v1.Record{{Name: "www.b.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4", Ttl: 300}}}))
+
+	entries, err := s.List(ctx, Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "a", entries[0].Procedure)
+	require.Equal(t, "1.2.3.4", entries[1].After[0].Data)
+
+	entries, err = s.List(ctx, Filter{Subject: "bob"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "b", entries[0].Procedure)
+}
+
+type memStore struct {
+	entries []*Entry
+}
+
+func (m *memStore) Append(_ context.Context, e *Entry) error {
+	m.entries = append(m.entries, e)
+	return nil
+}
+
+func (m *memStore) List(_ context.Context, f Filter) ([]*Entry, error) {
+	return m.entries, nil
+}
+
+// fakeRecords simulates a backend where Create adds a single record
+type fakeRecords struct {
+	apiv1connect.UnimplementedRecordServiceHandler
+	records []*v1.Record
+	err     error
+}
+
+func (f *fakeRecords) Snapshot(_ context.Context, req any) (*Snapshot, error) {
+	s := &Snapshot{Domains: []string{"a.example.com."}, Records: append([]*v1.Record{}, f.records...)}
+	if r, ok := req.(*v1.RecordServiceCreateRequest); ok {
+		s.Names = []string{r.Name}
+	}
+	return s, nil
+}
+
+func (f *fakeRecords) Create(_ context.Context, rq *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	r := &v1.Record{Name: rq.Msg.Name, Type: rq.Msg.Type, Data: rq.Msg.Data, Ttl: rq.Msg.Ttl}
+	f.records = append(f.records, r)
+	return connect.NewResponse(&v1.RecordServiceCreateResponse{Record: r}), nil
+}
+
+func (f *fakeRecords) List(context.Context, *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
+	return connect.NewResponse(&v1.RecordServiceListResponse{Records: f.records}), nil
+}
+
+func (f *fakeRecords) Update(context.Context, *connect.Request[v1.RecordServiceUpdateRequest]) (*connect.Response[v1.RecordServiceUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+func (f *fakeRecords) Delete(context.Context, *connect.Request[v1.RecordServiceDeleteRequest]) (*connect.Response[v1.RecordServiceDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, nil)
+}
+
+// withClaims simulates the authorizer
+type withClaims struct {
+	connect.Interceptor
+}
+
+func (w withClaims) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		claims := &token.DNSClaims{}
+		claims.Subject = "alice"
+		claims.Issuer = "Tester"
+		claims.ID = "test-jti"
+		return next(token.ContextWithClaims(ctx, claims), req)
+	}
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{}
+	records := &fakeRecords{}
+
+	i := NewInterceptor(zaptest.NewLogger(t).Sugar(), store, records)
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewRecordServiceHandler(records, connect.WithInterceptors(withClaims{Interceptor: i}, i)))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := apiv1connect.NewRecordServiceClient(server.Client(), server.URL)
+
+	_, err := c.List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: "a.example.com."}))
+	require.NoError(t, err)
+	require.Empty(t, store.entries, "reads must not be recorded")
+
+	_, err = c.Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{Name: "www.a.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4", Ttl: 300}))
+	require.NoError(t, err)
+	require.Len(t, store.entries, 1)
+	e := store.entries[0]
+	require.Equal(t, apiv1connect.RecordServiceCreateProcedure, e.Procedure)
+	require.Equal(t, "alice", e.Subject)
+	require.Equal(t, "Tester", e.Issuer)
+	require.Equal(t, "test-jti", e.JTI)
+	require.Equal(t, []string{"a.example.com."}, e.Domains)
+	require.Equal(t, []string{"www.a.example.com."}, e.Names)
+	require.Equal(t, "ok", e.Result)
+	require.Empty(t, e.Before)
+	require.Len(t, e.After, 1)
+	require.Contains(t, e.Request, "www.a.example.com.")
+
+	records.err = connect.NewError(connect.CodeInternal, errors.New("backend failed"))
+	_, err = c.Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{Name: "mail.a.example.com.", Type: v1.RecordType_A, Data: "1.2.3.5", Ttl: 300}))
+	require.Error(t, err)
+	require.Len(t, store.entries, 2)
+	e = store.entries[1]
+	require.Equal(t, "internal", e.Result)
+	require.Contains(t, e.Error, "backend failed")
+	require.Len(t, e.Before, 1)
+	require.Len(t, e.After, 1)
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileStore appends audit entries as json lines to a file
+type FileStore struct {
+	lock sync.Mutex
+	path string
+	f    *os.File
+}
+
+// NewFileStore opens or creates the audit log at path
+func NewFileStore(path string) (*FileStore, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log %w", err)
+	}
+	return &FileStore{
+		path: path,
+		f:    f,
+	}, nil
+}
+
+// Append writes e to the end of the audit log and syncs the file
+func (s *FileStore) Append(ctx context.Context, e *Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, err = s.f.Write(line)
+	if err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+// List returns all entries selected by f in the order they were written
+func (s *FileStore) List(ctx context.Context, f Filter) ([]*Entry, error) {
+	file, err := os.Open(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open audit log %w", err)
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("audit log is corrupt %w", err)
+		}
+		if f.Matches(&e) {
+			entries = append(entries, &e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Close closes the audit log
+func (s *FileStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.f.Close()
+}
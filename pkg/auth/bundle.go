@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/open-policy-agent/opa/bundle"
+	"github.com/open-policy-agent/opa/loader"
+)
+
+// reloadDelay is the time to wait for further changes in the policy path before policies are reloaded,
+// editors and deployment tools tend to write files in several steps.
+var reloadDelay = 500 * time.Millisecond
+
+// loadBundle reads rego modules and data documents from a directory or a bundle tarball.
+func loadBundle(path string) (*bundle.Bundle, error) {
+	b, err := loader.NewFileLoader().WithSkipBundleVerification(true).AsBundle(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load policies from %s %w", path, err)
+	}
+	return b, nil
+}
+
+// Reload compiles the policies again and activates them.
+// Calls which are already evaluated finish with the previous policies.
+// If compilation fails, the previous policies stay active.
+func (o *OpaAuther) Reload(ctx context.Context) error {
+	qDecision, err := o.prepare(ctx)
+	if err != nil {
+		return err
+	}
+	o.qDecision.Store(qDecision)
+	return nil
+}
+
+// WatchPolicies reloads the policies whenever the content of the policy path changes, until ctx is done.
+func (o *OpaAuther) WatchPolicies(ctx context.Context) error {
+	if o.policyPath == "" {
+		return fmt.Errorf("no policy path configured")
+	}
+	info, err := os.Stat(o.policyPath)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create policy watcher %w", err)
+	}
+
+	if info.IsDir() {
+		err = filepath.WalkDir(o.policyPath, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return watcher.Add(path)
+			}
+			return nil
+		})
+	} else {
+		// watch the parent directory, bundles are usually replaced by a rename which removes the watch of the file
+		err = watcher.Add(filepath.Dir(o.policyPath))
+	}
+	if err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("unable to watch policies in %s %w", o.policyPath, err)
+	}
+
+	go o.watch(ctx, watcher, info.IsDir())
+	return nil
+}
+
+func (o *OpaAuther) watch(ctx context.Context, watcher *fsnotify.Watcher, isDir bool) {
+	defer watcher.Close()
+
+	bundleFile := filepath.Clean(o.policyPath)
+	reload := time.NewTimer(reloadDelay)
+	reload.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			reload.Stop()
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !isDir && filepath.Clean(event.Name) != bundleFile {
+				continue
+			}
+			if isDir && event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := watcher.Add(event.Name); err != nil {
+						o.log.Errorw("unable to watch policy directory", "path", event.Name, "error", err)
+					}
+				}
+			}
+			o.log.Debugw("policy change detected", "event", event)
+			reload.Reset(reloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			o.log.Errorw("error watching policies", "path", o.policyPath, "error", err)
+		case <-reload.C:
+			if err := o.Reload(ctx); err != nil {
+				o.log.Errorw("unable to reload policies, keep serving the previous ones", "path", o.policyPath, "error", err)
+				continue
+			}
+			o.log.Infow("policies reloaded", "path", o.policyPath)
+		}
+	}
+}
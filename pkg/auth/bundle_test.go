@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const restrictedTokenService = `package api.v1.metalstack.io.authz
+
+e = {"permission": permissions["/api.v1.TokenService/Create"], "public": false} {
+	input.method == "/api.v1.TokenService/Create"
+	input.method == token.payload.permissions[_]
+}
+`
+
+const adminsFromData = `package api.v1.metalstack.io.authz
+
+e = {"permission": permissions["/api.v1.DomainService/Create"], "public": false} {
+	input.method == "/api.v1.DomainService/Create"
+	token.payload.sub == data.admins[_]
+}
+`
+
+func TestPolicyPathReplacesEmbedded(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tokenservice.rego"), []byte(restrictedTokenService), 0600))
+
+	o, err := NewOpaAuther(log, "secret", WithPolicyPath(dir))
+	require.NoError(t, err)
+
+	jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, nil)
+	got, err := o.Explain(ctx, "/api.v1.TokenService/Create", nil, jwtToken)
+	require.NoError(t, err)
+	require.False(t, got.Allow)
+
+	jwtToken = newTestToken(t, "secret", []string{"a.example.com."}, []string{"/api.v1.TokenService/Create"})
+	got, err = o.Explain(ctx, "/api.v1.TokenService/Create", nil, jwtToken)
+	require.NoError(t, err)
+	require.True(t, got.Allow)
+}
+
+func TestWatchPolicies(t *testing.T) {
+	reloadDelay = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := zaptest.NewLogger(t).Sugar()
+
+	dir := t.TempDir()
+	o, err := NewOpaAuther(log, "secret", WithPolicyPath(dir))
+	require.NoError(t, err)
+	require.NoError(t, o.WatchPolicies(ctx))
+
+	jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, nil)
+	allowed := func() bool {
+		got, err := o.Explain(ctx, "/api.v1.DomainService/Create", map[string]any{"name": "a.example.com."}, jwtToken)
+		require.NoError(t, err)
+		return got.Allow
+	}
+	require.False(t, allowed())
+
+	// add a module and data which allow the token subject to create domains
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{"admins": ["metal-dns"]}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "admins.rego"), []byte(adminsFromData), 0600))
+	require.Eventually(t, allowed, 5*time.Second, 20*time.Millisecond)
+
+	// a broken module must not replace the last good policies
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.rego"), []byte("package broken\n\nallow {"), 0600))
+	time.Sleep(10 * reloadDelay)
+	require.True(t, allowed())
+
+	// once the module is fixed, changes are picked up again
+	require.NoError(t, os.Remove(filepath.Join(dir, "broken.rego")))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.json"), []byte(`{"admins": []}`), 0600))
+	require.Eventually(t, func() bool { return !allowed() }, 5*time.Second, 20*time.Millisecond)
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/majst01/metal-dns/pkg/token"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ClientCertIssuer is the issuer of tokens which are created for client certificates
+	ClientCertIssuer = "metal-dns-client-certificate"
+	// TSIGIssuer is the issuer of tokens which are created for the TSIG keys of dynamic updates
+	TSIGIssuer = "metal-dns-tsig"
+	// clientCertTokenExpiry is the lifetime of a token created for a client certificate, it is only used for a single call
+	clientCertTokenExpiry = time.Minute
+)
+
+// ClientSubject grants domains and permissions to the client certificates with the given subject
+type ClientSubject struct {
+	// Subject is compared to the common name and to the full distinguished name of the certificate,
+	// e.g. "external-dns" or "CN=external-dns,O=metal-stack"
+	Subject     string   `yaml:"subject"`
+	Domains     []string `yaml:"domains"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// LoadClientSubjects reads a list of ClientSubject from a yaml file at path
+func LoadClientSubjects(path string) ([]ClientSubject, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read client subjects %w", err)
+	}
+	var subjects []ClientSubject
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	err = dec.Decode(&subjects)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse client subjects in %s %w", path, err)
+	}
+	for _, s := range subjects {
+		if s.Subject == "" {
+			return nil, fmt.Errorf("client subject in %s without subject", path)
+		}
+	}
+	return subjects, nil
+}
+
+// WithClientSubjects authenticates calls without a bearer token with their verified client certificate,
+// the domains and permissions of the matching subject are evaluated by the policies like a token.
+// Requires the http handler to be wrapped with ClientCertificateHandler.
+func WithClientSubjects(subjects []ClientSubject) Option {
+	return func(o *OpaAuther) {
+		o.clientSubjects = subjects
+	}
+}
+
+type clientCertKey struct{}
+
+// ClientCertificateHandler stores the verified client certificate of a TLS connection in the request context
+func ClientCertificateHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), clientCertKey{}, r.TLS.VerifiedChains[0][0]))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientCertToken returns a token for the client certificate in ctx if its subject is mapped
+func (o *OpaAuther) clientCertToken(ctx context.Context) (string, bool, error) {
+	cert, ok := ctx.Value(clientCertKey{}).(*x509.Certificate)
+	if !ok || cert == nil {
+		return "", false, nil
+	}
+	for _, s := range o.clientSubjects {
+		if s.Subject != cert.Subject.CommonName && s.Subject != cert.Subject.String() {
+			continue
+		}
+		t, err := token.NewJWTToken(cert.Subject.String(), ClientCertIssuer, s.Domains, s.Permissions, clientCertTokenExpiry, o.secret)
+		if err != nil {
+			return "", false, err
+		}
+		return t, true, nil
+	}
+	o.log.Debugw("no client subject matches certificate", "subject", cert.Subject.String())
+	return "", false, nil
+}
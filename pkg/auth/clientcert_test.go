@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/majst01/metal-dns/test"
+	testv1 "github.com/majst01/metal-dns/test/v1"
+	"github.com/majst01/metal-dns/test/v1/testv1connect"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLoadClientSubjects(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`
+- subject: external-dns
+  domains: [a.example.com.]
+  permissions: [/api.v1.RecordService/Create]
+`), 0600))
+	subjects, err := LoadClientSubjects(valid)
+	require.NoError(t, err)
+	require.Equal(t, []ClientSubject{{Subject: "external-dns", Domains: []string{"a.example.com."}, Permissions: []string{"/api.v1.RecordService/Create"}}}, subjects)
+
+	unknownField := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknownField, []byte(`
+- subject: external-dns
+  domain: [a.example.com.]
+`), 0600))
+	_, err = LoadClientSubjects(unknownField)
+	require.Error(t, err)
+
+	noSubject := filepath.Join(dir, "nosubject.yaml")
+	require.NoError(t, os.WriteFile(noSubject, []byte(`
+- domains: [a.example.com.]
+`), 0600))
+	_, err = LoadClientSubjects(noSubject)
+	require.Error(t, err)
+}
+
+func TestClientCertificate(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "streamingtestservice.rego"), []byte(streamingTestServicePolicy), 0600))
+
+	o, err := NewOpaAuther(log, "secret", WithPolicyPath(dir), WithClientSubjects([]ClientSubject{
+		{Subject: "external-dns", Domains: []string{"a.example.com."}, Permissions: []string{"/test.v1.StreamingTestService/ServerStream"}},
+	}))
+	require.NoError(t, err)
+
+	ca, err := test.NewCA()
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(ca.PEM))
+
+	mux := http.NewServeMux()
+	mux.Handle(testv1connect.NewStreamingTestServiceHandler(&streamingTestService{}, connect.WithInterceptors(o)))
+	server := httptest.NewUnstartedServer(ClientCertificateHandler(mux))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{ClientCAs: pool, ClientAuth: tls.VerifyClientCertIfGiven, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	clientWithCert := func(commonName string) testv1connect.StreamingTestServiceClient {
+		certPEM, keyPEM, err := ca.Issue(commonName)
+		require.NoError(t, err)
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		require.NoError(t, err)
+		transport := server.Client().Transport.(*http.Transport).Clone()
+		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+		return testv1connect.NewStreamingTestServiceClient(&http.Client{Transport: transport}, server.URL)
+	}
+
+	serverStream := func(c testv1connect.StreamingTestServiceClient, jwtToken, name string) ([]*testv1.StreamingTestServiceServerStreamResponse, error) {
+		req := connect.NewRequest(&testv1.StreamingTestServiceServerStreamRequest{Name: name, Count: 1})
+		if jwtToken != "" {
+			req.Header().Set("Authorization", "Bearer "+jwtToken)
+		}
+		stream, err := c.ServerStream(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close()
+		var resps []*testv1.StreamingTestServiceServerStreamResponse
+		for stream.Receive() {
+			resps = append(resps, stream.Msg())
+		}
+		return resps, stream.Err()
+	}
+
+	t.Run("mapped certificate allowed", func(t *testing.T) {
+		resps, err := serverStream(clientWithCert("external-dns"), "", "www.a.example.com.")
+		require.NoError(t, err)
+		require.Len(t, resps, 1)
+		require.Equal(t, "CN=external-dns", resps[0].Subject)
+	})
+	t.Run("mapped certificate denied by request", func(t *testing.T) {
+		_, err := serverStream(clientWithCert("external-dns"), "", "www.b.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("unmapped certificate", func(t *testing.T) {
+		_, err := serverStream(clientWithCert("somebody"), "", "www.a.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("without certificate", func(t *testing.T) {
+		c := testv1connect.NewStreamingTestServiceClient(server.Client(), server.URL)
+		_, err := serverStream(c, "", "www.a.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("token takes precedence over certificate", func(t *testing.T) {
+		jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, []string{"/test.v1.StreamingTestService/ServerStream"})
+		resps, err := serverStream(clientWithCert("external-dns"), jwtToken, "www.a.example.com.")
+		require.NoError(t, err)
+		require.Equal(t, "metal-dns", resps[0].Subject)
+	})
+}
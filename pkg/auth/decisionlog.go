@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/majst01/metal-dns/pkg/token"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DecisionLogConfig configures additional sinks for the authorization decision log.
+// Decisions are always written to the server log, File and URL are optional.
+type DecisionLogConfig struct {
+	// File is the path to a file where decisions are appended as json lines
+	File string
+	// URL is a http endpoint where every decision is posted as json
+	URL string
+}
+
+// NewDecisionLogger creates a logger which writes decisions to log and to all sinks configured in c.
+// The returned closer closes the file of the decision log and must be called on shutdown.
+func NewDecisionLogger(log *zap.SugaredLogger, c DecisionLogConfig) (*zap.SugaredLogger, io.Closer, error) {
+	var (
+		cores  []zapcore.Core
+		closer decisionLogFile
+	)
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoder := zapcore.NewJSONEncoder(encoderConfig)
+
+	if c.File != "" {
+		f, err := os.OpenFile(c.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to open decision log file %w", err)
+		}
+		closer.f = f
+		cores = append(cores, zapcore.NewCore(encoder, zapcore.Lock(f), zapcore.InfoLevel))
+	}
+	if c.URL != "" {
+		sink := newHTTPSink(log, c.URL)
+		cores = append(cores, zapcore.NewCore(encoder, sink, zapcore.InfoLevel))
+	}
+
+	decisionLog := log.Named("decision")
+	if len(cores) == 0 {
+		return decisionLog, closer, nil
+	}
+	return decisionLog.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(append(cores, core)...)
+	})), closer, nil
+}
+
+// decisionLogFile closes the file of the decision log if one is configured
+type decisionLogFile struct {
+	f *os.File
+}
+
+func (d decisionLogFile) Close() error {
+	if d.f == nil {
+		return nil
+	}
+	return d.f.Close()
+}
+
+func (o *OpaAuther) logDecision(method string, claims *token.DNSClaims, d *decision, err error, latency time.Duration) {
+	fields := []any{
+		"method", method,
+		"latency", latency,
+	}
+	if claims != nil {
+		fields = append(fields, "subject", claims.Subject, "issuer", claims.Issuer, "jti", claims.ID)
+	}
+	if err != nil {
+		o.decisionLog.Errorw("decision", append(fields, "allow", false, "error", err)...)
+		return
+	}
+	fields = append(fields, "allow", d.allow, "admin", d.isAdmin)
+	if d.reason != "" {
+		fields = append(fields, "reason", d.reason)
+	}
+	o.decisionLog.Infow("decision", fields...)
+}
+
+// httpSink posts every log entry to url. Entries are sent asynchronously,
+// if the endpoint is not able to keep up, entries are dropped.
+type httpSink struct {
+	log     *zap.SugaredLogger
+	url     string
+	client  *http.Client
+	entries chan []byte
+}
+
+func newHTTPSink(log *zap.SugaredLogger, url string) *httpSink {
+	s := &httpSink{
+		log:     log.Named("decision-sink"),
+		url:     url,
+		client:  &http.Client{Timeout: 5 * time.Second},
+		entries: make(chan []byte, 1024),
+	}
+	go s.run()
+	return s
+}
+
+func (s *httpSink) Write(p []byte) (int, error) {
+	// p is reused by zap after Write returns
+	entry := make([]byte, len(p))
+	copy(entry, p)
+	select {
+	case s.entries <- entry:
+	default:
+		s.log.Warnw("decision log endpoint too slow, dropping entry", "url", s.url)
+	}
+	return len(p), nil
+}
+
+func (s *httpSink) Sync() error {
+	return nil
+}
+
+func (s *httpSink) run() {
+	for entry := range s.entries {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, s.url, bytes.NewReader(entry))
+		if err != nil {
+			s.log.Errorw("unable to create decision log request", "error", err)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := s.client.Do(req)
+		if err != nil {
+			s.log.Errorw("unable to send decision log entry", "url", s.url, "error", err)
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			s.log.Errorw("unable to send decision log entry", "url", s.url, "status", resp.Status)
+		}
+	}
+}
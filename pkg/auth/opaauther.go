@@ -22,15 +22,23 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/bufbuild/connect-go"
 	"github.com/majst01/metal-dns/pkg/policies"
 	"github.com/majst01/metal-dns/pkg/token"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 	"github.com/open-policy-agent/opa/storage/inmem"
 	"github.com/open-policy-agent/opa/topdown"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.uber.org/zap"
 )
@@ -39,52 +47,129 @@ const (
 	authorizationHeader = "authorization"
 )
 
-// FIXME This buffer need to be cleared after every call
-var buf bytes.Buffer
+const tracerName = "github.com/majst01/metal-dns/pkg/auth"
 
 // OpaAuther is a gRPC server authorizer using OPA as backend
 type OpaAuther struct {
-	qDecision *rego.PreparedEvalQuery
-	log       *zap.SugaredLogger
-	secret    string
+	qDecision   atomic.Pointer[rego.PreparedEvalQuery]
+	log         *zap.SugaredLogger
+	decisionLog *zap.SugaredLogger
+	observer    DecisionObserver
+	tracer      trace.Tracer
+	secret      string
+	policyPath  string
+	// clientSubjects map client certificates to domains and permissions
+	clientSubjects []ClientSubject
+}
+
+// DecisionObserver gets notified about every authorization decision
+type DecisionObserver interface {
+	ObserveDecision(procedure string, allow bool, err error, duration time.Duration)
+}
+
+// Option configures optional behavior of the OpaAuther
+type Option func(o *OpaAuther)
+
+// WithDecisionLog sends every authorization decision to the given logger
+// instead of the default one, see NewDecisionLogger.
+func WithDecisionLog(decisionLog *zap.SugaredLogger) Option {
+	return func(o *OpaAuther) {
+		o.decisionLog = decisionLog
+	}
+}
+
+// WithDecisionObserver notifies observer about every authorization decision, e.g. to record metrics.
+func WithDecisionObserver(observer DecisionObserver) Option {
+	return func(o *OpaAuther) {
+		o.observer = observer
+	}
+}
+
+// WithTracerProvider creates the spans of the decisions with tp instead of the global tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *OpaAuther) {
+		o.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// WithPolicyPath loads additional rego modules and data from a directory or bundle tarball at path.
+// Modules with the same file name as one of the embedded policies replace the embedded one.
+func WithPolicyPath(path string) Option {
+	return func(o *OpaAuther) {
+		o.policyPath = path
+	}
 }
 
 // NewOpaAuther creates an OPA authorizer
-func NewOpaAuther(log *zap.SugaredLogger, secret string) (*OpaAuther, error) {
+func NewOpaAuther(log *zap.SugaredLogger, secret string, opts ...Option) (*OpaAuther, error) {
+	o := &OpaAuther{
+		log:         log,
+		decisionLog: log.Named("decision"),
+		tracer:      otel.Tracer(tracerName),
+		secret:      secret,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	qDecision, err := o.prepare(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	o.qDecision.Store(qDecision)
+	return o, nil
+}
+
+// prepare compiles the embedded policies together with the policies found at the policy path.
+func (o *OpaAuther) prepare(ctx context.Context) (*rego.PreparedEvalQuery, error) {
 	files, err := policies.RegoPolicies.ReadDir(".")
 	if err != nil {
 		return nil, err
 	}
 
-	var moduleLoads []func(r *rego.Rego)
+	modules := map[string]string{}
 	for _, f := range files {
 		data, err := policies.RegoPolicies.ReadFile(f.Name())
 		if err != nil {
 			return nil, err
 		}
-		moduleLoads = append(moduleLoads, rego.Module(f.Name(), string(data)))
+		modules[f.Name()] = string(data)
+	}
+
+	documents := map[string]any{}
+	if o.policyPath != "" {
+		b, err := loadBundle(o.policyPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range b.Modules {
+			delete(modules, filepath.Base(m.Path))
+			modules[m.Path] = string(m.Raw)
+		}
+		for k, v := range b.Data {
+			documents[k] = v
+		}
+	}
+
+	var moduleLoads []func(r *rego.Rego)
+	for name, module := range modules {
+		moduleLoads = append(moduleLoads, rego.Module(name, module))
 	}
 	// will be accessible as data.secret/roles/methods in rego rules
-	data := inmem.NewFromObject(map[string]any{
-		"secret": secret,
-	})
+	documents["secret"] = o.secret
+	data := inmem.NewFromObject(documents)
 
 	moduleLoads = append(moduleLoads, rego.Query("x = data.api.v1.metalstack.io.authz.decision"))
 	moduleLoads = append(moduleLoads, rego.EnablePrintStatements(true))
-	moduleLoads = append(moduleLoads, rego.PrintHook(topdown.NewPrintHook(&buf)))
 	moduleLoads = append(moduleLoads, rego.Store(data))
 
 	qDecision, err := rego.New(
 		moduleLoads...,
-	).PrepareForEval(context.Background())
+	).PrepareForEval(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &OpaAuther{
-		log:       log,
-		secret:    secret,
-		qDecision: &qDecision,
-	}, nil
+	return &qDecision, nil
 }
 
 func (o *OpaAuther) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
@@ -97,8 +182,19 @@ func (o *OpaAuther) WrapStreamingClient(next connect.StreamingClientFunc) connec
 // WrapStreamingHandler is a Opa StreamServerInterceptor for the
 // server. Only one stream interceptor can be installed.
 // If you want to add extra functionality you might decorate this function.
+// The first message of the stream is received before the decision is made,
+// to be able to evaluate rules which depend on the request.
 func (o *OpaAuther) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
 	return connect.StreamingHandlerFunc(func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		conn, req, err := receiveFirst(conn)
+		if err != nil {
+			return err
+		}
+		claims, jwtToken, err := o.authorize(ctx, conn.Spec().Procedure, conn.RequestHeader().Get, req)
+		if err != nil {
+			return err
+		}
+		ctx = token.ContextWithToken(token.ContextWithClaims(ctx, claims), jwtToken)
 		return next(ctx, conn)
 	})
 }
@@ -109,69 +205,168 @@ func (o *OpaAuther) WrapStreamingHandler(next connect.StreamingHandlerFunc) conn
 func (o *OpaAuther) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	// Same as previous UnaryInterceptorFunc.
 	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
-		claims, err := o.authorize(ctx, req.Spec().Procedure, req.Header().Get, req.Any())
+		claims, jwtToken, err := o.authorize(ctx, req.Spec().Procedure, req.Header().Get, req.Any())
 		if err != nil {
 			return nil, err
 		}
-		ctx = token.ContextWithClaims(ctx, claims)
+		ctx = token.ContextWithToken(token.ContextWithClaims(ctx, claims), jwtToken)
 		return next(ctx, req)
 	})
 }
-func (o *OpaAuther) authorize(ctx context.Context, methodName string, jwtTokenfunc func(string) string, req any) (*token.DNSClaims, error) {
+func (o *OpaAuther) authorize(ctx context.Context, methodName string, jwtTokenfunc func(string) string, req any) (*token.DNSClaims, string, error) {
 	o.log.Debugw("authorize", "method", methodName, "req", req)
 	// FIXME put this into a central config map
 	if methodName == "/grpc.health.v1.Health/Check" {
-		return nil, nil
+		return nil, "", nil
 	}
 	jwtToken, err := ExtractJWT(jwtTokenfunc)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		// a bearer token takes precedence over the client certificate
+		certToken, ok, certErr := o.clientCertToken(ctx)
+		if certErr != nil {
+			return nil, "", connect.NewError(connect.CodeInternal, certErr)
+		}
+		if !ok {
+			return nil, "", connect.NewError(connect.CodeUnauthenticated, err)
+		}
+		jwtToken = certToken
 	}
 	claims, _ := token.ParseJWTToken(jwtToken)
-	ok, err := o.decide(ctx, newOpaRequest(methodName, req, jwtToken), methodName)
+
+	start := time.Now()
+	d, err := o.decide(ctx, newOpaRequest(methodName, req, jwtToken), nil)
+	latency := time.Since(start)
+	o.logDecision(methodName, claims, d, err, latency)
+	if o.observer != nil {
+		o.observer.ObserveDecision(methodName, d != nil && d.allow, err, latency)
+	}
+	if err != nil {
+		return nil, "", connect.NewError(connect.CodeUnauthenticated, err)
+	}
+
+	if !d.allow {
+		if d.reason != "" {
+			return nil, "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("access denied: %s", d.reason))
+		}
+		return nil, "", connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("access denied to:%s", methodName))
+	}
+	return claims, jwtToken, nil
+}
+
+// Explanation describes how the policies decided about a single call
+type Explanation struct {
+	Allow   bool
+	IsAdmin bool
+	Reason  string
+	// Rules which evaluated successfully, formatted as "name file:row"
+	Rules []string
+	// Prints contains the output of all print statements hit during evaluation
+	Prints []string
+}
+
+// Explain evaluates the policies for a hypothetical call of method with req and the given token,
+// and reports which rules contributed to the decision.
+func (o *OpaAuther) Explain(ctx context.Context, method string, req any, jwtToken string) (*Explanation, error) {
+	tracer := topdown.NewBufferTracer()
+	d, err := o.decide(ctx, newOpaRequest(method, req, jwtToken), tracer)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeUnauthenticated, err)
+		return nil, err
 	}
 
-	if ok {
-		return claims, nil
+	seen := map[string]bool{}
+	var rules []string
+	for _, event := range *tracer {
+		if event.Op != topdown.ExitOp {
+			continue
+		}
+		rule, ok := event.Node.(*ast.Rule)
+		if !ok || rule.Location == nil {
+			continue
+		}
+		r := fmt.Sprintf("%s %s:%d", rule.Head.Ref(), rule.Location.File, rule.Location.Row)
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		rules = append(rules, r)
 	}
-	return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("not allowed to call: %s", methodName))
 
+	return &Explanation{
+		Allow:   d.allow,
+		IsAdmin: d.isAdmin,
+		Reason:  d.reason,
+		Rules:   rules,
+		Prints:  d.prints,
+	}, nil
+}
+
+type decision struct {
+	allow   bool
+	isAdmin bool
+	reason  string
+	prints  []string
+}
+
+func (o *OpaAuther) decide(ctx context.Context, input map[string]any, queryTracer topdown.QueryTracer) (*decision, error) {
+	method, _ := input["method"].(string)
+	ctx, span := o.tracer.Start(ctx, "OpaAuther.decide", trace.WithAttributes(attribute.String("rpc.method", method)))
+	defer span.End()
+
+	d, err := o.evaluate(ctx, input, queryTracer)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(attribute.Bool("authz.allow", d.allow))
+	if d.reason != "" {
+		span.SetAttributes(attribute.String("authz.reason", d.reason))
+	}
+	return d, nil
 }
 
-func (o *OpaAuther) decide(ctx context.Context, input map[string]any, method string) (bool, error) {
-	o.log.Infow("rego evaluation", "input", input)
-	results, err := o.qDecision.Eval(ctx, rego.EvalInput(input))
+func (o *OpaAuther) evaluate(ctx context.Context, input map[string]any, queryTracer topdown.QueryTracer) (*decision, error) {
+	o.log.Debugw("rego evaluation", "method", input["method"])
+
+	// print statements are captured per evaluation, concurrent calls must not share a buffer
+	var buf bytes.Buffer
+	evalOpts := []rego.EvalOption{
+		rego.EvalInput(input),
+		rego.EvalPrintHook(topdown.NewPrintHook(&buf)),
+	}
+	if queryTracer != nil {
+		evalOpts = append(evalOpts, rego.EvalQueryTracer(queryTracer))
+	}
+
+	results, err := o.qDecision.Load().Eval(ctx, evalOpts...)
 	if err != nil {
-		return false, fmt.Errorf("error evaluating rego result set %w", err)
+		return nil, fmt.Errorf("error evaluating rego result set %w", err)
 	}
 	if len(results) == 0 {
-		return false, fmt.Errorf("error evaluating rego result set: results have no length")
+		return nil, fmt.Errorf("error evaluating rego result set: results have no length")
 	}
 
-	decision, ok := results[0].Bindings["x"].(map[string]any)
+	result, ok := results[0].Bindings["x"].(map[string]any)
 	if !ok {
-		return false, fmt.Errorf("error evaluating rego result set: unexpected response type")
+		return nil, fmt.Errorf("error evaluating rego result set: unexpected response type")
 	}
 
-	o.log.Debugw("made auth decision", "decision", decision)
+	o.log.Debugw("made auth decision", "decision", result)
 
-	allow, ok := decision["allow"].(bool)
+	allow, ok := result["allow"].(bool)
 	if !ok {
-		return false, fmt.Errorf("error evaluating rego result set: unexpected response type")
+		return nil, fmt.Errorf("error evaluating rego result set: unexpected response type")
 	}
 
-	if !allow {
-		reason, ok := decision["reason"].(string)
-		if ok {
-			return false, fmt.Errorf("access denied: %s", reason)
-		}
-		return false, fmt.Errorf("access denied to:%s", method)
+	d := &decision{allow: allow}
+	d.isAdmin, _ = result["isAdmin"].(bool)
+	d.reason, _ = result["reason"].(string)
+	if buf.Len() > 0 {
+		d.prints = strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		o.log.Debugw("rego print output", "method", input["method"], "prints", d.prints)
 	}
-	o.log.Debugw("made auth decision", "results", results)
 
-	return true, nil
+	return d, nil
 }
 
 func newOpaRequest(method string, req any, token string) map[string]any {
@@ -189,7 +384,7 @@ func ExtractJWT(jwtTokenfunc func(string) string) (string, error) {
 	}
 	// can be bearer or token
 	_, jwtToken, found := strings.Cut(bearer, " ")
-	if !found {
+	if !found || jwtToken == "" {
 		return "", fmt.Errorf("no bearer token found")
 	}
 	return jwtToken, nil
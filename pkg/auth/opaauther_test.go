@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExplain(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	o, err := NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+
+	jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, []string{"/api.v1.RecordService/Create"})
+
+	tests := []struct {
+		name       string
+		method     string
+		req        map[string]any
+		wantAllow  bool
+		wantReason string
+		wantRule   string
+	}{
+		{
+			name:      "allowed record create",
+			method:    "/api.v1.RecordService/Create",
+			req:       map[string]any{"name": "www.a.example.com."},
+			wantAllow: true,
+			wantRule:  "e recordservice.rego",
+		},
+		{
+			name:       "denied record create in foreign domain",
+			method:     "/api.v1.RecordService/Create",
+			req:        map[string]any{"name": "www.b.example.com."},
+			wantAllow:  false,
+			wantReason: "no rule allows /api.v1.RecordService/Create with this token and request",
+		},
+		{
+			name:       "denied domain create without permission",
+			method:     "/api.v1.DomainService/Create",
+			req:        map[string]any{"name": "a.example.com."},
+			wantAllow:  false,
+			wantReason: "no rule allows /api.v1.DomainService/Create with this token and request",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.Explain(ctx, tt.method, tt.req, jwtToken)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantAllow, got.Allow)
+			require.Equal(t, tt.wantReason, got.Reason)
+			require.NotEmpty(t, got.Rules)
+			if tt.wantRule != "" {
+				// the rules are formatted as "name file:row", the row changes with every rule added above
+				var rules []string
+				for _, r := range got.Rules {
+					rule, _, _ := strings.Cut(r, ":")
+					rules = append(rules, rule)
+				}
+				require.Contains(t, rules, tt.wantRule)
+			}
+		})
+	}
+}
+
+func TestAuthorizeConcurrentPrints(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	o, err := NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+
+	jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, []string{"/api.v1.DomainService/List"})
+	header := func(string) string { return "Bearer " + jwtToken }
+
+	errs := make(chan error)
+	for i := 0; i < 10; i++ {
+		go func() {
+			_, _, err := o.authorize(ctx, "/api.v1.DomainService/List", header, nil)
+			errs <- err
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		require.NoError(t, <-errs)
+	}
+}
+
+func newTestToken(t *testing.T, secret string, domains, permissions []string) string {
+	now := time.Now()
+	claims := &token.DNSClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        "test-jti",
+			Subject:   "metal-dns",
+			Issuer:    "Tester",
+		},
+		Domains:     domains,
+		Permissions: permissions,
+	}
+	res, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	require.NoError(t, err)
+	return res
+}
+
+func TestDecideSpan(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	o, err := NewOpaAuther(log, "secret", WithTracerProvider(tp))
+	require.NoError(t, err)
+
+	jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, []string{"/api.v1.DomainService/List"})
+	header := func(string) string { return "Bearer " + jwtToken }
+
+	ctx, parent := tp.Tracer("test").Start(ctx, "parent")
+	_, _, err = o.authorize(ctx, "/api.v1.DomainService/List", header, nil)
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "OpaAuther.decide", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Contains(t, spans[0].Attributes(), attribute.Bool("authz.allow", true))
+}
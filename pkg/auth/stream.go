@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// receiveFirst reads the first message of a stream and returns a connection which replays it.
+// The message type is looked up in the protobuf registry by the procedure name.
+// If the procedure is unknown or the client closed the stream without sending, the returned request is nil.
+func receiveFirst(conn connect.StreamingHandlerConn) (connect.StreamingHandlerConn, any, error) {
+	msgType, err := requestType(conn.Spec().Procedure)
+	if err != nil {
+		return conn, nil, nil
+	}
+	first := msgType.New().Interface()
+	err = conn.Receive(first)
+	if errors.Is(err, io.EOF) {
+		return &replayConn{StreamingHandlerConn: conn, eof: true}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return &replayConn{StreamingHandlerConn: conn, first: first}, first, nil
+}
+
+// requestType returns the input message type of procedure, e.g. /api.v1.DomainService/Get
+func requestType(procedure string) (protoreflect.MessageType, error) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(procedure, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, err
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a method", procedure)
+	}
+	return protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+}
+
+// replayConn returns the already received first message on the first call to Receive
+type replayConn struct {
+	connect.StreamingHandlerConn
+	first proto.Message
+	eof   bool
+}
+
+func (r *replayConn) Receive(msg any) error {
+	if r.eof {
+		return io.EOF
+	}
+	if r.first == nil {
+		return r.StreamingHandlerConn.Receive(msg)
+	}
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unable to receive %T, not a proto message", msg)
+	}
+	proto.Reset(m)
+	proto.Merge(m, r.first)
+	r.first = nil
+	return nil
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/majst01/metal-dns/pkg/token"
+	testv1 "github.com/majst01/metal-dns/test/v1"
+	"github.com/majst01/metal-dns/test/v1/testv1connect"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const streamingTestServicePolicy = `package api.v1.metalstack.io.authz
+
+import future.keywords.contains
+
+permissions contains "/test.v1.StreamingTestService/ServerStream"
+
+permissions contains "/test.v1.StreamingTestService/ClientStream"
+
+e = {"permission": permissions["/test.v1.StreamingTestService/ServerStream"], "public": false} {
+	input.method == "/test.v1.StreamingTestService/ServerStream"
+	input.method == token.payload.permissions[_]
+	endswith(input.request.name, token.payload.domains[_])
+}
+
+e = {"permission": permissions["/test.v1.StreamingTestService/ClientStream"], "public": false} {
+	input.method == "/test.v1.StreamingTestService/ClientStream"
+	input.method == token.payload.permissions[_]
+	endswith(input.request.name, token.payload.domains[_])
+}
+`
+
+type streamingTestService struct{}
+
+func (s *streamingTestService) ServerStream(ctx context.Context, rq *connect.Request[testv1.StreamingTestServiceServerStreamRequest], stream *connect.ServerStream[testv1.StreamingTestServiceServerStreamResponse]) error {
+	claims := token.ClaimsFromContext(ctx)
+	for i := uint32(0); i < rq.Msg.Count; i++ {
+		err := stream.Send(&testv1.StreamingTestServiceServerStreamResponse{Name: rq.Msg.Name, Subject: claims.Subject})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *streamingTestService) ClientStream(ctx context.Context, stream *connect.ClientStream[testv1.StreamingTestServiceClientStreamRequest]) (*connect.Response[testv1.StreamingTestServiceClientStreamResponse], error) {
+	claims := token.ClaimsFromContext(ctx)
+	var names []string
+	for stream.Receive() {
+		names = append(names, stream.Msg().Name)
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&testv1.StreamingTestServiceClientStreamResponse{Names: names, Subject: claims.Subject}), nil
+}
+
+func TestWrapStreamingHandler(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "streamingtestservice.rego"), []byte(streamingTestServicePolicy), 0600))
+
+	o, err := NewOpaAuther(log, "secret", WithPolicyPath(dir))
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(testv1connect.NewStreamingTestServiceHandler(&streamingTestService{}, connect.WithInterceptors(o)))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	c := testv1connect.NewStreamingTestServiceClient(server.Client(), server.URL)
+
+	jwtToken := newTestToken(t, "secret", []string{"a.example.com."}, []string{
+		"/test.v1.StreamingTestService/ServerStream",
+		"/test.v1.StreamingTestService/ClientStream",
+	})
+
+	serverStream := func(jwtToken, name string) ([]*testv1.StreamingTestServiceServerStreamResponse, error) {
+		req := connect.NewRequest(&testv1.StreamingTestServiceServerStreamRequest{Name: name, Count: 3})
+		if jwtToken != "" {
+			req.Header().Set("Authorization", "Bearer "+jwtToken)
+		}
+		stream, err := c.ServerStream(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close()
+		var resps []*testv1.StreamingTestServiceServerStreamResponse
+		for stream.Receive() {
+			resps = append(resps, stream.Msg())
+		}
+		return resps, stream.Err()
+	}
+
+	clientStream := func(jwtToken string, names ...string) (*testv1.StreamingTestServiceClientStreamResponse, error) {
+		stream := c.ClientStream(ctx)
+		if jwtToken != "" {
+			stream.RequestHeader().Set("Authorization", "Bearer "+jwtToken)
+		}
+		for _, name := range names {
+			if err := stream.Send(&testv1.StreamingTestServiceClientStreamRequest{Name: name}); err != nil {
+				break
+			}
+		}
+		resp, err := stream.CloseAndReceive()
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	t.Run("server stream allowed", func(t *testing.T) {
+		resps, err := serverStream(jwtToken, "www.a.example.com.")
+		require.NoError(t, err)
+		require.Len(t, resps, 3)
+		require.Equal(t, "www.a.example.com.", resps[0].Name)
+		require.Equal(t, "metal-dns", resps[0].Subject)
+	})
+	t.Run("server stream denied by request", func(t *testing.T) {
+		_, err := serverStream(jwtToken, "www.b.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("server stream without token", func(t *testing.T) {
+		_, err := serverStream("", "www.a.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("server stream with token of wrong secret", func(t *testing.T) {
+		_, err := serverStream(newTestToken(t, "wrong-secret", []string{"a.example.com."}, []string{"/test.v1.StreamingTestService/ServerStream"}), "www.a.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("client stream allowed, first message is replayed", func(t *testing.T) {
+		resp, err := clientStream(jwtToken, "www.a.example.com.", "mail.a.example.com.")
+		require.NoError(t, err)
+		require.Equal(t, []string{"www.a.example.com.", "mail.a.example.com."}, resp.Names)
+		require.Equal(t, "metal-dns", resp.Subject)
+	})
+	t.Run("client stream denied by first message", func(t *testing.T) {
+		_, err := clientStream(jwtToken, "www.b.example.com.", "www.a.example.com.")
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+	t.Run("client stream without messages", func(t *testing.T) {
+		_, err := clientStream(jwtToken)
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	})
+}
@@ -0,0 +1,81 @@
+// Package backend abstracts the authoritative servers the domains and records are stored in.
+// The model of the powerdns api is used by all backends, other backends translate it.
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/joeig/go-powerdns/v3"
+)
+
+// ErrUnsupported is returned by backends which cannot perform an operation, e.g. create zones
+var ErrUnsupported = errors.New("not supported by the backend")
+
+// Zones manages the zones of a backend, it is implemented by the zones service of the powerdns client
+type Zones interface {
+	// List returns all zones without their records
+	List(ctx context.Context) ([]powerdns.Zone, error)
+	// Get returns a zone with its records
+	Get(ctx context.Context, domain string) (*powerdns.Zone, error)
+	Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error)
+	Change(ctx context.Context, domain string, zone *powerdns.Zone) error
+	Delete(ctx context.Context, domain string) error
+}
+
+// Records manages the records of the zones of a backend, it is implemented by the records service of the powerdns client
+type Records interface {
+	// Add replaces the records of name and type with content
+	Add(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error
+	// Change replaces the records of name and type with content
+	Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error
+	// Delete removes all records of name and type
+	Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error
+	// Patch replaces or deletes the given sets of records at once
+	Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error
+}
+
+// Backend stores the domains and records
+type Backend struct {
+	// Name of the backend, e.g. powerdns, used in health checks and logs
+	Name    string
+	Zones   Zones
+	Records Records
+	// Probe checks that the backend is reachable
+	Probe func(ctx context.Context) error
+}
+
+// NewPowerDNS returns a backend which stores the domains and records in the vhost of a powerdns server
+func NewPowerDNS(baseURL string, vHost string, apikey string, httpClient *http.Client) *Backend {
+	pdns := powerdns.NewClient(baseURL, vHost, map[string]string{"X-API-Key": apikey}, httpClient)
+	return &Backend{
+		Name:    "powerdns",
+		Zones:   pdns.Zones,
+		Records: pdns.Records,
+		// fetches the server information of the vhost, which requires a valid api key
+		Probe: func(ctx context.Context) error {
+			_, err := pdns.Servers.Get(ctx, vHost)
+			if err != nil {
+				return fmt.Errorf("unable to get powerdns server %s %w", vHost, err)
+			}
+			return nil
+		},
+	}
+}
+
+// NotFound returns the error powerdns returns for unknown zones, callers check it with IsNotFound
+func NotFound(format string, args ...any) error {
+	return &powerdns.Error{
+		StatusCode: http.StatusNotFound,
+		Status:     http.StatusText(http.StatusNotFound),
+		Message:    fmt.Sprintf(format, args...),
+	}
+}
+
+// IsNotFound returns true if err is returned for an unknown zone
+func IsNotFound(err error) bool {
+	var pdnsErr *powerdns.Error
+	return errors.As(err, &pdnsErr) && pdnsErr.StatusCode == http.StatusNotFound
+}
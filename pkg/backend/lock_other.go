@@ -0,0 +1,16 @@
+//go:build !unix
+
+package backend
+
+import (
+	"os"
+)
+
+// lockFD does nothing, changes are only serialized within the process
+func lockFD(f *os.File) error {
+	return nil
+}
+
+func unlockFD(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,321 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultRFC2136Timeout is the default timeout of updates and zone transfers
+	DefaultRFC2136Timeout = 10 * time.Second
+	// fudge is the allowed clock skew of signed messages
+	fudge = 300
+)
+
+var tsigAlgorithms = map[string]string{
+	"hmac-sha1":   dns.HmacSHA1,
+	"hmac-sha224": dns.HmacSHA224,
+	"hmac-sha256": dns.HmacSHA256,
+	"hmac-sha384": dns.HmacSHA384,
+	"hmac-sha512": dns.HmacSHA512,
+}
+
+// TSIGAlgorithm returns the canonical name of a TSIG algorithm with a trailing dot, e.g. hmac-sha256. for hmac-sha256.
+// An empty name is hmac-sha256.
+func TSIGAlgorithm(name string) (string, error) {
+	if name == "" {
+		return dns.HmacSHA256, nil
+	}
+	algorithm, ok := tsigAlgorithms[strings.TrimSuffix(strings.ToLower(name), ".")]
+	if !ok {
+		return "", fmt.Errorf("unsupported tsig algorithm %q", name)
+	}
+	return algorithm, nil
+}
+
+// RFC2136Config configures a backend for authoritative servers like BIND or Knot
+type RFC2136Config struct {
+	// Nameserver receives the updates and zone transfers, in the form host:port
+	Nameserver string `yaml:"nameserver"`
+	// Zones are the zones of the nameserver which are managed, they cannot be listed with dns
+	Zones []string `yaml:"zones"`
+	// TSIGKeyName signs updates and zone transfers with TSIGSecret, messages are not signed if empty
+	TSIGKeyName   string `yaml:"tsig-key-name"`
+	TSIGAlgorithm string `yaml:"tsig-algorithm"`
+	// TSIGSecret is base64 encoded
+	TSIGSecret string        `yaml:"tsig-secret"`
+	Timeout    time.Duration `yaml:"timeout"`
+}
+
+// NewRFC2136 returns a backend which reads zones with AXFR and changes records with dynamic updates as specified in RFC 2136.
+// Zones cannot be created or deleted, the rrsets are replaced as a whole like powerdns does.
+func NewRFC2136(config RFC2136Config) (*Backend, error) {
+	algorithm, err := TSIGAlgorithm(config.TSIGAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultRFC2136Timeout
+	}
+	r := &rfc2136{
+		c:         config,
+		algorithm: algorithm,
+		zones:     map[string]string{},
+	}
+	if config.TSIGKeyName != "" {
+		r.keyName = dns.CanonicalName(config.TSIGKeyName)
+		r.secrets = map[string]string{r.keyName: config.TSIGSecret}
+	}
+	for _, z := range config.Zones {
+		r.zones[dns.CanonicalName(z)] = dns.Fqdn(z)
+	}
+	return &Backend{
+		Name:    "rfc2136",
+		Zones:   &rfc2136Zones{r},
+		Records: &rfc2136Records{r},
+		Probe:   r.probe,
+	}, nil
+}
+
+type rfc2136 struct {
+	c         RFC2136Config
+	algorithm string
+	keyName   string
+	secrets   map[string]string
+	// zones maps the canonical names of the managed zones to their configured names
+	zones map[string]string
+}
+
+// zone returns the configured name of a managed zone or a not found error
+func (r *rfc2136) zone(domain string) (string, error) {
+	zone, ok := r.zones[dns.CanonicalName(domain)]
+	if !ok {
+		return "", NotFound("zone %s is not managed", domain)
+	}
+	return zone, nil
+}
+
+func (r *rfc2136) sign(m *dns.Msg) {
+	if r.keyName != "" {
+		m.SetTsig(r.keyName, r.algorithm, fudge, time.Now().Unix())
+	}
+}
+
+// transfer returns all records of zone, the soa which ends the transfer is omitted
+func (r *rfc2136) transfer(ctx context.Context, zone string) ([]dns.RR, error) {
+	m := new(dns.Msg)
+	m.SetAxfr(zone)
+	r.sign(m)
+	timeout := r.c.Timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	t := &dns.Transfer{DialTimeout: timeout, ReadTimeout: timeout, WriteTimeout: timeout, TsigSecret: r.secrets}
+	envelopes, err := t.In(m, r.c.Nameserver)
+	if err != nil {
+		return nil, fmt.Errorf("unable to transfer zone %s %w", zone, err)
+	}
+	var (
+		rrs  []dns.RR
+		errs []error
+	)
+	for e := range envelopes {
+		if e.Error != nil {
+			errs = append(errs, e.Error)
+			continue
+		}
+		rrs = append(rrs, e.RR...)
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("unable to transfer zone %s %w", zone, errs[0])
+	}
+	if len(rrs) > 1 {
+		if _, ok := rrs[len(rrs)-1].(*dns.SOA); ok {
+			rrs = rrs[:len(rrs)-1]
+		}
+	}
+	return rrs, nil
+}
+
+// update sends the changes of m and fails if the nameserver does not apply them
+func (r *rfc2136) update(ctx context.Context, m *dns.Msg) error {
+	r.sign(m)
+	c := &dns.Client{Net: "tcp", Timeout: r.c.Timeout, TsigSecret: r.secrets}
+	resp, _, err := c.ExchangeContext(ctx, m, r.c.Nameserver)
+	if err != nil {
+		return fmt.Errorf("unable to update zone %s %w", m.Question[0].Name, err)
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return fmt.Errorf("update of zone %s failed with %s", m.Question[0].Name, dns.RcodeToString[resp.Rcode])
+	}
+	return nil
+}
+
+// probe checks that the nameserver is authoritative for all zones
+func (r *rfc2136) probe(ctx context.Context) error {
+	c := &dns.Client{Net: "tcp", Timeout: r.c.Timeout}
+	for _, zone := range r.zones {
+		m := new(dns.Msg)
+		m.SetQuestion(zone, dns.TypeSOA)
+		resp, _, err := c.ExchangeContext(ctx, m, r.c.Nameserver)
+		if err != nil {
+			return fmt.Errorf("unable to query soa of %s %w", zone, err)
+		}
+		if resp.Rcode != dns.RcodeSuccess || !resp.Authoritative {
+			return fmt.Errorf("%s is not authoritative for %s, rcode %s", r.c.Nameserver, zone, dns.RcodeToString[resp.Rcode])
+		}
+	}
+	return nil
+}
+
+// patch replaces and deletes the rrsets in a single update, which is applied atomically
+func (r *rfc2136) patch(ctx context.Context, domain string, sets []powerdns.RRset) error {
+	zone, err := r.zone(domain)
+	if err != nil {
+		return err
+	}
+	m := new(dns.Msg)
+	m.SetUpdate(zone)
+	for _, set := range sets {
+		name := powerdns.StringValue(set.Name)
+		if set.Type == nil {
+			return fmt.Errorf("rrset %s without type", name)
+		}
+		rrtype, ok := dns.StringToType[string(*set.Type)]
+		if !ok {
+			return fmt.Errorf("unknown record type %s", *set.Type)
+		}
+		// the soa cannot be deleted, a new one replaces the existing one. Deletions of the ns rrset of the apex
+		// are ignored by the nameserver (RFC 2136 3.4.2.4), its records are deleted one by one after the new ones are added.
+		apexNS := rrtype == dns.TypeNS && dns.CanonicalName(name) == dns.CanonicalName(zone)
+		if rrtype != dns.TypeSOA && !apexNS {
+			m.RemoveRRset([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: name, Rrtype: rrtype}}})
+		}
+		var rrs []dns.RR
+		if set.ChangeType == nil || *set.ChangeType != powerdns.ChangeTypeDelete {
+			for _, record := range set.Records {
+				if powerdns.BoolValue(record.Disabled) {
+					continue
+				}
+				rr, err := newRR(name, string(*set.Type), powerdns.Uint32Value(set.TTL), powerdns.StringValue(record.Content))
+				if err != nil {
+					return err
+				}
+				rrs = append(rrs, rr)
+			}
+		}
+		m.Insert(rrs)
+		if apexNS {
+			existing, err := r.nameservers(ctx, zone)
+			if err != nil {
+				return err
+			}
+			m.Remove(staleNameservers(existing, rrs))
+		}
+	}
+	return r.update(ctx, m)
+}
+
+// nameservers returns the ns records of the apex of zone
+func (r *rfc2136) nameservers(ctx context.Context, zone string) ([]dns.RR, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(zone, dns.TypeNS)
+	c := &dns.Client{Net: "tcp", Timeout: r.c.Timeout}
+	resp, _, err := c.ExchangeContext(ctx, m, r.c.Nameserver)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query nameservers of %s %w", zone, err)
+	}
+	if resp.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("query of the nameservers of %s failed with %s", zone, dns.RcodeToString[resp.Rcode])
+	}
+	var result []dns.RR
+	for _, rr := range resp.Answer {
+		if ns, ok := rr.(*dns.NS); ok && dns.CanonicalName(ns.Hdr.Name) == dns.CanonicalName(zone) {
+			result = append(result, ns)
+		}
+	}
+	return result, nil
+}
+
+// staleNameservers returns the ns records of existing which are not wanted
+func staleNameservers(existing, wanted []dns.RR) []dns.RR {
+	keep := map[string]bool{}
+	for _, rr := range wanted {
+		if ns, ok := rr.(*dns.NS); ok {
+			keep[dns.CanonicalName(ns.Ns)] = true
+		}
+	}
+	var result []dns.RR
+	for _, rr := range existing {
+		if ns, ok := rr.(*dns.NS); ok && !keep[dns.CanonicalName(ns.Ns)] {
+			result = append(result, ns)
+		}
+	}
+	return result
+}
+
+type rfc2136Zones struct {
+	*rfc2136
+}
+
+// List returns the managed zones without transferring them
+func (z *rfc2136Zones) List(ctx context.Context) ([]powerdns.Zone, error) {
+	result := []powerdns.Zone{}
+	for _, zone := range z.c.Zones {
+		result = append(result, *toZone(dns.Fqdn(zone), nil))
+	}
+	return result, nil
+}
+
+func (z *rfc2136Zones) Get(ctx context.Context, domain string) (*powerdns.Zone, error) {
+	zone, err := z.zone(domain)
+	if err != nil {
+		return nil, err
+	}
+	rrs, err := z.transfer(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	return toZone(zone, rrs), nil
+}
+
+func (z *rfc2136Zones) Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error) {
+	return nil, fmt.Errorf("zones must be added to the configuration of the nameserver %w", ErrUnsupported)
+}
+
+// Change replaces the nameservers of the zone, the other attributes of powerdns zones do not exist
+func (z *rfc2136Zones) Change(ctx context.Context, domain string, zone *powerdns.Zone) error {
+	if len(zone.Nameservers) == 0 {
+		return nil
+	}
+	return z.patch(ctx, domain, []powerdns.RRset{nameserversRRset(domain, zone)})
+}
+
+func (z *rfc2136Zones) Delete(ctx context.Context, domain string) error {
+	return fmt.Errorf("zones must be deleted from the configuration of the nameserver %w", ErrUnsupported)
+}
+
+type rfc2136Records struct {
+	*rfc2136
+}
+
+func (r *rfc2136Records) Add(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	return r.Change(ctx, domain, name, recordType, ttl, content, options...)
+}
+
+func (r *rfc2136Records) Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	return r.patch(ctx, domain, []powerdns.RRset{replaceRRset(name, recordType, ttl, content)})
+}
+
+func (r *rfc2136Records) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
+	return r.patch(ctx, domain, []powerdns.RRset{deleteRRset(name, recordType)})
+}
+
+func (r *rfc2136Records) Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error {
+	return r.patch(ctx, domain, rrSets.Sets)
+}
@@ -0,0 +1,281 @@
+package backend
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "c2VjcmV0LW9mLXRoZS1iYWNrZW5kLWtleQ=="
+
+func TestRFC2136(t *testing.T) {
+	ctx := context.Background()
+	ns := startNameserver(t, map[string]string{"metal-dns.": testSecret}, "a.example.com.",
+		"a.example.com. 3600 IN SOA ns1.a.example.com. hostmaster.a.example.com. 7 3600 600 86400 300",
+		"a.example.com. 3600 IN NS ns1.a.example.com.",
+		"www.a.example.com. 300 IN A 1.1.1.1",
+	)
+
+	b, err := NewRFC2136(RFC2136Config{
+		Nameserver:  ns.addr,
+		Zones:       []string{"a.example.com"},
+		TSIGKeyName: "metal-dns",
+		TSIGSecret:  testSecret,
+	})
+	require.NoError(t, err)
+
+	get := func(t *testing.T) *powerdns.Zone {
+		zone, err := b.Zones.Get(ctx, "a.example.com.")
+		require.NoError(t, err)
+		return zone
+	}
+
+	t.Run("probe", func(t *testing.T) {
+		require.NoError(t, b.Probe(ctx))
+	})
+	t.Run("list", func(t *testing.T) {
+		zones, err := b.Zones.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, zones, 1)
+		require.Equal(t, "a.example.com.", *zones[0].Name)
+	})
+	t.Run("get", func(t *testing.T) {
+		zone := get(t)
+		require.Equal(t, "a.example.com.", *zone.ID)
+		require.Equal(t, uint32(7), *zone.Serial)
+		require.Equal(t, []string{"ns1.a.example.com."}, zone.Nameservers)
+		require.Len(t, zone.RRsets, 3)
+		require.Equal(t, []string{"1.1.1.1"}, contentOf(zone, "www.a.example.com.", powerdns.RRTypeA))
+	})
+	t.Run("unknown zone", func(t *testing.T) {
+		_, err := b.Zones.Get(ctx, "b.example.com.")
+		require.True(t, IsNotFound(err))
+		require.True(t, IsNotFound(b.Records.Delete(ctx, "b.example.com.", "www.b.example.com.", powerdns.RRTypeA)))
+	})
+	t.Run("zones cannot be added or deleted", func(t *testing.T) {
+		_, err := b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("b.example.com.")})
+		require.ErrorIs(t, err, ErrUnsupported)
+		require.ErrorIs(t, b.Zones.Delete(ctx, "a.example.com."), ErrUnsupported)
+	})
+	t.Run("add replaces the rrset", func(t *testing.T) {
+		require.NoError(t, b.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 60, []string{"1.2.3.4", "1.2.3.5"}))
+		require.ElementsMatch(t, []string{"1.2.3.4", "1.2.3.5"}, contentOf(get(t), "www.a.example.com.", powerdns.RRTypeA))
+
+		require.NoError(t, b.Records.Change(ctx, "a.example.com.", "_acme-challenge.a.example.com.", powerdns.RRTypeTXT, 60, []string{`"token"`}))
+		require.Equal(t, []string{`"token"`}, contentOf(get(t), "_acme-challenge.a.example.com.", powerdns.RRTypeTXT))
+	})
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, b.Records.Delete(ctx, "a.example.com.", "_acme-challenge.a.example.com.", powerdns.RRTypeTXT))
+		require.Empty(t, contentOf(get(t), "_acme-challenge.a.example.com.", powerdns.RRTypeTXT))
+	})
+	t.Run("patch", func(t *testing.T) {
+		err := b.Records.Patch(ctx, "a.example.com.", &powerdns.RRsets{Sets: []powerdns.RRset{
+			{
+				Name:       powerdns.String("www.a.example.com."),
+				Type:       powerdns.RRTypePtr(powerdns.RRTypeA),
+				ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeDelete),
+			},
+			{
+				Name:       powerdns.String("mail.a.example.com."),
+				Type:       powerdns.RRTypePtr(powerdns.RRTypeMX),
+				TTL:        powerdns.Uint32(300),
+				ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace),
+				Records: []powerdns.Record{
+					{Content: powerdns.String("10 mx1.a.example.com.")},
+					{Content: powerdns.String("20 mx2.a.example.com."), Disabled: powerdns.Bool(true)},
+				},
+			},
+		}})
+		require.NoError(t, err)
+		zone := get(t)
+		require.Empty(t, contentOf(zone, "www.a.example.com.", powerdns.RRTypeA))
+		require.Equal(t, []string{"10 mx1.a.example.com."}, contentOf(zone, "mail.a.example.com.", powerdns.RRTypeMX))
+	})
+	t.Run("malformed content is not sent", func(t *testing.T) {
+		updates := ns.updateCount()
+		require.Error(t, b.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 60, []string{"not an ip"}))
+		require.Equal(t, updates, ns.updateCount())
+	})
+	t.Run("change nameservers", func(t *testing.T) {
+		zone := get(t)
+		zone.Nameservers = []string{"ns2.a.example.com.", "ns3.a.example.com"}
+		require.NoError(t, b.Zones.Change(ctx, "a.example.com.", zone))
+		require.ElementsMatch(t, []string{"ns2.a.example.com.", "ns3.a.example.com."}, get(t).Nameservers)
+	})
+	t.Run("replace a nameserver", func(t *testing.T) {
+		zone := get(t)
+		zone.Nameservers = []string{"ns3.a.example.com.", "ns4.a.example.com."}
+		require.NoError(t, b.Zones.Change(ctx, "a.example.com.", zone))
+		require.ElementsMatch(t, []string{"ns3.a.example.com.", "ns4.a.example.com."}, get(t).Nameservers)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		other, err := NewRFC2136(RFC2136Config{
+			Nameserver:  ns.addr,
+			Zones:       []string{"a.example.com."},
+			TSIGKeyName: "metal-dns.",
+			TSIGSecret:  "b3RoZXItc2VjcmV0",
+		})
+		require.NoError(t, err)
+		_, err = other.Zones.Get(ctx, "a.example.com.")
+		require.Error(t, err)
+		require.Error(t, other.Records.Delete(ctx, "a.example.com.", "mail.a.example.com.", powerdns.RRTypeMX))
+		require.NotEmpty(t, contentOf(get(t), "mail.a.example.com.", powerdns.RRTypeMX))
+	})
+}
+
+func contentOf(zone *powerdns.Zone, name string, rrtype powerdns.RRType) []string {
+	var result []string
+	for _, set := range zone.RRsets {
+		if *set.Name != name || *set.Type != rrtype {
+			continue
+		}
+		for _, r := range set.Records {
+			result = append(result, *r.Content)
+		}
+	}
+	return result
+}
+
+// nameserver serves a single zone over tcp, it answers zone transfers and applies dynamic updates which are signed
+type nameserver struct {
+	lock    sync.Mutex
+	addr    string
+	zone    string
+	records []dns.RR
+	updates int
+}
+
+func startNameserver(t *testing.T, secrets map[string]string, zone string, records ...string) *nameserver {
+	ns := &nameserver{zone: zone}
+	for _, r := range records {
+		rr, err := dns.NewRR(r)
+		require.NoError(t, err)
+		ns.records = append(ns.records, rr)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ns.addr = l.Addr().String()
+
+	server := &dns.Server{
+		Listener:   l,
+		Handler:    ns,
+		TsigSecret: secrets,
+		MsgAcceptFunc: func(dh dns.Header) dns.MsgAcceptAction {
+			return dns.MsgAccept
+		},
+	}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+	return ns
+}
+
+func (ns *nameserver) updateCount() int {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+	return ns.updates
+}
+
+func (ns *nameserver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.Authoritative = true
+	signed := r.IsTsig() != nil && w.TsigStatus() == nil
+	q := r.Question[0]
+	switch {
+	case !dns.IsSubDomain(ns.zone, q.Name):
+		m.Rcode = dns.RcodeRefused
+	case !signed && (r.Opcode == dns.OpcodeUpdate || q.Qtype == dns.TypeAXFR):
+		m.Rcode = dns.RcodeNotAuth
+	case r.Opcode == dns.OpcodeUpdate:
+		ns.updates++
+		ns.apply(r.Ns)
+	case q.Qtype == dns.TypeAXFR:
+		m.Answer = append(append([]dns.RR{}, ns.records...), ns.records[0])
+	case q.Qtype == dns.TypeSOA:
+		m.Answer = []dns.RR{ns.records[0]}
+	case q.Qtype == dns.TypeNS:
+		for _, rr := range ns.records {
+			if rr.Header().Rrtype == dns.TypeNS && dns.CanonicalName(rr.Header().Name) == dns.CanonicalName(q.Name) {
+				m.Answer = append(m.Answer, rr)
+			}
+		}
+	default:
+		m.Rcode = dns.RcodeNotAuth
+	}
+	if signed {
+		t := r.IsTsig()
+		m.SetTsig(t.Hdr.Name, t.Algorithm, 300, time.Now().Unix())
+	}
+	_ = w.WriteMsg(m)
+}
+
+// apply changes the records like RFC 2136 3.4.2 describes, the soa is always kept and so is the last ns of the apex
+func (ns *nameserver) apply(updates []dns.RR) {
+	for _, u := range updates {
+		h := u.Header()
+		apex := dns.CanonicalName(h.Name) == dns.CanonicalName(ns.zone)
+		switch h.Class {
+		case dns.ClassANY:
+			if h.Rrtype == dns.TypeSOA || (apex && h.Rrtype == dns.TypeNS) {
+				continue
+			}
+			ns.remove(func(rr dns.RR) bool {
+				return dns.CanonicalName(rr.Header().Name) == dns.CanonicalName(h.Name) && rr.Header().Rrtype == h.Rrtype
+			})
+		case dns.ClassNONE:
+			if h.Rrtype == dns.TypeSOA || (apex && h.Rrtype == dns.TypeNS && ns.count(h.Name, dns.TypeNS) == 1) {
+				continue
+			}
+			value := dns.Copy(u)
+			value.Header().Class = dns.ClassINET
+			ns.remove(func(rr dns.RR) bool {
+				return dns.IsDuplicate(rr, value)
+			})
+		case dns.ClassINET:
+			if h.Rrtype == dns.TypeSOA {
+				ns.records[0] = u
+				continue
+			}
+			duplicate := false
+			for _, rr := range ns.records {
+				duplicate = duplicate || dns.IsDuplicate(rr, u)
+			}
+			if !duplicate {
+				ns.records = append(ns.records, u)
+			}
+		}
+	}
+}
+
+func (ns *nameserver) remove(match func(dns.RR) bool) {
+	var kept []dns.RR
+	for _, rr := range ns.records {
+		if !match(rr) {
+			kept = append(kept, rr)
+		}
+	}
+	ns.records = kept
+}
+
+func (ns *nameserver) count(name string, rrtype uint16) int {
+	count := 0
+	for _, rr := range ns.records {
+		if dns.CanonicalName(rr.Header().Name) == dns.CanonicalName(name) && rr.Header().Rrtype == rrtype {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,239 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/miekg/dns"
+	"gopkg.in/yaml.v3"
+)
+
+// Route passes the calls for the zones below one of the suffixes to a backend
+type Route struct {
+	// Suffixes of the zones, e.g. example.com. for example.com. and a.example.com., "." matches all zones
+	Suffixes []string
+	Backend  *Backend
+}
+
+// NewRouter returns a backend which passes the calls for a zone to the backend of the route with the longest matching suffix.
+// List merges the zones of all backends which are routed to the backend they are listed by.
+func NewRouter(name string, routes []Route) (*Backend, error) {
+	r := &router{}
+	suffixes := map[string]string{}
+	for _, route := range routes {
+		if len(route.Suffixes) == 0 {
+			return nil, fmt.Errorf("route to backend %s without zones", route.Backend.Name)
+		}
+		r.backends = append(r.backends, route.Backend)
+		for _, s := range route.Suffixes {
+			suffix := dns.CanonicalName(s)
+			if other, ok := suffixes[suffix]; ok {
+				return nil, fmt.Errorf("zones %s are routed to backends %s and %s", suffix, other, route.Backend.Name)
+			}
+			suffixes[suffix] = route.Backend.Name
+			r.routes = append(r.routes, suffixRoute{suffix: suffix, backend: route.Backend})
+		}
+	}
+	sort.SliceStable(r.routes, func(i, j int) bool {
+		return dns.CountLabel(r.routes[i].suffix) > dns.CountLabel(r.routes[j].suffix)
+	})
+	return &Backend{
+		Name:    name,
+		Zones:   &routerZones{r},
+		Records: &routerRecords{r},
+		Probe:   r.probe,
+	}, nil
+}
+
+type suffixRoute struct {
+	suffix  string
+	backend *Backend
+}
+
+type router struct {
+	// routes are sorted by the number of labels of their suffix, the longest first
+	routes   []suffixRoute
+	backends []*Backend
+}
+
+// route returns the backend of a zone, a zone without route is not found
+func (r *router) route(domain string) (*Backend, error) {
+	zone := dns.CanonicalName(domain)
+	for _, route := range r.routes {
+		if dns.IsSubDomain(route.suffix, zone) {
+			return route.backend, nil
+		}
+	}
+	return nil, NotFound("no backend for zone %s", domain)
+}
+
+// probe checks all backends
+func (r *router) probe(ctx context.Context) error {
+	var errs []error
+	for _, b := range r.backends {
+		if err := b.Probe(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("backend %s %w", b.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type routerZones struct {
+	*router
+}
+
+// List fails if one of the backends fails, zones which are routed to another backend are omitted
+func (z *routerZones) List(ctx context.Context) ([]powerdns.Zone, error) {
+	result := []powerdns.Zone{}
+	for _, b := range z.backends {
+		zones, err := b.Zones.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list zones of backend %s %w", b.Name, err)
+		}
+		for _, zone := range zones {
+			if routed, err := z.route(powerdns.StringValue(zone.Name)); err == nil && routed == b {
+				result = append(result, zone)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (z *routerZones) Get(ctx context.Context, domain string) (*powerdns.Zone, error) {
+	b, err := z.route(domain)
+	if err != nil {
+		return nil, err
+	}
+	return b.Zones.Get(ctx, domain)
+}
+
+func (z *routerZones) Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error) {
+	b, err := z.route(powerdns.StringValue(zone.Name))
+	if err != nil {
+		return nil, err
+	}
+	return b.Zones.Add(ctx, zone)
+}
+
+func (z *routerZones) Change(ctx context.Context, domain string, zone *powerdns.Zone) error {
+	b, err := z.route(domain)
+	if err != nil {
+		return err
+	}
+	return b.Zones.Change(ctx, domain, zone)
+}
+
+func (z *routerZones) Delete(ctx context.Context, domain string) error {
+	b, err := z.route(domain)
+	if err != nil {
+		return err
+	}
+	return b.Zones.Delete(ctx, domain)
+}
+
+type routerRecords struct {
+	*router
+}
+
+func (r *routerRecords) Add(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	b, err := r.route(domain)
+	if err != nil {
+		return err
+	}
+	return b.Records.Add(ctx, domain, name, recordType, ttl, content, options...)
+}
+
+func (r *routerRecords) Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	b, err := r.route(domain)
+	if err != nil {
+		return err
+	}
+	return b.Records.Change(ctx, domain, name, recordType, ttl, content, options...)
+}
+
+func (r *routerRecords) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
+	b, err := r.route(domain)
+	if err != nil {
+		return err
+	}
+	return b.Records.Delete(ctx, domain, name, recordType)
+}
+
+func (r *routerRecords) Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error {
+	b, err := r.route(domain)
+	if err != nil {
+		return err
+	}
+	return b.Records.Patch(ctx, domain, rrSets)
+}
+
+// PowerDNSServer is a powerdns server and the zones it stores
+type PowerDNSServer struct {
+	// Name of the server, used in health checks and logs
+	Name string `yaml:"name"`
+	// URL of the api, e.g. http://powerdns:8081
+	URL string `yaml:"url"`
+	// VHost of the server, localhost if empty
+	VHost  string `yaml:"vhost"`
+	APIKey string `yaml:"api-key"`
+	// Zones are the suffixes of the zones which are stored in this server, "." for all zones without another server
+	Zones []string `yaml:"zones"`
+}
+
+// LoadPowerDNSServers reads a list of PowerDNSServer from a yaml file at path
+func LoadPowerDNSServers(path string) ([]PowerDNSServer, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read powerdns servers %w", err)
+	}
+	var servers []PowerDNSServer
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	err = dec.Decode(&servers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse powerdns servers in %s %w", path, err)
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no powerdns servers in %s", path)
+	}
+	names := map[string]bool{}
+	for i := range servers {
+		s := &servers[i]
+		if s.Name == "" {
+			return nil, fmt.Errorf("powerdns server in %s without name", path)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("powerdns server %s is defined twice in %s", s.Name, path)
+		}
+		names[s.Name] = true
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("powerdns server %s must have an http or https url, got %q", s.Name, s.URL)
+		}
+		if s.VHost == "" {
+			s.VHost = "localhost"
+		}
+		if len(s.Zones) == 0 {
+			return nil, fmt.Errorf("powerdns server %s without zones", s.Name)
+		}
+	}
+	return servers, nil
+}
+
+// NewPowerDNSRouter returns a backend which routes the zones to the powerdns servers
+func NewPowerDNSRouter(servers []PowerDNSServer, httpClient *http.Client) (*Backend, error) {
+	var routes []Route
+	for _, s := range servers {
+		b := NewPowerDNS(s.URL, s.VHost, s.APIKey, httpClient)
+		b.Name = s.Name
+		routes = append(routes, Route{Suffixes: s.Zones, Backend: b})
+	}
+	return NewRouter("powerdns", routes)
+}
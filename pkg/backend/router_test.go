@@ -0,0 +1,222 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	prod := newFake("prod", "example.com.", "b.example.com.", "staging.example.com.")
+	staging := newFake("staging", "staging.example.com.", "a.staging.example.com.")
+	legacy := newFake("legacy", "example.org.")
+
+	b, err := NewRouter("powerdns", []Route{
+		{Suffixes: []string{"example.com."}, Backend: prod.backend()},
+		{Suffixes: []string{"staging.example.com"}, Backend: staging.backend()},
+		{Suffixes: []string{"."}, Backend: legacy.backend()},
+	})
+	require.NoError(t, err)
+
+	t.Run("longest suffix wins", func(t *testing.T) {
+		require.NoError(t, b.Records.Add(ctx, "b.example.com.", "www.b.example.com.", powerdns.RRTypeA, 60, []string{"1.2.3.4"}))
+		require.NoError(t, b.Records.Delete(ctx, "a.staging.example.com.", "www.a.staging.example.com.", powerdns.RRTypeA))
+		require.NoError(t, b.Records.Patch(ctx, "STAGING.example.com.", &powerdns.RRsets{}))
+		require.NoError(t, b.Records.Change(ctx, "example.org.", "www.example.org.", powerdns.RRTypeA, 60, []string{"1.2.3.4"}))
+		require.Equal(t, []string{"add b.example.com."}, prod.calls)
+		require.Equal(t, []string{"delete a.staging.example.com.", "patch STAGING.example.com."}, staging.calls)
+		require.Equal(t, []string{"change example.org."}, legacy.calls)
+	})
+	t.Run("zones", func(t *testing.T) {
+		zone, err := b.Zones.Get(ctx, "a.staging.example.com.")
+		require.NoError(t, err)
+		require.Equal(t, "a.staging.example.com.", *zone.Name)
+
+		_, err = b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("c.example.com.")})
+		require.NoError(t, err)
+		require.Contains(t, prod.zones, "c.example.com.")
+
+		require.NoError(t, b.Zones.Delete(ctx, "example.org."))
+		require.Empty(t, legacy.zones)
+	})
+	t.Run("list merges the routed zones", func(t *testing.T) {
+		zones, err := b.Zones.List(ctx)
+		require.NoError(t, err)
+		var names []string
+		for _, z := range zones {
+			names = append(names, *z.Name)
+		}
+		// staging.example.com. of prod is routed to staging
+		require.Equal(t, []string{"example.com.", "b.example.com.", "c.example.com.", "staging.example.com.", "a.staging.example.com."}, names)
+
+		staging.err = errors.New("connection refused")
+		_, err = b.Zones.List(ctx)
+		require.ErrorContains(t, err, "unable to list zones of backend staging connection refused")
+	})
+	t.Run("probe", func(t *testing.T) {
+		legacy.err = errors.New("unauthorized")
+		err := b.Probe(ctx)
+		require.ErrorContains(t, err, "backend staging connection refused")
+		require.ErrorContains(t, err, "backend legacy unauthorized")
+		staging.err, legacy.err = nil, nil
+		require.NoError(t, b.Probe(ctx))
+	})
+	t.Run("without route", func(t *testing.T) {
+		b, err := NewRouter("powerdns", []Route{{Suffixes: []string{"example.com."}, Backend: prod.backend()}})
+		require.NoError(t, err)
+		_, err = b.Zones.Get(ctx, "example.org.")
+		require.True(t, IsNotFound(err))
+		require.True(t, IsNotFound(b.Records.Delete(ctx, "example.org.", "www.example.org.", powerdns.RRTypeA)))
+	})
+	t.Run("invalid routes", func(t *testing.T) {
+		_, err := NewRouter("powerdns", []Route{
+			{Suffixes: []string{"example.com."}, Backend: prod.backend()},
+			{Suffixes: []string{"Example.com"}, Backend: staging.backend()},
+		})
+		require.ErrorContains(t, err, "zones example.com. are routed to backends prod and staging")
+		_, err = NewRouter("powerdns", []Route{{Backend: prod.backend()}})
+		require.Error(t, err)
+	})
+}
+
+func TestLoadPowerDNSServers(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`
+- name: prod
+  url: http://pdns-prod:8081
+  api-key: secret
+  zones: [.]
+- name: staging
+  url: https://pdns-staging:8081
+  vhost: staging
+  zones: [staging.example.com.]
+`), 0600))
+	servers, err := LoadPowerDNSServers(valid)
+	require.NoError(t, err)
+	require.Equal(t, []PowerDNSServer{
+		{Name: "prod", URL: "http://pdns-prod:8081", VHost: "localhost", APIKey: "secret", Zones: []string{"."}},
+		{Name: "staging", URL: "https://pdns-staging:8081", VHost: "staging", Zones: []string{"staging.example.com."}},
+	}, servers)
+	b, err := NewPowerDNSRouter(servers, nil)
+	require.NoError(t, err)
+	require.Equal(t, "powerdns", b.Name)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "empty", content: "[]"},
+		{name: "unknown field", content: "- name: a\n  url: http://a\n  zone: [.]\n"},
+		{name: "without name", content: "- url: http://a\n  zones: [.]\n"},
+		{name: "duplicate", content: "- name: a\n  url: http://a\n  zones: [.]\n- name: a\n  url: http://b\n  zones: [b.]\n"},
+		{name: "invalid url", content: "- name: a\n  url: pdns:8081\n  zones: [.]\n"},
+		{name: "without zones", content: "- name: a\n  url: http://a\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "invalid.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			_, err := LoadPowerDNSServers(path)
+			require.Error(t, err)
+		})
+	}
+}
+
+// fake is a backend which records the calls for its zones
+type fake struct {
+	name  string
+	zones []string
+	calls []string
+	err   error
+}
+
+func newFake(name string, zones ...string) *fake {
+	return &fake{name: name, zones: zones}
+}
+
+func (f *fake) backend() *Backend {
+	return &Backend{
+		Name:    f.name,
+		Zones:   &fakeZones{f},
+		Records: &fakeRecords{f},
+		Probe: func(ctx context.Context) error {
+			return f.err
+		},
+	}
+}
+
+type fakeZones struct {
+	*fake
+}
+
+func (z *fakeZones) List(ctx context.Context) ([]powerdns.Zone, error) {
+	if z.err != nil {
+		return nil, z.err
+	}
+	var result []powerdns.Zone
+	for _, zone := range z.zones {
+		result = append(result, powerdns.Zone{Name: powerdns.String(zone)})
+	}
+	return result, nil
+}
+
+func (z *fakeZones) Get(ctx context.Context, domain string) (*powerdns.Zone, error) {
+	for _, zone := range z.zones {
+		if zone == domain {
+			return &powerdns.Zone{Name: powerdns.String(zone)}, nil
+		}
+	}
+	return nil, NotFound("zone %s not found", domain)
+}
+
+func (z *fakeZones) Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error) {
+	z.zones = append(z.zones, *zone.Name)
+	return zone, nil
+}
+
+func (z *fakeZones) Change(ctx context.Context, domain string, zone *powerdns.Zone) error {
+	return nil
+}
+
+func (z *fakeZones) Delete(ctx context.Context, domain string) error {
+	var kept []string
+	for _, zone := range z.zones {
+		if zone != domain {
+			kept = append(kept, zone)
+		}
+	}
+	z.zones = kept
+	return nil
+}
+
+type fakeRecords struct {
+	*fake
+}
+
+func (r *fakeRecords) Add(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	r.calls = append(r.calls, "add "+domain)
+	return nil
+}
+
+func (r *fakeRecords) Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	r.calls = append(r.calls, "change "+domain)
+	return nil
+}
+
+func (r *fakeRecords) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
+	r.calls = append(r.calls, "delete "+domain)
+	return nil
+}
+
+func (r *fakeRecords) Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error {
+	r.calls = append(r.calls, "patch "+domain)
+	return nil
+}
@@ -0,0 +1,98 @@
+package backend
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/miekg/dns"
+)
+
+// defaultNSTTL is the ttl of the nameservers of a zone if it has none yet
+const defaultNSTTL = 3600
+
+func newRR(name, rrtype string, ttl uint32, content string) (dns.RR, error) {
+	rr, err := dns.NewRR(fmt.Sprintf("%s %d IN %s %s", dns.Fqdn(name), ttl, rrtype, content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s record %q %w", rrtype, content, err)
+	}
+	if rr == nil {
+		return nil, fmt.Errorf("%s record of %s must not be empty", rrtype, name)
+	}
+	return rr, nil
+}
+
+// toZone groups the records of a zone into rrsets in the order of their first occurrence
+func toZone(zone string, rrs []dns.RR) *powerdns.Zone {
+	z := &powerdns.Zone{
+		ID:   powerdns.String(zone),
+		Name: powerdns.String(zone),
+		URL:  powerdns.String(""),
+		Kind: powerdns.ZoneKindPtr(powerdns.MasterZoneKind),
+	}
+	index := map[string]int{}
+	for _, rr := range rrs {
+		h := rr.Header()
+		rrtype := dns.TypeToString[h.Rrtype]
+		key := dns.CanonicalName(h.Name) + " " + rrtype
+		i, ok := index[key]
+		if !ok {
+			i = len(z.RRsets)
+			index[key] = i
+			z.RRsets = append(z.RRsets, powerdns.RRset{
+				Name:    powerdns.String(h.Name),
+				Type:    powerdns.RRTypePtr(powerdns.RRType(rrtype)),
+				TTL:     powerdns.Uint32(h.Ttl),
+				Records: []powerdns.Record{},
+			})
+		}
+		content := strings.TrimPrefix(rr.String(), h.String())
+		z.RRsets[i].Records = append(z.RRsets[i].Records, powerdns.Record{Content: powerdns.String(content), Disabled: powerdns.Bool(false)})
+
+		switch rr := rr.(type) {
+		case *dns.SOA:
+			z.Serial = powerdns.Uint32(rr.Serial)
+		case *dns.NS:
+			if dns.CanonicalName(h.Name) == dns.CanonicalName(zone) {
+				z.Nameservers = append(z.Nameservers, rr.Ns)
+			}
+		}
+	}
+	return z
+}
+
+// nameserversRRset replaces the apex nameservers with the nameservers of zone, the ttl of the existing ones is kept
+func nameserversRRset(domain string, zone *powerdns.Zone) powerdns.RRset {
+	ttl := uint32(defaultNSTTL)
+	for _, set := range zone.RRsets {
+		if set.Type != nil && *set.Type == powerdns.RRTypeNS && dns.CanonicalName(powerdns.StringValue(set.Name)) == dns.CanonicalName(domain) {
+			ttl = powerdns.Uint32Value(set.TTL)
+		}
+	}
+	content := make([]string, 0, len(zone.Nameservers))
+	for _, ns := range zone.Nameservers {
+		content = append(content, dns.Fqdn(ns))
+	}
+	return replaceRRset(dns.Fqdn(domain), powerdns.RRTypeNS, ttl, content)
+}
+
+func replaceRRset(name string, recordType powerdns.RRType, ttl uint32, content []string) powerdns.RRset {
+	set := powerdns.RRset{
+		Name:       powerdns.String(name),
+		Type:       powerdns.RRTypePtr(recordType),
+		TTL:        powerdns.Uint32(ttl),
+		ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace),
+	}
+	for _, c := range content {
+		set.Records = append(set.Records, powerdns.Record{Content: powerdns.String(c)})
+	}
+	return set
+}
+
+func deleteRRset(name string, recordType powerdns.RRType) powerdns.RRset {
+	return powerdns.RRset{
+		Name:       powerdns.String(name),
+		Type:       powerdns.RRTypePtr(recordType),
+		ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeDelete),
+	}
+}
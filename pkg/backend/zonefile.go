@@ -0,0 +1,408 @@
+package backend
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultReloadTimeout is the default timeout of the reload command of zone files
+	DefaultReloadTimeout = 30 * time.Second
+	// ZoneFileSuffix is appended to the name of the zone without the trailing dot to get the name of its file
+	ZoneFileSuffix = ".zone"
+	// lockFile is locked in the directory of the zone files while they are changed
+	lockFile = ".metal-dns.lock"
+)
+
+// ZoneFileConfig configures a backend which keeps the zones in RFC 1035 zone files
+type ZoneFileConfig struct {
+	// Directory contains one file per zone, e.g. example.com.zone for example.com.
+	Directory string `yaml:"directory"`
+	// ReloadCommand is run after every change of a zone file, with the zone and the path of its file
+	// in the environment variables ZONE and ZONE_FILE. Nothing is run if empty.
+	ReloadCommand []string      `yaml:"reload-command"`
+	ReloadTimeout time.Duration `yaml:"reload-timeout"`
+}
+
+// NewZoneFile returns a backend which reads and writes the zone files in a directory, e.g. to be served by the file plugin of CoreDNS.
+// Files are replaced atomically while a lock on the directory is held and the serial of the soa is incremented with every change.
+func NewZoneFile(config ZoneFileConfig) (*Backend, error) {
+	info, err := os.Stat(config.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access zone file directory %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("zone file directory %s is not a directory", config.Directory)
+	}
+	if config.ReloadTimeout <= 0 {
+		config.ReloadTimeout = DefaultReloadTimeout
+	}
+	z := &zoneFile{c: config}
+	return &Backend{
+		Name:    "zonefile",
+		Zones:   &zoneFileZones{z},
+		Records: &zoneFileRecords{z},
+		Probe:   z.probe,
+	}, nil
+}
+
+type zoneFile struct {
+	c ZoneFileConfig
+	// lock serializes the changes within the process, the lock file serializes them with other processes
+	lock sync.Mutex
+}
+
+// path returns the path of the file of a zone
+func (z *zoneFile) path(domain string) (string, error) {
+	zone := strings.TrimSuffix(dns.CanonicalName(domain), ".")
+	if _, ok := dns.IsDomainName(domain); !ok || zone == "" || strings.ContainsAny(zone, `/\`) {
+		return "", fmt.Errorf("%q is not a valid zone name", domain)
+	}
+	return filepath.Join(z.c.Directory, zone+ZoneFileSuffix), nil
+}
+
+// read parses the zone file of domain, a missing file is a not found error
+func (z *zoneFile) read(domain string) ([]dns.RR, error) {
+	path, err := z.path(domain)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, NotFound("zone %s not found", domain)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open zone file %w", err)
+	}
+	defer f.Close()
+
+	var rrs []dns.RR
+	zp := dns.NewZoneParser(f, dns.Fqdn(domain), path)
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		rrs = append(rrs, rr)
+	}
+	if err := zp.Err(); err != nil {
+		return nil, fmt.Errorf("unable to parse zone file %w", err)
+	}
+	if soaOf(rrs) == nil {
+		return nil, fmt.Errorf("zone file %s has no soa", path)
+	}
+	return rrs, nil
+}
+
+// write replaces the zone file at path with rrs, the soa is written first
+func (z *zoneFile) write(path string, zone string, rrs []dns.RR) error {
+	mode := fs.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	f, err := os.CreateTemp(z.c.Directory, "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to create zone file %w", err)
+	}
+	defer func() {
+		// does nothing after the rename
+		_ = os.Remove(f.Name())
+	}()
+
+	w := bufio.NewWriter(f)
+	fmt.Fprintf(w, "; zone %s, written by metal-dns\n$ORIGIN %s\n", zone, zone)
+	soa := soaOf(rrs)
+	fmt.Fprintln(w, soa.String())
+	for _, rr := range rrs {
+		if rr != soa {
+			fmt.Fprintln(w, rr.String())
+		}
+	}
+	err = w.Flush()
+	if err == nil {
+		err = f.Chmod(mode)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write zone file %w", err)
+	}
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return fmt.Errorf("unable to replace zone file %w", err)
+	}
+	return nil
+}
+
+// locked runs fn while holding the lock of the directory
+func (z *zoneFile) locked(fn func() error) error {
+	z.lock.Lock()
+	defer z.lock.Unlock()
+	f, err := os.OpenFile(filepath.Join(z.c.Directory, lockFile), os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open lock file %w", err)
+	}
+	defer f.Close()
+	err = lockFD(f)
+	if err != nil {
+		return fmt.Errorf("unable to lock zone file directory %w", err)
+	}
+	defer func() {
+		_ = unlockFD(f)
+	}()
+	return fn()
+}
+
+// modify replaces the records of a zone with the result of fn, the serial is incremented and the reload command is run
+func (z *zoneFile) modify(ctx context.Context, domain string, fn func(rrs []dns.RR) ([]dns.RR, error)) error {
+	path, err := z.path(domain)
+	if err != nil {
+		return err
+	}
+	zone := dns.Fqdn(domain)
+	err = z.locked(func() error {
+		rrs, err := z.read(zone)
+		if err != nil {
+			return err
+		}
+		serial := soaOf(rrs).Serial
+		rrs, err = fn(rrs)
+		if err != nil {
+			return err
+		}
+		// a replaced soa gets the next serial too, secondaries would not transfer the zone otherwise
+		soa := soaOf(rrs)
+		if soa == nil {
+			return fmt.Errorf("the soa of zone %s must not be deleted", zone)
+		}
+		soa.Serial = serial + 1
+		return z.write(path, zone, rrs)
+	})
+	if err != nil {
+		return err
+	}
+	return z.reload(ctx, zone, path)
+}
+
+// reload runs the reload command after zone was written
+func (z *zoneFile) reload(ctx context.Context, zone, path string) error {
+	if len(z.c.ReloadCommand) == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, z.c.ReloadTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, z.c.ReloadCommand[0], z.c.ReloadCommand[1:]...)
+	cmd.Env = append(os.Environ(), "ZONE="+zone, "ZONE_FILE="+path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("zone %s was written but the reload command failed %w: %s", zone, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// probe checks that the directory of the zone files is accessible
+func (z *zoneFile) probe(ctx context.Context) error {
+	_, err := os.ReadDir(z.c.Directory)
+	if err != nil {
+		return fmt.Errorf("unable to read zone file directory %w", err)
+	}
+	return nil
+}
+
+func soaOf(rrs []dns.RR) *dns.SOA {
+	for _, rr := range rrs {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa
+		}
+	}
+	return nil
+}
+
+// applyRRsets replaces and deletes the rrsets in rrs like a patch of powerdns, disabled records are dropped
+func applyRRsets(zone string, rrs []dns.RR, sets []powerdns.RRset) ([]dns.RR, error) {
+	for _, set := range sets {
+		name := powerdns.StringValue(set.Name)
+		if !dns.IsSubDomain(zone, dns.Fqdn(name)) {
+			return nil, fmt.Errorf("%s is not in zone %s", name, zone)
+		}
+		if set.Type == nil {
+			return nil, fmt.Errorf("rrset %s without type", name)
+		}
+		rrtype, ok := dns.StringToType[string(*set.Type)]
+		if !ok {
+			return nil, fmt.Errorf("unknown record type %s", *set.Type)
+		}
+		deleted := set.ChangeType != nil && *set.ChangeType == powerdns.ChangeTypeDelete
+		// the soa cannot be deleted, a new one replaces the existing one
+		if rrtype == dns.TypeSOA && deleted {
+			continue
+		}
+		var added []dns.RR
+		if !deleted {
+			for _, record := range set.Records {
+				if powerdns.BoolValue(record.Disabled) {
+					continue
+				}
+				rr, err := newRR(name, string(*set.Type), powerdns.Uint32Value(set.TTL), powerdns.StringValue(record.Content))
+				if err != nil {
+					return nil, err
+				}
+				added = append(added, rr)
+			}
+		}
+		if rrtype == dns.TypeSOA && len(added) != 1 {
+			return nil, fmt.Errorf("zone must have exactly one soa, got %d", len(added))
+		}
+		kept := make([]dns.RR, 0, len(rrs)+len(added))
+		for _, rr := range rrs {
+			h := rr.Header()
+			if h.Rrtype != rrtype || dns.CanonicalName(h.Name) != dns.CanonicalName(name) {
+				kept = append(kept, rr)
+			}
+		}
+		rrs = append(kept, added...)
+	}
+	return rrs, nil
+}
+
+type zoneFileZones struct {
+	*zoneFile
+}
+
+// List returns the zones with a file in the directory without parsing them
+func (z *zoneFileZones) List(ctx context.Context) ([]powerdns.Zone, error) {
+	entries, err := os.ReadDir(z.c.Directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read zone file directory %w", err)
+	}
+	result := []powerdns.Zone{}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || !strings.HasSuffix(name, ZoneFileSuffix) {
+			continue
+		}
+		result = append(result, *toZone(strings.TrimSuffix(name, ZoneFileSuffix)+".", nil))
+	}
+	return result, nil
+}
+
+// Get parses the zone file, it is replaced atomically and can be read without the lock
+func (z *zoneFileZones) Get(ctx context.Context, domain string) (*powerdns.Zone, error) {
+	rrs, err := z.read(domain)
+	if err != nil {
+		return nil, err
+	}
+	return toZone(dns.Fqdn(domain), rrs), nil
+}
+
+// Add writes a new zone file with the rrsets and nameservers of zone, a soa is created if zone has none
+func (z *zoneFileZones) Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error) {
+	name := dns.Fqdn(powerdns.StringValue(zone.Name))
+	path, err := z.path(name)
+	if err != nil {
+		return nil, err
+	}
+	sets := zone.RRsets
+	if len(zone.Nameservers) > 0 {
+		sets = append(append([]powerdns.RRset{}, sets...), nameserversRRset(name, zone))
+	}
+	rrs, err := applyRRsets(name, nil, sets)
+	if err != nil {
+		return nil, err
+	}
+	if soaOf(rrs) == nil {
+		if len(zone.Nameservers) == 0 {
+			return nil, fmt.Errorf("zone %s needs nameservers or a soa", name)
+		}
+		rrs = append(rrs, &dns.SOA{
+			Hdr:     dns.RR_Header{Name: name, Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: defaultNSTTL},
+			Ns:      dns.Fqdn(zone.Nameservers[0]),
+			Mbox:    "hostmaster." + name,
+			Serial:  1,
+			Refresh: 3600,
+			Retry:   600,
+			Expire:  604800,
+			Minttl:  3600,
+		})
+	}
+	err = z.locked(func() error {
+		_, err := os.Stat(path)
+		if err == nil {
+			return fmt.Errorf("zone %s already exists", name)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("unable to access zone file %w", err)
+		}
+		return z.write(path, name, rrs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = z.reload(ctx, name, path)
+	if err != nil {
+		return nil, err
+	}
+	return toZone(name, rrs), nil
+}
+
+// Change replaces the nameservers of the zone, the other attributes of powerdns zones do not exist
+func (z *zoneFileZones) Change(ctx context.Context, domain string, zone *powerdns.Zone) error {
+	if len(zone.Nameservers) == 0 {
+		return nil
+	}
+	return z.modify(ctx, domain, func(rrs []dns.RR) ([]dns.RR, error) {
+		return applyRRsets(dns.Fqdn(domain), rrs, []powerdns.RRset{nameserversRRset(domain, zone)})
+	})
+}
+
+func (z *zoneFileZones) Delete(ctx context.Context, domain string) error {
+	path, err := z.path(domain)
+	if err != nil {
+		return err
+	}
+	err = z.locked(func() error {
+		err := os.Remove(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return NotFound("zone %s not found", domain)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return z.reload(ctx, dns.Fqdn(domain), path)
+}
+
+type zoneFileRecords struct {
+	*zoneFile
+}
+
+func (r *zoneFileRecords) Add(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	return r.Change(ctx, domain, name, recordType, ttl, content, options...)
+}
+
+func (r *zoneFileRecords) Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	return r.Patch(ctx, domain, &powerdns.RRsets{Sets: []powerdns.RRset{replaceRRset(name, recordType, ttl, content)}})
+}
+
+func (r *zoneFileRecords) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
+	return r.Patch(ctx, domain, &powerdns.RRsets{Sets: []powerdns.RRset{deleteRRset(name, recordType)}})
+}
+
+func (r *zoneFileRecords) Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error {
+	return r.modify(ctx, domain, func(rrs []dns.RR) ([]dns.RR, error) {
+		return applyRRsets(dns.Fqdn(domain), rrs, rrSets.Sets)
+	})
+}
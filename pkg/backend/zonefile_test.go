@@ -0,0 +1,179 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	reloads := filepath.Join(t.TempDir(), "reloads")
+
+	b, err := NewZoneFile(ZoneFileConfig{
+		Directory:     dir,
+		ReloadCommand: []string{"sh", "-c", `echo "$ZONE $ZONE_FILE" >> ` + reloads},
+	})
+	require.NoError(t, err)
+
+	get := func(t *testing.T) *powerdns.Zone {
+		zone, err := b.Zones.Get(ctx, "a.example.com.")
+		require.NoError(t, err)
+		return zone
+	}
+
+	t.Run("probe", func(t *testing.T) {
+		require.NoError(t, b.Probe(ctx))
+		_, err := NewZoneFile(ZoneFileConfig{Directory: filepath.Join(dir, "missing")})
+		require.Error(t, err)
+	})
+	t.Run("add zone", func(t *testing.T) {
+		_, err := b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("a.example.com.")})
+		require.Error(t, err)
+
+		zone, err := b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("a.example.com"), Nameservers: []string{"ns1.a.example.com", "ns2.a.example.com."}})
+		require.NoError(t, err)
+		require.Equal(t, "a.example.com.", *zone.Name)
+		require.Equal(t, uint32(1), *zone.Serial)
+		require.FileExists(t, filepath.Join(dir, "a.example.com.zone"))
+
+		_, err = b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("a.example.com."), Nameservers: []string{"ns1.a.example.com."}})
+		require.Error(t, err)
+	})
+	t.Run("get", func(t *testing.T) {
+		zone := get(t)
+		require.Equal(t, []string{"ns1.a.example.com.", "ns2.a.example.com."}, zone.Nameservers)
+		require.Equal(t, []string{"ns1.a.example.com. hostmaster.a.example.com. 1 3600 600 604800 3600"}, contentOf(zone, "a.example.com.", powerdns.RRTypeSOA))
+
+		_, err := b.Zones.Get(ctx, "b.example.com.")
+		require.True(t, IsNotFound(err))
+		_, err = b.Zones.Get(ctx, "../a.example.com.")
+		require.Error(t, err)
+	})
+	t.Run("list", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a zone"), 0600))
+		zones, err := b.Zones.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, zones, 1)
+		require.Equal(t, "a.example.com.", *zones[0].Name)
+	})
+	t.Run("records increment the serial", func(t *testing.T) {
+		require.NoError(t, b.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 60, []string{"1.2.3.4", "1.2.3.5"}))
+		zone := get(t)
+		require.ElementsMatch(t, []string{"1.2.3.4", "1.2.3.5"}, contentOf(zone, "www.a.example.com.", powerdns.RRTypeA))
+		require.Equal(t, uint32(2), *zone.Serial)
+
+		require.NoError(t, b.Records.Change(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 60, []string{"1.2.3.6"}))
+		zone = get(t)
+		require.Equal(t, []string{"1.2.3.6"}, contentOf(zone, "www.a.example.com.", powerdns.RRTypeA))
+		require.Equal(t, uint32(3), *zone.Serial)
+	})
+	t.Run("patch", func(t *testing.T) {
+		err := b.Records.Patch(ctx, "a.example.com.", &powerdns.RRsets{Sets: []powerdns.RRset{
+			deleteRRset("www.a.example.com.", powerdns.RRTypeA),
+			{
+				Name:       powerdns.String("mail.a.example.com."),
+				Type:       powerdns.RRTypePtr(powerdns.RRTypeMX),
+				TTL:        powerdns.Uint32(300),
+				ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace),
+				Records: []powerdns.Record{
+					{Content: powerdns.String("10 mx1.a.example.com.")},
+					{Content: powerdns.String("20 mx2.a.example.com."), Disabled: powerdns.Bool(true)},
+				},
+			},
+			// the serial is incremented nevertheless
+			replaceRRset("a.example.com.", powerdns.RRTypeSOA, 3600, []string{"ns2.a.example.com. hostmaster.a.example.com. 1 7200 600 604800 300"}),
+		}})
+		require.NoError(t, err)
+		zone := get(t)
+		require.Empty(t, contentOf(zone, "www.a.example.com.", powerdns.RRTypeA))
+		require.Equal(t, []string{"10 mx1.a.example.com."}, contentOf(zone, "mail.a.example.com.", powerdns.RRTypeMX))
+		require.Equal(t, []string{"ns2.a.example.com. hostmaster.a.example.com. 4 7200 600 604800 300"}, contentOf(zone, "a.example.com.", powerdns.RRTypeSOA))
+	})
+	t.Run("invalid changes are not written", func(t *testing.T) {
+		before := get(t)
+		require.Error(t, b.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 60, []string{"not an ip"}))
+		require.Error(t, b.Records.Add(ctx, "a.example.com.", "www.b.example.com.", powerdns.RRTypeA, 60, []string{"1.2.3.4"}))
+		require.Error(t, b.Records.Add(ctx, "a.example.com.", "a.example.com.", powerdns.RRTypeSOA, 60, nil))
+		require.Equal(t, before, get(t))
+		require.True(t, IsNotFound(b.Records.Delete(ctx, "b.example.com.", "www.b.example.com.", powerdns.RRTypeA)))
+	})
+	t.Run("change nameservers", func(t *testing.T) {
+		zone := get(t)
+		zone.Nameservers = []string{"ns3.a.example.com"}
+		require.NoError(t, b.Zones.Change(ctx, "a.example.com.", zone))
+		require.Equal(t, []string{"ns3.a.example.com."}, get(t).Nameservers)
+	})
+	t.Run("concurrent changes", func(t *testing.T) {
+		serial := *get(t).Serial
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				require.NoError(t, b.Records.Add(ctx, "a.example.com.", fmt.Sprintf("host%d.a.example.com.", i), powerdns.RRTypeA, 60, []string{"1.2.3.4"}))
+			}(i)
+		}
+		wg.Wait()
+		zone := get(t)
+		require.Equal(t, serial+10, *zone.Serial)
+		for i := 0; i < 10; i++ {
+			require.Equal(t, []string{"1.2.3.4"}, contentOf(zone, fmt.Sprintf("host%d.a.example.com.", i), powerdns.RRTypeA))
+		}
+	})
+	t.Run("reload command", func(t *testing.T) {
+		content, err := os.ReadFile(reloads)
+		require.NoError(t, err)
+		require.Contains(t, string(content), "a.example.com. "+filepath.Join(dir, "a.example.com.zone")+"\n")
+
+		failing, err := NewZoneFile(ZoneFileConfig{Directory: dir, ReloadCommand: []string{"sh", "-c", "echo reload failed; exit 1"}})
+		require.NoError(t, err)
+		err = failing.Records.Delete(ctx, "a.example.com.", "host0.a.example.com.", powerdns.RRTypeA)
+		require.ErrorContains(t, err, "reload failed")
+		// the change was written nevertheless
+		require.Empty(t, contentOf(get(t), "host0.a.example.com.", powerdns.RRTypeA))
+	})
+	t.Run("delete zone", func(t *testing.T) {
+		require.NoError(t, b.Zones.Delete(ctx, "a.example.com."))
+		require.NoFileExists(t, filepath.Join(dir, "a.example.com.zone"))
+		require.True(t, IsNotFound(b.Zones.Delete(ctx, "a.example.com.")))
+	})
+}
+
+func TestZoneFileParsesExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.example.com.zone"), []byte(`$ORIGIN b.example.com.
+$TTL 300
+@	IN SOA ns1 hostmaster 2023010100 3600 600 604800 300
+	IN NS ns1
+www	IN A 1.1.1.1
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.example.com.zone"), []byte("www 300 IN A 1.1.1.1\n"), 0600))
+
+	b, err := NewZoneFile(ZoneFileConfig{Directory: dir})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	zone, err := b.Zones.Get(ctx, "b.example.com.")
+	require.NoError(t, err)
+	require.Equal(t, uint32(2023010100), *zone.Serial)
+	require.Equal(t, []string{"1.1.1.1"}, contentOf(zone, "www.b.example.com.", powerdns.RRTypeA))
+
+	require.NoError(t, b.Records.Delete(ctx, "b.example.com.", "www.b.example.com.", powerdns.RRTypeA))
+	info, err := os.Stat(filepath.Join(dir, "b.example.com.zone"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	zone, err = b.Zones.Get(ctx, "b.example.com.")
+	require.NoError(t, err)
+	require.Equal(t, uint32(2023010101), *zone.Serial)
+
+	_, err = b.Zones.Get(ctx, "c.example.com.")
+	require.ErrorContains(t, err, "has no soa")
+}
@@ -0,0 +1,458 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/miekg/dns"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultOwner is the owner written to the ownership markers if ApplyOptions.Owner is empty
+	DefaultOwner = "default"
+	// ownerPrefix is prepended to the name of a rrset to get the name of its ownership marker,
+	// a separate name is used because a CNAME can not coexist with a TXT record
+	ownerPrefix = "_owner."
+	// wildcardOwnerPrefix replaces the * of a wildcard name, _owner.*.example.com. is not a valid name
+	wildcardOwnerPrefix = "_owner-wildcard."
+	ownerTTL            = 300
+	heritage            = "metal-dns"
+	defaultTTL          = 3600
+)
+
+// ZoneFile is the desired state of domains and their rrsets
+type ZoneFile struct {
+	Domains []ZoneDomain `yaml:"domains"`
+}
+
+// ZoneDomain is a domain, which is created if it does not exist, and all rrsets which must exist in it
+type ZoneDomain struct {
+	Name        string   `yaml:"name"`
+	Nameservers []string `yaml:"nameservers,omitempty"`
+	RRsets      []RRset  `yaml:"rrsets"`
+}
+
+// RRset are all records of a name and type. The api stores a single record per rrset,
+// data therefore is a single value, e.g. "10 mx.example.com." for a MX record.
+type RRset struct {
+	// Name is either a fqdn, "@" for the domain itself or relative to the domain
+	Name string `yaml:"name" json:"name"`
+	Type string `yaml:"type" json:"type"`
+	TTL  uint32 `yaml:"ttl,omitempty" json:"ttl"`
+	Data string `yaml:"data" json:"data"`
+}
+
+// ApplyOptions configure how a zone file is compared to the zones
+type ApplyOptions struct {
+	// Owner is written to the ownership markers of all rrsets of the zone file
+	Owner string
+	// Prune deletes rrsets which are not in the zone file, but only if they are marked as owned by Owner
+	Prune bool
+}
+
+// Action is the change to a rrset
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// RRsetChange changes a rrset from Before, the records in the zone, to After
+type RRsetChange struct {
+	Action Action  `json:"action"`
+	Before []RRset `json:"before,omitempty"`
+	After  *RRset  `json:"after,omitempty"`
+}
+
+// DomainPlan are the changes to a domain
+type DomainPlan struct {
+	Name string `json:"name"`
+	// Create is set if the domain does not exist yet
+	Create      bool          `json:"create,omitempty"`
+	Nameservers []string      `json:"nameservers,omitempty"`
+	Changes     []RRsetChange `json:"changes,omitempty"`
+}
+
+// Plan are the changes required to bring the zones to the state of a zone file
+type Plan struct {
+	Domains []DomainPlan `json:"domains"`
+}
+
+// ReadZoneFile reads and validates a zone file, unknown fields are rejected
+func ReadZoneFile(r io.Reader) (*ZoneFile, error) {
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	zf := &ZoneFile{}
+	if err := decoder.Decode(zf); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to parse zone file %w", err)
+	}
+	if err := zf.normalize(); err != nil {
+		return nil, err
+	}
+	return zf, nil
+}
+
+// normalize makes all names fully qualified, sets default ttls and quotes txt records like powerdns does
+func (zf *ZoneFile) normalize() error {
+	var errs []error
+	domains := map[string]bool{}
+	for i := range zf.Domains {
+		d := &zf.Domains[i]
+		d.Name = dns.Fqdn(d.Name)
+		if _, ok := dns.IsDomainName(d.Name); !ok || d.Name == "." {
+			errs = append(errs, fmt.Errorf("domain %q is not a valid domain name", d.Name))
+			continue
+		}
+		if domains[d.Name] {
+			errs = append(errs, fmt.Errorf("domain %s is given more than once", d.Name))
+		}
+		domains[d.Name] = true
+
+		rrsets := map[string]bool{}
+		for j := range d.RRsets {
+			rr := &d.RRsets[j]
+			switch {
+			case rr.Name == "@":
+				rr.Name = d.Name
+			case !dns.IsFqdn(rr.Name):
+				rr.Name = rr.Name + "." + d.Name
+			}
+			rr.Type = strings.ToUpper(rr.Type)
+			if rr.TTL == 0 {
+				rr.TTL = defaultTTL
+			}
+			if rr.Type == "TXT" || rr.Type == "SPF" {
+				rr.Data = quoteTXT(rr.Data)
+			}
+
+			if _, ok := dns.IsDomainName(rr.Name); !ok || !dns.IsSubDomain(d.Name, rr.Name) {
+				errs = append(errs, fmt.Errorf("rrset %q is not a name in domain %s", rr.Name, d.Name))
+			}
+			switch t := ToV1RecordType(rr.Type); t {
+			case v1.RecordType_UNKNOWN, v1.RecordType_ANY, v1.RecordType_ZZZ:
+				errs = append(errs, fmt.Errorf("rrset %s has an invalid type %q", rr.Name, rr.Type))
+			}
+			if rr.Data == "" {
+				errs = append(errs, fmt.Errorf("rrset %s %s has no data", rr.Name, rr.Type))
+			}
+			if _, ok := markedName(rr.Name); ok {
+				errs = append(errs, fmt.Errorf("rrset %s uses the name of an ownership marker", rr.Name))
+			}
+			key := rr.key()
+			if rrsets[key] {
+				errs = append(errs, fmt.Errorf("rrset %s %s is given more than once", rr.Name, rr.Type))
+			}
+			rrsets[key] = true
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// markerName returns the name of the ownership marker of the rrsets of name
+func markerName(name string) string {
+	if rest, ok := strings.CutPrefix(name, "*."); ok {
+		return wildcardOwnerPrefix + rest
+	}
+	return ownerPrefix + name
+}
+
+// markedName returns the name whose rrsets are marked by a marker at name, ok is false if name is no marker name
+func markedName(name string) (string, bool) {
+	if rest, ok := strings.CutPrefix(name, wildcardOwnerPrefix); ok {
+		return "*." + rest, true
+	}
+	return strings.CutPrefix(name, ownerPrefix)
+}
+
+func (rr RRset) key() string {
+	return rr.Name + " " + rr.Type
+}
+
+func quoteTXT(data string) string {
+	if strings.HasPrefix(data, `"`) {
+		return data
+	}
+	return strconv.Quote(data)
+}
+
+// marker is the content of an ownership marker, the types of the rrsets at a name which are owned by owner
+type marker struct {
+	owner string
+	types []string
+}
+
+func (m marker) String() string {
+	return strconv.Quote(fmt.Sprintf("heritage=%s,owner=%s,types=%s", heritage, m.owner, strings.Join(m.types, ";")))
+}
+
+// parseMarker parses the content of a txt record, ok is false if it is not an ownership marker
+func parseMarker(data string) (m marker, ok bool) {
+	unquoted, err := strconv.Unquote(data)
+	if err != nil {
+		return marker{}, false
+	}
+	var isMarker bool
+	for _, field := range strings.Split(unquoted, ",") {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "heritage":
+			isMarker = value == heritage
+		case "owner":
+			m.owner = value
+		case "types":
+			if value != "" {
+				m.types = strings.Split(value, ";")
+			}
+		}
+	}
+	return m, isMarker
+}
+
+// PlanZoneFile compares the zone file to the records returned by RecordService.List.
+// The ownership markers of all rrsets of the zone file are part of the plan.
+func PlanZoneFile(ctx context.Context, c Client, zf *ZoneFile, opts ApplyOptions) (*Plan, error) {
+	owner := opts.Owner
+	if owner == "" {
+		owner = DefaultOwner
+	}
+	plan := &Plan{}
+	for _, d := range zf.Domains {
+		dp := DomainPlan{Name: d.Name}
+
+		var current []*v1.Record
+		_, err := c.Domain().Get(ctx, connect.NewRequest(&v1.DomainServiceGetRequest{Name: d.Name}))
+		switch {
+		case connect.CodeOf(err) == connect.CodeNotFound:
+			dp.Create = true
+			dp.Nameservers = d.Nameservers
+		case err != nil:
+			return nil, fmt.Errorf("unable to get domain %s %w", d.Name, err)
+		default:
+			resp, err := c.Record().List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: d.Name, Type: v1.RecordType_ANY}))
+			if err != nil {
+				return nil, fmt.Errorf("unable to list records of domain %s %w", d.Name, err)
+			}
+			current = resp.Msg.Records
+		}
+
+		dp.Changes = diff(d.RRsets, current, owner, opts.Prune)
+		plan.Domains = append(plan.Domains, dp)
+	}
+	return plan, nil
+}
+
+func diff(rrsets []RRset, records []*v1.Record, owner string, prune bool) []RRsetChange {
+	current := map[string][]RRset{}
+	for _, r := range records {
+		rr := RRset{Name: r.Name, Type: r.Type.String(), TTL: r.Ttl, Data: r.Data}
+		current[rr.key()] = append(current[rr.key()], rr)
+	}
+
+	// the rrsets which are owned by owner according to the markers in the zone
+	owned := map[string]bool{}
+	for key, rrs := range current {
+		name, typ, _ := strings.Cut(key, " ")
+		marked, ok := markedName(name)
+		if typ != "TXT" || !ok {
+			continue
+		}
+		for _, rr := range rrs {
+			m, ok := parseMarker(rr.Data)
+			if !ok || m.owner != owner {
+				continue
+			}
+			owned[key] = true
+			for _, t := range m.types {
+				owned[marked+" "+t] = true
+			}
+		}
+	}
+
+	desired := append([]RRset{}, rrsets...)
+	types := map[string][]string{}
+	for _, rr := range rrsets {
+		types[rr.Name] = append(types[rr.Name], rr.Type)
+	}
+	if !prune {
+		// rrsets which are kept because pruning is disabled stay owned
+		for key := range owned {
+			name, typ, _ := strings.Cut(key, " ")
+			if _, ok := types[name]; !ok || current[key] == nil || contains(types[name], typ) {
+				continue
+			}
+			types[name] = append(types[name], typ)
+		}
+	}
+	for _, name := range sortedKeys(types) {
+		sort.Strings(types[name])
+		desired = append(desired, RRset{
+			Name: markerName(name),
+			Type: "TXT",
+			TTL:  ownerTTL,
+			Data: marker{owner: owner, types: types[name]}.String(),
+		})
+	}
+
+	var changes []RRsetChange
+	wanted := map[string]bool{}
+	for i := range desired {
+		rr := desired[i]
+		wanted[rr.key()] = true
+		before, exists := current[rr.key()]
+		switch {
+		case !exists:
+			changes = append(changes, RRsetChange{Action: ActionCreate, After: &rr})
+		case len(before) != 1 || before[0] != rr:
+			changes = append(changes, RRsetChange{Action: ActionUpdate, Before: before, After: &rr})
+		}
+	}
+	if prune {
+		for _, key := range sortedKeys(current) {
+			if wanted[key] || !owned[key] {
+				continue
+			}
+			changes = append(changes, RRsetChange{Action: ActionDelete, Before: current[key]})
+		}
+	}
+	return changes
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Empty is true if the plan has no changes
+func (p *Plan) Empty() bool {
+	for _, d := range p.Domains {
+		if d.Create || len(d.Changes) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders the plan in a human readable form, created rrsets are marked with "+",
+// updated with "~" and deleted with "-"
+func (p *Plan) String() string {
+	var (
+		buf                       bytes.Buffer
+		creates, updates, deletes int
+	)
+	for _, d := range p.Domains {
+		if d.Create {
+			fmt.Fprintf(&buf, "+ domain %s\n", d.Name)
+		}
+		for _, c := range d.Changes {
+			switch c.Action {
+			case ActionCreate:
+				creates++
+				fmt.Fprintf(&buf, "+ %s\n", c.After)
+			case ActionUpdate:
+				updates++
+				fmt.Fprintf(&buf, "~ %s\n", c.After)
+				for _, rr := range c.Before {
+					fmt.Fprintf(&buf, "    was %s\n", rr)
+				}
+			case ActionDelete:
+				deletes++
+				for _, rr := range c.Before {
+					fmt.Fprintf(&buf, "- %s\n", rr)
+				}
+			}
+		}
+	}
+	if p.Empty() {
+		buf.WriteString("no changes\n")
+		return buf.String()
+	}
+	fmt.Fprintf(&buf, "%d to create, %d to update, %d to delete\n", creates, updates, deletes)
+	return buf.String()
+}
+
+func (rr RRset) String() string {
+	return fmt.Sprintf("%s %d %s %s", rr.Name, rr.TTL, rr.Type, rr.Data)
+}
+
+// ApplyPlan creates the missing domains and changes the rrsets of the plan.
+// rrsets are created and updated before any rrset is deleted, it stops at the first error.
+func ApplyPlan(ctx context.Context, c Client, p *Plan) error {
+	for _, d := range p.Domains {
+		if !d.Create {
+			continue
+		}
+		_, err := c.Domain().Create(ctx, connect.NewRequest(&v1.DomainServiceCreateRequest{Name: d.Name, Nameservers: d.Nameservers}))
+		if err != nil {
+			return fmt.Errorf("unable to create domain %s %w", d.Name, err)
+		}
+	}
+	for _, action := range []Action{ActionCreate, ActionUpdate, ActionDelete} {
+		for _, d := range p.Domains {
+			for _, change := range d.Changes {
+				if change.Action != action {
+					continue
+				}
+				if err := applyChange(ctx, c, change); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func applyChange(ctx context.Context, c Client, change RRsetChange) error {
+	var err error
+	switch change.Action {
+	case ActionCreate:
+		rr := change.After
+		_, err = c.Record().Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{
+			Type: ToV1RecordType(rr.Type), Name: rr.Name, Data: rr.Data, Ttl: rr.TTL,
+		}))
+	case ActionUpdate:
+		rr := change.After
+		_, err = c.Record().Update(ctx, connect.NewRequest(&v1.RecordServiceUpdateRequest{
+			Type: ToV1RecordType(rr.Type), Name: rr.Name, Data: rr.Data, Ttl: rr.TTL,
+		}))
+	case ActionDelete:
+		// deleting a record removes its whole rrset
+		rr := change.Before[0]
+		_, err = c.Record().Delete(ctx, connect.NewRequest(&v1.RecordServiceDeleteRequest{
+			Type: ToV1RecordType(rr.Type), Name: rr.Name, Data: rr.Data,
+		}))
+	default:
+		return fmt.Errorf("unknown action %q", change.Action)
+	}
+	if err != nil {
+		rr := change.After
+		if rr == nil {
+			rr = &change.Before[0]
+		}
+		return fmt.Errorf("unable to %s rrset %s %s %w", change.Action, rr.Name, rr.Type, err)
+	}
+	return nil
+}
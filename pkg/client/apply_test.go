@@ -0,0 +1,434 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/service"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestReadZoneFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *ZoneFile
+		wantErr string
+	}{
+		{
+			name: "valid",
+			content: `
+domains:
+  - name: example.com
+    nameservers: [ns1.example.com.]
+    rrsets:
+      - name: "@"
+        type: mx
+        data: 10 mx.example.com.
+      - name: www
+        type: A
+        ttl: 600
+        data: 1.2.3.4
+      - name: example.com.
+        type: TXT
+        data: v=spf1 -all
+`,
+			want: &ZoneFile{Domains: []ZoneDomain{
+				{
+					Name:        "example.com.",
+					Nameservers: []string{"ns1.example.com."},
+					RRsets: []RRset{
+						{Name: "example.com.", Type: "MX", TTL: 3600, Data: "10 mx.example.com."},
+						{Name: "www.example.com.", Type: "A", TTL: 600, Data: "1.2.3.4"},
+						{Name: "example.com.", Type: "TXT", TTL: 3600, Data: `"v=spf1 -all"`},
+					},
+				},
+			}},
+		},
+		{
+			name:    "unknown field",
+			content: "domains:\n  - name: example.com.\n    records: []\n",
+			wantErr: "field records not found",
+		},
+		{
+			name: "invalid rrsets",
+			content: `
+domains:
+  - name: example.com.
+    rrsets:
+      - name: www.example.org.
+        type: A
+        data: 1.2.3.4
+      - name: www
+        type: B
+        data: 1.2.3.4
+      - name: ftp
+        type: A
+      - name: _owner.www
+        type: TXT
+        data: owned
+      - name: mail
+        type: A
+        data: 1.2.3.4
+      - name: mail
+        type: A
+        data: 1.2.3.5
+`,
+			wantErr: `rrset "www.example.org." is not a name in domain example.com.
+rrset www.example.com. has an invalid type "B"
+rrset ftp.example.com. A has no data
+rrset _owner.www.example.com. uses the name of an ownership marker
+rrset mail.example.com. A is given more than once`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadZoneFile(strings.NewReader(tt.content))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	www := RRset{Name: "www.example.com.", Type: "A", TTL: 600, Data: "1.2.3.4"}
+	wwwMarker := marker{owner: "default", types: []string{"A"}}.String()
+
+	tests := []struct {
+		name    string
+		rrsets  []RRset
+		records []*v1.Record
+		prune   bool
+		want    []RRsetChange
+	}{
+		{
+			name:   "create with marker",
+			rrsets: []RRset{www},
+			want: []RRsetChange{
+				{Action: ActionCreate, After: &www},
+				{Action: ActionCreate, After: &RRset{Name: "_owner.www.example.com.", Type: "TXT", TTL: 300, Data: wwwMarker}},
+			},
+		},
+		{
+			name:   "unchanged",
+			rrsets: []RRset{www},
+			records: []*v1.Record{
+				{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+				{Name: "_owner.www.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: wwwMarker},
+			},
+		},
+		{
+			name:   "update ttl and multiple records",
+			rrsets: []RRset{www},
+			records: []*v1.Record{
+				{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+				{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.5"},
+				{Name: "_owner.www.example.com.", Type: v1.RecordType_TXT, Ttl: 60, Data: wwwMarker},
+			},
+			want: []RRsetChange{
+				{Action: ActionUpdate, After: &www, Before: []RRset{www, {Name: "www.example.com.", Type: "A", TTL: 600, Data: "1.2.3.5"}}},
+				{
+					Action: ActionUpdate,
+					After:  &RRset{Name: "_owner.www.example.com.", Type: "TXT", TTL: 300, Data: wwwMarker},
+					Before: []RRset{{Name: "_owner.www.example.com.", Type: "TXT", TTL: 60, Data: wwwMarker}},
+				},
+			},
+		},
+		{
+			name:  "prune only owned rrsets",
+			prune: true,
+			records: []*v1.Record{
+				{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+				{Name: "_owner.www.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: wwwMarker},
+				{Name: "ftp.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+				{Name: "mail.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+				{Name: "_owner.mail.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: marker{owner: "other", types: []string{"A"}}.String()},
+			},
+			want: []RRsetChange{
+				{Action: ActionDelete, Before: []RRset{{Name: "_owner.www.example.com.", Type: "TXT", TTL: 300, Data: wwwMarker}}},
+				{Action: ActionDelete, Before: []RRset{www}},
+			},
+		},
+		{
+			name:   "owned rrsets stay owned without prune",
+			rrsets: []RRset{www},
+			records: []*v1.Record{
+				{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+				{Name: "www.example.com.", Type: v1.RecordType_AAAA, Ttl: 600, Data: "::1"},
+				{Name: "_owner.www.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: marker{owner: "default", types: []string{"A", "AAAA"}}.String()},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := diff(tt.rrsets, tt.records, "default", tt.prune)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyZoneFile(t *testing.T) {
+	fake := &fakeZones{
+		domains: map[string]bool{"example.com.": true},
+		records: map[string]*v1.Record{},
+	}
+	fake.set(&v1.Record{Name: "old.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"})
+	fake.set(&v1.Record{Name: "_owner.old.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: marker{owner: "git", types: []string{"A"}}.String()})
+	fake.set(&v1.Record{Name: "manual.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"})
+	fake.set(&v1.Record{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.5"})
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewDomainServiceHandler(fakeDomainService{fakeZones: fake}))
+	mux.Handle(apiv1connect.NewRecordServiceHandler(fakeRecordService{fakeZones: fake}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx := context.Background()
+	c := New(ctx, DialConfig{BaseURL: server.URL})
+
+	zf, err := ReadZoneFile(strings.NewReader(`
+domains:
+  - name: example.com.
+    rrsets:
+      - name: www
+        type: A
+        ttl: 600
+        data: 1.2.3.4
+  - name: example.org.
+    nameservers: [ns1.example.com.]
+    rrsets:
+      - name: "@"
+        type: MX
+        ttl: 600
+        data: 10 mx.example.com.
+`))
+	require.NoError(t, err)
+
+	plan, err := PlanZoneFile(ctx, c, zf, ApplyOptions{Owner: "git", Prune: true})
+	require.NoError(t, err)
+	require.Equal(t, `~ www.example.com. 600 A 1.2.3.4
+    was www.example.com. 600 A 1.2.3.5
++ _owner.www.example.com. 300 TXT "heritage=metal-dns,owner=git,types=A"
+- _owner.old.example.com. 300 TXT "heritage=metal-dns,owner=git,types=A"
+- old.example.com. 600 A 1.2.3.4
++ domain example.org.
++ example.org. 600 MX 10 mx.example.com.
++ _owner.example.org. 300 TXT "heritage=metal-dns,owner=git,types=MX"
+3 to create, 1 to update, 2 to delete
+`, plan.String())
+
+	require.NoError(t, ApplyPlan(ctx, c, plan))
+	require.Equal(t, []string{
+		"_owner.example.org. TXT 300 \"heritage=metal-dns,owner=git,types=MX\"",
+		"_owner.www.example.com. TXT 300 \"heritage=metal-dns,owner=git,types=A\"",
+		"example.org. MX 600 10 mx.example.com.",
+		"manual.example.com. A 600 1.2.3.4",
+		"www.example.com. A 600 1.2.3.4",
+	}, fake.list())
+
+	plan, err = PlanZoneFile(ctx, c, zf, ApplyOptions{Owner: "git", Prune: true})
+	require.NoError(t, err)
+	require.True(t, plan.Empty())
+	require.Equal(t, "no changes\n", plan.String())
+}
+
+func TestApplyZoneFileService(t *testing.T) {
+	log := zaptest.NewLogger(t).Sugar()
+	b, err := backend.NewZoneFile(backend.ZoneFileConfig{Directory: t.TempDir()})
+	require.NoError(t, err)
+	authz, err := auth.NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+	interceptors := connect.WithInterceptors(authz)
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewDomainServiceHandler(service.NewDomainService(log, b), interceptors))
+	mux.Handle(apiv1connect.NewRecordServiceHandler(service.NewRecordService(log, b), interceptors))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	jwtToken, err := token.NewJWTToken("metal-dns", "Tester", []string{"example.com.", "example.org."}, []string{
+		apiv1connect.DomainServiceGetProcedure,
+		apiv1connect.DomainServiceCreateProcedure,
+		apiv1connect.RecordServiceListProcedure,
+		apiv1connect.RecordServiceCreateProcedure,
+		apiv1connect.RecordServiceUpdateProcedure,
+		apiv1connect.RecordServiceDeleteProcedure,
+	}, time.Hour, "secret")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	c := New(ctx, DialConfig{BaseURL: server.URL, Token: jwtToken})
+
+	_, err = c.Domain().Create(ctx, connect.NewRequest(&v1.DomainServiceCreateRequest{Name: "example.com.", Nameservers: []string{"ns1.example.com."}}))
+	require.NoError(t, err)
+	for _, r := range []*v1.Record{
+		{Name: "old.example.com.", Type: v1.RecordType_A, Ttl: 600, Data: "1.2.3.4"},
+		{Name: "_owner.old.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: marker{owner: "git", types: []string{"A"}}.String()},
+	} {
+		_, err = c.Record().Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{Name: r.Name, Type: r.Type, Ttl: r.Ttl, Data: r.Data}))
+		require.NoError(t, err)
+	}
+
+	zf, err := ReadZoneFile(strings.NewReader(`
+domains:
+  - name: example.com.
+    rrsets:
+      - name: "@"
+        type: MX
+        ttl: 600
+        data: 10 mx.example.com.
+      - name: www
+        type: A
+        ttl: 600
+        data: 1.2.3.4
+      - name: "*.apps"
+        type: CNAME
+        ttl: 600
+        data: www.example.com.
+  - name: example.org.
+    nameservers: [ns1.example.com.]
+    rrsets:
+      - name: "@"
+        type: MX
+        ttl: 600
+        data: 10 mx.example.com.
+`))
+	require.NoError(t, err)
+
+	plan, err := PlanZoneFile(ctx, c, zf, ApplyOptions{Owner: "git", Prune: true})
+	require.NoError(t, err)
+	require.NoError(t, ApplyPlan(ctx, c, plan))
+
+	list := func(domain string) []string {
+		resp, err := c.Record().List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: domain, Type: v1.RecordType_ANY}))
+		require.NoError(t, err)
+		var records []string
+		for _, r := range resp.Msg.Records {
+			if r.Type == v1.RecordType_SOA || r.Type == v1.RecordType_NS {
+				continue
+			}
+			records = append(records, fmt.Sprintf("%s %s %d %s", r.Name, r.Type, r.Ttl, r.Data))
+		}
+		sort.Strings(records)
+		return records
+	}
+	require.Equal(t, []string{
+		"*.apps.example.com. CNAME 600 www.example.com.",
+		"_owner-wildcard.apps.example.com. TXT 300 \"heritage=metal-dns,owner=git,types=CNAME\"",
+		"_owner.example.com. TXT 300 \"heritage=metal-dns,owner=git,types=MX\"",
+		"_owner.www.example.com. TXT 300 \"heritage=metal-dns,owner=git,types=A\"",
+		"example.com. MX 600 10 mx.example.com.",
+		"www.example.com. A 600 1.2.3.4",
+	}, list("example.com."))
+	require.Equal(t, []string{
+		"_owner.example.org. TXT 300 \"heritage=metal-dns,owner=git,types=MX\"",
+		"example.org. MX 600 10 mx.example.com.",
+	}, list("example.org."))
+
+	plan, err = PlanZoneFile(ctx, c, zf, ApplyOptions{Owner: "git", Prune: true})
+	require.NoError(t, err)
+	require.True(t, plan.Empty(), plan.String())
+}
+
+// fakeZones stores a single record per rrset, like the RecordService does
+type fakeZones struct {
+	lock    sync.Mutex
+	domains map[string]bool
+	records map[string]*v1.Record
+}
+
+func (f *fakeZones) set(r *v1.Record) {
+	f.records[r.Name+" "+r.Type.String()] = r
+}
+
+func (f *fakeZones) list() []string {
+	var records []string
+	for _, r := range f.records {
+		records = append(records, fmt.Sprintf("%s %s %d %s", r.Name, r.Type, r.Ttl, r.Data))
+	}
+	sort.Strings(records)
+	return records
+}
+
+type fakeDomainService struct {
+	apiv1connect.UnimplementedDomainServiceHandler
+	*fakeZones
+}
+
+func (f fakeDomainService) Get(ctx context.Context, req *connect.Request[v1.DomainServiceGetRequest]) (*connect.Response[v1.DomainServiceGetResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.domains[req.Msg.Name] {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("not found"))
+	}
+	return connect.NewResponse(&v1.DomainServiceGetResponse{Domain: &v1.Domain{Name: req.Msg.Name}}), nil
+}
+
+func (f fakeDomainService) Create(ctx context.Context, req *connect.Request[v1.DomainServiceCreateRequest]) (*connect.Response[v1.DomainServiceCreateResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.domains[req.Msg.Name] = true
+	return connect.NewResponse(&v1.DomainServiceCreateResponse{Domain: &v1.Domain{Name: req.Msg.Name}}), nil
+}
+
+type fakeRecordService struct {
+	apiv1connect.UnimplementedRecordServiceHandler
+	*fakeZones
+}
+
+func (f fakeRecordService) List(ctx context.Context, req *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var records []*v1.Record
+	for _, r := range f.records {
+		if strings.HasSuffix(r.Name, req.Msg.Domain) {
+			records = append(records, r)
+		}
+	}
+	return connect.NewResponse(&v1.RecordServiceListResponse{Records: records}), nil
+}
+
+func (f fakeRecordService) Create(ctx context.Context, req *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	r := &v1.Record{Name: req.Msg.Name, Type: req.Msg.Type, Ttl: req.Msg.Ttl, Data: req.Msg.Data}
+	f.set(r)
+	return connect.NewResponse(&v1.RecordServiceCreateResponse{Record: r}), nil
+}
+
+func (f fakeRecordService) Update(ctx context.Context, req *connect.Request[v1.RecordServiceUpdateRequest]) (*connect.Response[v1.RecordServiceUpdateResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	r := &v1.Record{Name: req.Msg.Name, Type: req.Msg.Type, Ttl: req.Msg.Ttl, Data: req.Msg.Data}
+	f.set(r)
+	return connect.NewResponse(&v1.RecordServiceUpdateResponse{Record: r}), nil
+}
+
+func (f fakeRecordService) Delete(ctx context.Context, req *connect.Request[v1.RecordServiceDeleteRequest]) (*connect.Response[v1.RecordServiceDeleteResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.records, req.Msg.Name+" "+req.Msg.Type.String())
+	return connect.NewResponse(&v1.RecordServiceDeleteResponse{}), nil
+}
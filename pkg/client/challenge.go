@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/miekg/dns"
+)
+
+const (
+	// DefaultPropagationTimeout and DefaultPollingInterval are the defaults of lego
+	DefaultPropagationTimeout = 60 * time.Second
+	DefaultPollingInterval    = 2 * time.Second
+)
+
+// ChallengeProviderConfig configures a ChallengeProvider, zero values are replaced by the defaults
+type ChallengeProviderConfig struct {
+	// TTL of the challenge records, the ChallengeService defaults to 60 seconds
+	TTL uint32
+	// PropagationTimeout and PollingInterval are returned by Timeout to tell lego how long to wait for the record
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+}
+
+// ChallengeProvider solves ACME DNS-01 challenges with the ChallengeService.
+// It implements challenge.Provider and challenge.ProviderTimeout of github.com/go-acme/lego/v4.
+type ChallengeProvider struct {
+	client Client
+	config ChallengeProviderConfig
+}
+
+// NewChallengeProvider creates a provider, which can be passed to lego with SetDNS01Provider
+func NewChallengeProvider(c Client, config ChallengeProviderConfig) *ChallengeProvider {
+	if config.PropagationTimeout == 0 {
+		config.PropagationTimeout = DefaultPropagationTimeout
+	}
+	if config.PollingInterval == 0 {
+		config.PollingInterval = DefaultPollingInterval
+	}
+	return &ChallengeProvider{client: c, config: config}
+}
+
+// Present creates the TXT record of the challenge for domain, other challenges of the same name are kept
+func (p *ChallengeProvider) Present(domain, token, keyAuth string) error {
+	name, value := ChallengeRecord(domain, keyAuth)
+	_, err := p.client.Challenge().Present(context.Background(), connect.NewRequest(&v1.ChallengeServicePresentRequest{
+		Name:  name,
+		Value: value,
+		Ttl:   p.config.TTL,
+	}))
+	if err != nil {
+		return fmt.Errorf("unable to present challenge for %s %w", domain, err)
+	}
+	return nil
+}
+
+// CleanUp removes the TXT record of the challenge for domain
+func (p *ChallengeProvider) CleanUp(domain, token, keyAuth string) error {
+	name, value := ChallengeRecord(domain, keyAuth)
+	_, err := p.client.Challenge().CleanUp(context.Background(), connect.NewRequest(&v1.ChallengeServiceCleanUpRequest{
+		Name:  name,
+		Value: value,
+	}))
+	if err != nil {
+		return fmt.Errorf("unable to clean up challenge for %s %w", domain, err)
+	}
+	return nil
+}
+
+// Timeout returns how long lego waits for the record to become visible and how often it checks
+func (p *ChallengeProvider) Timeout() (timeout, interval time.Duration) {
+	return p.config.PropagationTimeout, p.config.PollingInterval
+}
+
+// ChallengeRecord returns the name and value of the TXT record of a DNS-01 challenge, like dns01.GetRecord of lego.
+// The challenge of a wildcard domain is placed at the name of the domain itself, CNAMEs are not followed.
+func ChallengeRecord(domain, keyAuth string) (name, value string) {
+	digest := sha256.Sum256([]byte(keyAuth))
+	value = base64.RawURLEncoding.EncodeToString(digest[:])
+	name = "_acme-challenge." + dns.Fqdn(strings.TrimPrefix(domain, "*."))
+	return name, value
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeRecord(t *testing.T) {
+	tests := []struct {
+		domain   string
+		wantName string
+	}{
+		{domain: "example.com", wantName: "_acme-challenge.example.com."},
+		{domain: "*.example.com", wantName: "_acme-challenge.example.com."},
+		{domain: "www.example.com.", wantName: "_acme-challenge.www.example.com."},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.domain, func(t *testing.T) {
+			name, value := ChallengeRecord(tt.domain, "token.thumbprint")
+			require.Equal(t, tt.wantName, name)
+			// base64url of the sha256 digest without padding
+			require.Equal(t, "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I", value)
+		})
+	}
+}
+
+func TestChallengeProvider(t *testing.T) {
+	fake := &fakeChallengeService{}
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewChallengeServiceHandler(fake))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := NewChallengeProvider(New(context.Background(), DialConfig{BaseURL: server.URL}), ChallengeProviderConfig{TTL: 30})
+
+	timeout, interval := p.Timeout()
+	require.Equal(t, time.Minute, timeout)
+	require.Equal(t, 2*time.Second, interval)
+
+	require.NoError(t, p.Present("*.example.com", "token", "token.thumbprint"))
+	require.Equal(t, "_acme-challenge.example.com.", fake.present.Name)
+	require.Equal(t, "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I", fake.present.Value)
+	require.Equal(t, uint32(30), fake.present.Ttl)
+
+	require.NoError(t, p.CleanUp("*.example.com", "token", "token.thumbprint"))
+	require.Equal(t, "_acme-challenge.example.com.", fake.cleanUp.Name)
+	require.Equal(t, "61rBZ_4knHblO0MNoxFsXZ_eTFUHum0B6IVRbhvUn5I", fake.cleanUp.Value)
+
+	fake.err = connect.NewError(connect.CodePermissionDenied, nil)
+	require.EqualError(t, p.Present("example.com", "token", "token.thumbprint"), "unable to present challenge for example.com permission_denied")
+}
+
+type fakeChallengeService struct {
+	apiv1connect.UnimplementedChallengeServiceHandler
+	present *v1.ChallengeServicePresentRequest
+	cleanUp *v1.ChallengeServiceCleanUpRequest
+	err     error
+}
+
+func (f *fakeChallengeService) Present(ctx context.Context, req *connect.Request[v1.ChallengeServicePresentRequest]) (*connect.Response[v1.ChallengeServicePresentResponse], error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.present = req.Msg
+	return connect.NewResponse(&v1.ChallengeServicePresentResponse{Values: []string{req.Msg.Value}}), nil
+}
+
+func (f *fakeChallengeService) CleanUp(ctx context.Context, req *connect.Request[v1.ChallengeServiceCleanUpRequest]) (*connect.Response[v1.ChallengeServiceCleanUpResponse], error) {
+	f.cleanUp = req.Msg
+	return connect.NewResponse(&v1.ChallengeServiceCleanUpResponse{}), nil
+}
@@ -3,8 +3,11 @@ package client
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 
+	"github.com/bufbuild/connect-go"
+	otelconnect "github.com/bufbuild/connect-opentelemetry-go"
 	compress "github.com/klauspost/connect-compress"
 	"github.com/majst01/metal-dns/api/v1/apiv1connect"
 )
@@ -13,33 +16,58 @@ import (
 type Client interface {
 	Domain() apiv1connect.DomainServiceClient
 	Record() apiv1connect.RecordServiceClient
+	Challenge() apiv1connect.ChallengeServiceClient
+	Webhook() apiv1connect.WebhookServiceClient
 	Token() apiv1connect.TokenServiceClient
 }
 
 type api struct {
-	log                 *zap.SugaredLogger
-	domainServiceClient apiv1connect.DomainServiceClient
-	recordServiceClient apiv1connect.RecordServiceClient
-	tokenServiceClient  apiv1connect.TokenServiceClient
+	log                    *zap.SugaredLogger
+	domainServiceClient    apiv1connect.DomainServiceClient
+	recordServiceClient    apiv1connect.RecordServiceClient
+	challengeServiceClient apiv1connect.ChallengeServiceClient
+	webhookServiceClient   apiv1connect.WebhookServiceClient
+	tokenServiceClient     apiv1connect.TokenServiceClient
 }
 
 func New(ctx context.Context, config DialConfig) Client {
+	// spans are created with the global tracer provider of the caller,
+	// the trace context is always propagated in the W3C format
+	tracing := connect.WithInterceptors(otelconnect.NewInterceptor(
+		otelconnect.WithPropagator(propagation.TraceContext{}),
+		otelconnect.WithoutMetrics(),
+	))
 	return &api{
 		log: config.Log,
 		domainServiceClient: apiv1connect.NewDomainServiceClient(
 			config.HttpClient(),
 			config.BaseURL,
 			compress.WithAll(compress.LevelBalanced),
+			tracing,
 		),
 		recordServiceClient: apiv1connect.NewRecordServiceClient(
 			config.HttpClient(),
 			config.BaseURL,
 			compress.WithAll(compress.LevelBalanced),
+			tracing,
+		),
+		challengeServiceClient: apiv1connect.NewChallengeServiceClient(
+			config.HttpClient(),
+			config.BaseURL,
+			compress.WithAll(compress.LevelBalanced),
+			tracing,
+		),
+		webhookServiceClient: apiv1connect.NewWebhookServiceClient(
+			config.HttpClient(),
+			config.BaseURL,
+			compress.WithAll(compress.LevelBalanced),
+			tracing,
 		),
 		tokenServiceClient: apiv1connect.NewTokenServiceClient(
 			config.HttpClient(),
 			config.BaseURL,
 			compress.WithAll(compress.LevelBalanced),
+			tracing,
 		),
 	}
 }
@@ -54,6 +82,16 @@ func (a *api) Record() apiv1connect.RecordServiceClient {
 	return a.recordServiceClient
 }
 
+// Challenge is the root accessor for ACME DNS-01 challenge related functions
+func (a *api) Challenge() apiv1connect.ChallengeServiceClient {
+	return a.challengeServiceClient
+}
+
+// Webhook is the root accessor for webhook related functions
+func (a *api) Webhook() apiv1connect.WebhookServiceClient {
+	return a.webhookServiceClient
+}
+
 // Token is the root accessor for domain record related functions
 func (a *api) Token() apiv1connect.TokenServiceClient {
 	return a.tokenServiceClient
@@ -1,10 +1,13 @@
 package client
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httputil"
+	"os"
 
 	"go.uber.org/zap"
 )
@@ -16,19 +19,72 @@ type DialConfig struct {
 	Log     *zap.SugaredLogger
 	Debug   bool
 
+	// CA verifies the server certificate instead of the system roots, path to a pem file
+	CA string
+	// Cert and Key are the client certificate presented to the server, paths to pem files
+	Cert string
+	Key  string
+
 	UserAgent string
 }
 
 func (d *DialConfig) HttpClient() *http.Client {
+	var t http.RoundTripper = http.DefaultTransport
+	tlsConfig, err := d.TLSConfig()
+	switch {
+	case err != nil:
+		// report invalid tls settings with the first call, constructors of the clients do not return errors
+		t = errTransport{err: err}
+	case tlsConfig != nil:
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		t = transport
+	}
 	return &http.Client{
 		Transport: &AddHeaderTransport{
 			debug: d.Debug,
-			T:     http.DefaultTransport,
+			T:     t,
 			Token: d.Token,
 		},
 	}
 }
 
+// TLSConfig returns the tls configuration for the CA and client certificate,
+// nil if neither is configured.
+func (d *DialConfig) TLSConfig() (*tls.Config, error) {
+	if d.CA == "" && d.Cert == "" && d.Key == "" {
+		return nil, nil
+	}
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if d.CA != "" {
+		ca, err := os.ReadFile(d.CA)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in ca %s", d.CA)
+		}
+		config.RootCAs = pool
+	}
+	if d.Cert != "" || d.Key != "" {
+		cert, err := tls.LoadX509KeyPair(d.Cert, d.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+type errTransport struct {
+	err error
+}
+
+func (e errTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, e.err
+}
+
 type AddHeaderTransport struct {
 	debug bool
 
@@ -37,7 +93,9 @@ type AddHeaderTransport struct {
 }
 
 func (a *AddHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Add("Authorization", "Bearer "+a.Token)
+	if a.Token != "" {
+		req.Header.Add("Authorization", "Bearer "+a.Token)
+	}
 	if a.debug {
 		reqDump, err := httputil.DumpRequestOut(req, true)
 		if err != nil {
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cast"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Load reads the config file at path into v. The format is derived from the file extension, e.g. yaml, toml or json.
+// Every key must be the name of one of the flags and its value must be convertible to the type of the flag,
+// all violations are reported at once.
+func Load(v *viper.Viper, flags *pflag.FlagSet, path string) error {
+	file := viper.New()
+	file.SetConfigFile(path)
+	err := file.ReadInConfig()
+	if err != nil {
+		return fmt.Errorf("unable to read config file %s %w", path, err)
+	}
+
+	err = Check(file.AllSettings(), flags)
+	if err != nil {
+		return fmt.Errorf("invalid config file %s:\n%w", path, err)
+	}
+
+	v.SetConfigFile(path)
+	return v.ReadInConfig()
+}
+
+// Check verifies that all keys in settings are known flags and have a value of the flag type
+func Check(settings map[string]any, flags *pflag.FlagSet) error {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var errs []error
+	for _, key := range keys {
+		value := settings[key]
+		flag := flags.Lookup(key)
+		if flag == nil {
+			if suggestion := suggest(key, flags); suggestion != "" {
+				errs = append(errs, fmt.Errorf("unknown key %q, did you mean %q?", key, suggestion))
+			} else {
+				errs = append(errs, fmt.Errorf("unknown key %q", key))
+			}
+			continue
+		}
+		if err := checkType(flag.Value.Type(), value); err != nil {
+			errs = append(errs, fmt.Errorf("key %q: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func checkType(flagType string, value any) error {
+	switch value.(type) {
+	case map[string]any:
+		return fmt.Errorf("expected a %s, got a map", flagType)
+	case []any:
+		if flagType != "stringSlice" && flagType != "stringArray" {
+			return fmt.Errorf("expected a %s, got a list", flagType)
+		}
+	}
+
+	var err error
+	switch flagType {
+	case "string":
+		_, err = cast.ToStringE(value)
+	case "bool":
+		_, err = cast.ToBoolE(value)
+	case "int":
+		_, err = cast.ToIntE(value)
+	case "uint32":
+		_, err = cast.ToUint32E(value)
+	case "float64":
+		_, err = cast.ToFloat64E(value)
+	case "duration":
+		_, err = cast.ToDurationE(value)
+	case "stringSlice", "stringArray":
+		_, err = cast.ToStringSliceE(value)
+	}
+	if err != nil {
+		return fmt.Errorf("expected a %s, got %v", flagType, value)
+	}
+	return nil
+}
+
+// suggest returns the flag name closest to key, if it is similar enough
+func suggest(key string, flags *pflag.FlagSet) string {
+	best, bestDistance := "", 4
+	flags.VisitAll(func(f *pflag.Flag) {
+		if d := distance(key, f.Name); d < bestDistance {
+			best, bestDistance = f.Name, d
+		}
+	})
+	return best
+}
+
+// distance is the levenshtein distance of a and b
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j] + 1
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if prev[j-1]+cost < cur[j] {
+				cur[j] = prev[j-1] + cost
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
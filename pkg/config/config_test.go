@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func testFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("http-endpoint", "localhost:8080", "")
+	flags.String("pdns-api-vhost", "localhost", "")
+	flags.Bool("otlp-insecure", false, "")
+	flags.Float64("trace-sample-ratio", 1.0, "")
+	flags.Duration("timeout", 0, "")
+	flags.StringSlice("cors-allowed-origins", nil, "")
+	return flags
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]any
+		wantErr  []string
+	}{
+		{
+			name:     "valid",
+			settings: map[string]any{"http-endpoint": ":8080", "otlp-insecure": true, "trace-sample-ratio": 0.5, "timeout": "5s", "cors-allowed-origins": []any{"a", "b"}},
+		},
+		{
+			name:     "unknown key with suggestion",
+			settings: map[string]any{"pdns-api-vhosts": "localhost"},
+			wantErr:  []string{`unknown key "pdns-api-vhosts", did you mean "pdns-api-vhost"?`},
+		},
+		{
+			name:     "unknown key",
+			settings: map[string]any{"backends": map[string]any{"a": 1}},
+			wantErr:  []string{`unknown key "backends"`},
+		},
+		{
+			name:     "wrong types",
+			settings: map[string]any{"otlp-insecure": "maybe", "trace-sample-ratio": "high", "timeout": "soon", "http-endpoint": []any{"a"}},
+			wantErr: []string{
+				`key "http-endpoint": expected a string, got a list`,
+				`key "otlp-insecure": expected a bool, got maybe`,
+				`key "timeout": expected a duration, got soon`,
+				`key "trace-sample-ratio": expected a float64, got high`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.settings, testFlags())
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				require.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlFile := filepath.Join(dir, "metal-dns.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("http-endpoint: 0.0.0.0:9090\ntrace-sample-ratio: 0.1\n"), 0600))
+	v := viper.New()
+	require.NoError(t, Load(v, testFlags(), yamlFile))
+	require.Equal(t, "0.0.0.0:9090", v.GetString("http-endpoint"))
+	require.Equal(t, 0.1, v.GetFloat64("trace-sample-ratio"))
+
+	tomlFile := filepath.Join(dir, "metal-dns.toml")
+	require.NoError(t, os.WriteFile(tomlFile, []byte("otlp-insecure = true\n"), 0600))
+	v = viper.New()
+	require.NoError(t, Load(v, testFlags(), tomlFile))
+	require.True(t, v.GetBool("otlp-insecure"))
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("http-endpont: 0.0.0.0:9090\n"), 0600))
+	err := Load(viper.New(), testFlags(), invalid)
+	require.ErrorContains(t, err, `did you mean "http-endpoint"?`)
+
+	err = Load(viper.New(), testFlags(), filepath.Join(dir, "missing.yaml"))
+	require.Error(t, err)
+}
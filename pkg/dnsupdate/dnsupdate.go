@@ -0,0 +1,652 @@
+// Package dnsupdate accepts dynamic updates as specified in RFC 2136 which are signed with TSIG keys.
+// The updates are applied with the RecordService of the api, every key acts like a token with its domains
+// and permissions, the calls pass the authorizer, rate limits and the audit log like calls of other clients.
+package dnsupdate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/majst01/metal-dns/pkg/propagation"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// tokenExpiry is the lifetime of the token created for a key, it is only used for a single update
+	tokenExpiry = time.Minute
+	// updateTimeout limits the time to apply all changes of an update
+	updateTimeout = 30 * time.Second
+	// fudge is the allowed clock skew of the signed responses
+	fudge = 300
+)
+
+// Config of the dynamic update listener
+type Config struct {
+	// Endpoint is the address of the udp and tcp listeners, e.g. :53
+	Endpoint string
+	Keys     []Key
+	// Secret signs the tokens which are created for the keys
+	Secret string
+}
+
+// Server answers dynamic updates on udp and tcp
+type Server struct {
+	log     *zap.SugaredLogger
+	c       Config
+	keys    map[string]Key
+	records apiv1connect.RecordServiceClient
+	authz   apiv1connect.AuthzServiceClient
+	udp     *dns.Server
+	tcp     *dns.Server
+}
+
+// New returns a server which authorizes the updates with the AuthzService and applies them with the RecordService served by handler
+func New(log *zap.SugaredLogger, handler http.Handler, config Config) *Server {
+	keys := map[string]Key{}
+	for _, k := range config.Keys {
+		keys[dns.CanonicalName(k.Name)] = k
+	}
+	httpClient := &http.Client{Transport: handlerTransport{handler: handler}}
+	return &Server{
+		log:     log.Named("dnsupdate"),
+		c:       config,
+		keys:    keys,
+		records: apiv1connect.NewRecordServiceClient(httpClient, "http://metal-dns"),
+		authz:   apiv1connect.NewAuthzServiceClient(httpClient, "http://metal-dns"),
+	}
+}
+
+// Start binds the udp and tcp listeners to the same address and serves them in the background
+func (s *Server) Start() error {
+	pc, err := net.ListenPacket("udp", s.c.Endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to listen for dns updates %w", err)
+	}
+	// the udp address is used to get the same port if the endpoint has port 0
+	l, err := net.Listen("tcp", pc.LocalAddr().String())
+	if err != nil {
+		pc.Close()
+		return fmt.Errorf("unable to listen for dns updates %w", err)
+	}
+	secrets := map[string]string{}
+	for name, k := range s.keys {
+		secrets[name] = k.Secret
+	}
+	s.udp = &dns.Server{PacketConn: pc, Handler: s, TsigSecret: secrets, MsgAcceptFunc: acceptUpdate}
+	s.tcp = &dns.Server{Listener: l, Handler: s, TsigSecret: secrets, MsgAcceptFunc: acceptUpdate}
+	for _, srv := range []*dns.Server{s.udp, s.tcp} {
+		srv := srv
+		go func() {
+			if err := srv.ActivateAndServe(); err != nil {
+				s.log.Errorw("unable to serve dns updates", "error", err)
+			}
+		}()
+	}
+	s.log.Infow("serving dns updates", "address", pc.LocalAddr().String(), "keys", len(s.keys))
+	return nil
+}
+
+// Addr returns the address the listeners are bound to
+func (s *Server) Addr() string {
+	return s.udp.PacketConn.LocalAddr().String()
+}
+
+// Shutdown stops both listeners
+func (s *Server) Shutdown(ctx context.Context) error {
+	return errors.Join(s.udp.ShutdownContext(ctx), s.tcp.ShutdownContext(ctx))
+}
+
+// acceptUpdate accepts requests with the update opcode and a single zone, the default of miekg/dns only accepts queries
+func acceptUpdate(dh dns.Header) dns.MsgAcceptAction {
+	if dh.Bits&(1<<15) != 0 {
+		return dns.MsgIgnore
+	}
+	if int(dh.Bits>>11)&0xF != dns.OpcodeUpdate {
+		return dns.MsgRejectNotImplemented
+	}
+	if dh.Qdcount != 1 {
+		return dns.MsgReject
+	}
+	return dns.MsgAccept
+}
+
+// ServeDNS answers an update, successful responses are signed with the key of the request
+func (s *Server) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), remoteAddrKey{}, w.RemoteAddr().String()), updateTimeout)
+	defer cancel()
+
+	m := new(dns.Msg)
+	m.SetRcode(r, s.update(ctx, w, r))
+	if t := r.IsTsig(); t != nil && w.TsigStatus() == nil {
+		m.SetTsig(t.Hdr.Name, t.Algorithm, fudge, time.Now().Unix())
+	}
+	if err := w.WriteMsg(m); err != nil {
+		s.log.Debugw("unable to write response", "remote", w.RemoteAddr().String(), "error", err)
+	}
+}
+
+// update authenticates, checks and applies r and returns the rcode of the response
+func (s *Server) update(ctx context.Context, w dns.ResponseWriter, r *dns.Msg) int {
+	if r.Opcode != dns.OpcodeUpdate {
+		return dns.RcodeNotImplemented
+	}
+	t := r.IsTsig()
+	if t == nil {
+		s.log.Infow("refused unsigned update", "remote", w.RemoteAddr().String())
+		return dns.RcodeRefused
+	}
+	key, ok := s.keys[dns.CanonicalName(t.Hdr.Name)]
+	if !ok || w.TsigStatus() != nil || !strings.EqualFold(t.Algorithm, key.Algorithm) {
+		s.log.Infow("refused update with invalid signature", "remote", w.RemoteAddr().String(), "key", t.Hdr.Name, "error", w.TsigStatus())
+		return dns.RcodeNotAuth
+	}
+	if len(r.Question) != 1 || r.Question[0].Qtype != dns.TypeSOA || r.Question[0].Qclass != dns.ClassINET {
+		return dns.RcodeFormatError
+	}
+	zone := dns.CanonicalName(r.Question[0].Name)
+
+	jwt, err := token.NewJWTToken(key.Name, auth.TSIGIssuer, key.Domains, key.Permissions, tokenExpiry, s.c.Secret)
+	if err != nil {
+		s.log.Errorw("unable to create token", "key", key.Name, "error", err)
+		return dns.RcodeServerFailure
+	}
+	u := &updater{records: s.records, authz: s.authz, zone: zone, token: jwt}
+
+	rcode, err := u.checkPrerequisites(ctx, r.Answer)
+	if err == nil && rcode == dns.RcodeSuccess {
+		rcode, err = u.apply(ctx, r.Ns)
+	}
+	log := s.log.With("remote", w.RemoteAddr().String(), "key", key.Name, "zone", zone, "rcode", dns.RcodeToString[rcode])
+	if err != nil {
+		log.Infow("update failed", "error", err)
+	} else {
+		log.Infow("update")
+	}
+	return rcode
+}
+
+// updater applies the changes of one update to the records of zone
+type updater struct {
+	records apiv1connect.RecordServiceClient
+	authz   apiv1connect.AuthzServiceClient
+	zone    string
+	token   string
+	// before are the records of the zone before the update
+	before map[rrkey][]*v1.Record
+	// changed are the rrsets which were changed by the update, in order
+	changed []rrkey
+}
+
+// rrkey identifies an rrset
+type rrkey struct {
+	name   string
+	rrtype uint16
+}
+
+// checkPrerequisites evaluates the prerequisite section as described in RFC 2136 3.2
+func (u *updater) checkPrerequisites(ctx context.Context, prereqs []dns.RR) (int, error) {
+	if len(prereqs) == 0 {
+		return dns.RcodeSuccess, nil
+	}
+	for _, rr := range prereqs {
+		h := rr.Header()
+		if h.Ttl != 0 {
+			return dns.RcodeFormatError, fmt.Errorf("prerequisite %s has a ttl", h.Name)
+		}
+		if !dns.IsSubDomain(u.zone, h.Name) {
+			return dns.RcodeNotZone, fmt.Errorf("prerequisite %s is outside of the zone", h.Name)
+		}
+	}
+	existing, err := u.list(ctx, nil, v1.RecordType_ANY)
+	if err != nil {
+		return rcodeOf(err), err
+	}
+
+	expected := rrsets{}
+	for _, rr := range prereqs {
+		h := rr.Header()
+		name := dns.CanonicalName(h.Name)
+		switch h.Class {
+		case dns.ClassANY:
+			if !empty(rr) {
+				return dns.RcodeFormatError, fmt.Errorf("prerequisite %s must not have data", name)
+			}
+			if h.Rrtype == dns.TypeANY && !existing.inUse(name) {
+				return dns.RcodeNameError, fmt.Errorf("%s is not in use", name)
+			}
+			if h.Rrtype != dns.TypeANY && len(existing.get(name, h.Rrtype)) == 0 {
+				return dns.RcodeNXRrset, fmt.Errorf("%s %s does not exist", name, dns.TypeToString[h.Rrtype])
+			}
+		case dns.ClassNONE:
+			if !empty(rr) {
+				return dns.RcodeFormatError, fmt.Errorf("prerequisite %s must not have data", name)
+			}
+			if h.Rrtype == dns.TypeANY && existing.inUse(name) {
+				return dns.RcodeYXDomain, fmt.Errorf("%s is in use", name)
+			}
+			if h.Rrtype != dns.TypeANY && len(existing.get(name, h.Rrtype)) > 0 {
+				return dns.RcodeYXRrset, fmt.Errorf("%s %s exists", name, dns.TypeToString[h.Rrtype])
+			}
+		case dns.ClassINET:
+			expected.add(name, h.Rrtype, rdata(rr))
+		default:
+			return dns.RcodeFormatError, fmt.Errorf("prerequisite %s has unknown class %d", name, h.Class)
+		}
+	}
+	for name, types := range expected {
+		for rrtype, data := range types {
+			if !equal(normalize(name, rrtype, data), normalize(name, rrtype, existing.get(name, rrtype))) {
+				return dns.RcodeNXRrset, fmt.Errorf("%s %s has other values", name, dns.TypeToString[rrtype])
+			}
+		}
+	}
+	return dns.RcodeSuccess, nil
+}
+
+// apply checks all changes of the update section as described in RFC 2136 3.4.1 before they are applied in order.
+// An update is applied completely or not at all, if a change fails the changes before it are undone.
+func (u *updater) apply(ctx context.Context, updates []dns.RR) (int, error) {
+	adds := map[string]bool{}
+	for _, rr := range updates {
+		h := rr.Header()
+		name := dns.CanonicalName(h.Name)
+		if !dns.IsSubDomain(u.zone, name) {
+			return dns.RcodeNotZone, fmt.Errorf("%s is outside of the zone", name)
+		}
+		switch h.Class {
+		case dns.ClassINET:
+			if isMeta(h.Rrtype) || empty(rr) {
+				return dns.RcodeFormatError, fmt.Errorf("%s %s cannot be added", name, dns.TypeToString[h.Rrtype])
+			}
+			// the records of the api have a single value, a second value would replace the first one
+			key := name + " " + dns.TypeToString[h.Rrtype]
+			if adds[key] {
+				return dns.RcodeNotImplemented, fmt.Errorf("%s has more than one value, which is not supported", key)
+			}
+			adds[key] = true
+		case dns.ClassANY:
+			if h.Ttl != 0 || !empty(rr) || (isMeta(h.Rrtype) && h.Rrtype != dns.TypeANY) {
+				return dns.RcodeFormatError, fmt.Errorf("malformed deletion of %s", name)
+			}
+		case dns.ClassNONE:
+			if h.Ttl != 0 || isMeta(h.Rrtype) || empty(rr) {
+				return dns.RcodeFormatError, fmt.Errorf("malformed deletion of %s", name)
+			}
+		default:
+			return dns.RcodeFormatError, fmt.Errorf("%s has unknown class %d", name, h.Class)
+		}
+	}
+
+	if err := u.authorize(ctx, updates); err != nil {
+		return rcodeOf(err), err
+	}
+	before, err := u.snapshot(ctx)
+	if err != nil {
+		return rcodeOf(err), err
+	}
+	u.before = before
+	for _, rr := range updates {
+		h := rr.Header()
+		name := dns.CanonicalName(h.Name)
+		if u.ignored(name, h) {
+			continue
+		}
+		var err error
+		switch {
+		case h.Class == dns.ClassINET:
+			err = u.create(ctx, name, h.Rrtype, h.Ttl, rdata(rr))
+		case h.Class == dns.ClassANY && h.Rrtype == dns.TypeANY:
+			err = u.deleteName(ctx, name)
+		case h.Class == dns.ClassANY:
+			err = u.delete(ctx, name, h.Rrtype)
+		case h.Class == dns.ClassNONE:
+			err = u.deleteValue(ctx, name, h.Rrtype, rdata(rr))
+		}
+		if err != nil {
+			rcode := rcodeOf(err)
+			if undoErr := u.undo(ctx); undoErr != nil {
+				err = fmt.Errorf("%w, unable to undo the changes before %v", err, undoErr)
+			}
+			return rcode, err
+		}
+	}
+	return dns.RcodeSuccess, nil
+}
+
+// ignored returns true for changes of the soa, which is maintained by the backend, and deletions of the ns of the apex, which are never deleted
+func (u *updater) ignored(name string, h *dns.RR_Header) bool {
+	return h.Rrtype == dns.TypeSOA || (name == u.zone && h.Rrtype == dns.TypeNS && h.Class != dns.ClassINET)
+}
+
+// authorize asks the policies about every change before the update is applied, a denied change refuses the whole update
+func (u *updater) authorize(ctx context.Context, updates []dns.RR) error {
+	asked := map[string]bool{}
+	for _, rr := range updates {
+		h := rr.Header()
+		name := dns.CanonicalName(h.Name)
+		if u.ignored(name, h) {
+			continue
+		}
+		method := apiv1connect.RecordServiceDeleteProcedure
+		if h.Class == dns.ClassINET {
+			method = apiv1connect.RecordServiceCreateProcedure
+		}
+		if asked[method+" "+name] {
+			continue
+		}
+		asked[method+" "+name] = true
+		req, err := structpb.NewStruct(map[string]any{"name": name})
+		if err != nil {
+			return err
+		}
+		resp, err := u.authz.Explain(ctx, request(u.token, &v1.AuthzServiceExplainRequest{Method: method, Request: req}))
+		if err != nil {
+			return err
+		}
+		if !resp.Msg.Allow {
+			return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("access denied to %s of %s", method, name))
+		}
+	}
+	return nil
+}
+
+func (u *updater) create(ctx context.Context, name string, rrtype uint16, ttl uint32, data string) error {
+	t, err := recordType(rrtype)
+	if err != nil {
+		return err
+	}
+	_, err = u.records.Create(ctx, request(u.token, &v1.RecordServiceCreateRequest{Name: name, Type: t, Ttl: ttl, Data: data}))
+	if err != nil {
+		return err
+	}
+	u.changed = append(u.changed, rrkey{name: name, rrtype: rrtype})
+	return nil
+}
+
+func (u *updater) delete(ctx context.Context, name string, rrtype uint16) error {
+	t, err := recordType(rrtype)
+	if err != nil {
+		return err
+	}
+	_, err = u.records.Delete(ctx, request(u.token, &v1.RecordServiceDeleteRequest{Name: name, Type: t}))
+	if err != nil {
+		return err
+	}
+	u.changed = append(u.changed, rrkey{name: name, rrtype: rrtype})
+	return nil
+}
+
+// undo restores the rrsets which were changed by the update to their values before the update
+func (u *updater) undo(ctx context.Context) error {
+	// the update may have failed because ctx is done, the remote address is kept for the rate limits and the audit log
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), remoteAddrKey{}, ctx.Value(remoteAddrKey{})), updateTimeout)
+	defer cancel()
+	var (
+		errs     []error
+		restored = map[rrkey]bool{}
+	)
+	for i := len(u.changed) - 1; i >= 0; i-- {
+		k := u.changed[i]
+		if restored[k] {
+			continue
+		}
+		restored[k] = true
+		t, err := recordType(k.rrtype)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records := u.before[k]
+		switch len(records) {
+		case 0:
+			_, err = u.records.Delete(ctx, request(u.token, &v1.RecordServiceDeleteRequest{Name: k.name, Type: t}))
+		case 1:
+			_, err = u.records.Create(ctx, request(u.token, &v1.RecordServiceCreateRequest{Name: k.name, Type: t, Ttl: records[0].Ttl, Data: records[0].Data}))
+		default:
+			err = fmt.Errorf("%s %s has more than one value, which cannot be restored", k.name, t)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deleteName deletes all records of name, except the soa and ns of the apex
+func (u *updater) deleteName(ctx context.Context, name string) error {
+	existing, err := u.list(ctx, &name, v1.RecordType_ANY)
+	if err != nil {
+		return err
+	}
+	for rrtype := range existing[name] {
+		if rrtype == dns.TypeSOA || (name == u.zone && rrtype == dns.TypeNS) {
+			continue
+		}
+		if err := u.delete(ctx, name, rrtype); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteValue deletes the record of name with data, a record with other values is kept
+func (u *updater) deleteValue(ctx context.Context, name string, rrtype uint16, data string) error {
+	t, err := recordType(rrtype)
+	if err != nil {
+		return err
+	}
+	existing, err := u.list(ctx, &name, t)
+	if err != nil {
+		return err
+	}
+	values := normalize(name, rrtype, existing.get(name, rrtype))
+	value := normalize(name, rrtype, []string{data})
+	switch {
+	case equal(values, value):
+		return u.delete(ctx, name, rrtype)
+	case len(values) > 1 && contains(values, value[0]):
+		return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("%s %s has more than one value, which is not supported", name, t))
+	}
+	return nil
+}
+
+// list returns the records of the zone, optionally limited to name and type
+func (u *updater) list(ctx context.Context, name *string, t v1.RecordType) (rrsets, error) {
+	resp, err := u.records.List(ctx, request(u.token, &v1.RecordServiceListRequest{Domain: u.zone, Name: name, Type: t}))
+	if err != nil {
+		return nil, err
+	}
+	result := rrsets{}
+	for _, r := range resp.Msg.Records {
+		rrtype, ok := dns.StringToType[r.Type.String()]
+		if !ok {
+			continue
+		}
+		result.add(dns.CanonicalName(r.Name), rrtype, r.Data)
+	}
+	return result, nil
+}
+
+// snapshot returns the records of the zone by rrset, they are restored if the update fails
+func (u *updater) snapshot(ctx context.Context) (map[rrkey][]*v1.Record, error) {
+	resp, err := u.records.List(ctx, request(u.token, &v1.RecordServiceListRequest{Domain: u.zone, Type: v1.RecordType_ANY}))
+	if err != nil {
+		return nil, err
+	}
+	result := map[rrkey][]*v1.Record{}
+	for _, r := range resp.Msg.Records {
+		rrtype, ok := dns.StringToType[r.Type.String()]
+		if !ok {
+			continue
+		}
+		k := rrkey{name: dns.CanonicalName(r.Name), rrtype: rrtype}
+		result[k] = append(result[k], r)
+	}
+	return result, nil
+}
+
+// request authenticates msg with the token of the key
+func request[T any](token string, msg *T) *connect.Request[T] {
+	req := connect.NewRequest(msg)
+	req.Header().Set("Authorization", "Bearer "+token)
+	return req
+}
+
+// rrsets are the values of records by name and type
+type rrsets map[string]map[uint16][]string
+
+func (s rrsets) add(name string, rrtype uint16, data string) {
+	if s[name] == nil {
+		s[name] = map[uint16][]string{}
+	}
+	s[name][rrtype] = append(s[name][rrtype], data)
+}
+
+func (s rrsets) get(name string, rrtype uint16) []string {
+	return s[name][rrtype]
+}
+
+func (s rrsets) inUse(name string) bool {
+	return len(s[name]) > 0
+}
+
+// empty returns true if rr has no data, which is the case for deletions of a whole name or type
+func empty(rr dns.RR) bool {
+	if _, ok := rr.(*dns.RR_Header); ok {
+		return true
+	}
+	return rdata(rr) == ""
+}
+
+func rdata(rr dns.RR) string {
+	return strings.TrimSpace(strings.TrimPrefix(rr.String(), rr.Header().String()))
+}
+
+func isMeta(rrtype uint16) bool {
+	switch rrtype {
+	case dns.TypeANY, dns.TypeAXFR, dns.TypeIXFR, dns.TypeMAILA, dns.TypeMAILB, dns.TypeOPT, dns.TypeTSIG:
+		return true
+	}
+	return false
+}
+
+func recordType(rrtype uint16) (v1.RecordType, error) {
+	t := client.ToV1RecordType(dns.TypeToString[rrtype])
+	if t == v1.RecordType_UNKNOWN {
+		return t, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("record type %d is not supported", rrtype))
+	}
+	return t, nil
+}
+
+// normalize returns the values in the presentation of miekg/dns, values of the backend may be formatted differently
+func normalize(name string, rrtype uint16, data []string) []string {
+	result, err := propagation.Normalize(name, rrtype, data)
+	if err != nil {
+		return data
+	}
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// rcodeOf maps the error of a call to the RecordService to the rcode of the response
+func rcodeOf(err error) int {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnauthenticated, connect.CodePermissionDenied, connect.CodeResourceExhausted:
+		return dns.RcodeRefused
+	case connect.CodeInvalidArgument:
+		return dns.RcodeFormatError
+	case connect.CodeUnimplemented:
+		return dns.RcodeNotImplemented
+	default:
+		return dns.RcodeServerFailure
+	}
+}
+
+type remoteAddrKey struct{}
+
+// handlerTransport serves the calls of the RecordService client in process, the remote address
+// of the update is passed on to rate limits and the audit log.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	if addr, ok := r.Context().Value(remoteAddrKey{}).(string); ok {
+		r.RemoteAddr = addr
+	}
+	r.RequestURI = r.URL.RequestURI()
+	w := &responseWriter{header: http.Header{}}
+	t.handler.ServeHTTP(w, r)
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", w.status, http.StatusText(w.status)),
+		StatusCode:    w.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        w.header,
+		Body:          io.NopCloser(&w.body),
+		ContentLength: int64(w.body.Len()),
+		Request:       r,
+	}, nil
+}
+
+// responseWriter buffers the response of a call which is served in process
+type responseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
@@ -0,0 +1,272 @@
+package dnsupdate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/service"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const testSecret = "c2VjcmV0LW9mLXRoZS11cGRhdGUta2V5"
+
+func TestLoadKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`
+- name: NSUpdate
+  secret: `+testSecret+`
+  domains: [a.example.com.]
+  permissions: [/api.v1.RecordService/Create]
+- name: dhcp.
+  algorithm: hmac-sha512
+  secret: `+testSecret+`
+`), 0600))
+	keys, err := LoadKeys(valid)
+	require.NoError(t, err)
+	require.Equal(t, []Key{
+		{Name: "nsupdate.", Algorithm: dns.HmacSHA256, Secret: testSecret, Domains: []string{"a.example.com."}, Permissions: []string{"/api.v1.RecordService/Create"}},
+		{Name: "dhcp.", Algorithm: dns.HmacSHA512, Secret: testSecret},
+	}, keys)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown field", content: "- name: a.\n  secret: " + testSecret + "\n  domain: [a.example.com.]\n"},
+		{name: "without name", content: "- secret: " + testSecret + "\n"},
+		{name: "duplicate", content: "- name: a\n  secret: " + testSecret + "\n- name: a.\n  secret: " + testSecret + "\n"},
+		{name: "unsupported algorithm", content: "- name: a.\n  algorithm: hmac-md5\n  secret: " + testSecret + "\n"},
+		{name: "invalid secret", content: "- name: a.\n  secret: not base64!\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "invalid.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			_, err := LoadKeys(path)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	log := zaptest.NewLogger(t).Sugar()
+	authz, err := auth.NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+
+	records := &recordService{records: map[string]*v1.Record{}, fail: "fail.a.example.com."}
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewRecordServiceHandler(records, connect.WithInterceptors(authz)))
+	mux.Handle(apiv1connect.NewAuthzServiceHandler(service.NewAuthzService(log, authz), connect.WithInterceptors(authz)))
+
+	all := []string{
+		apiv1connect.RecordServiceListProcedure,
+		apiv1connect.RecordServiceCreateProcedure,
+		apiv1connect.RecordServiceDeleteProcedure,
+	}
+	s := New(log, mux, Config{
+		Endpoint: "127.0.0.1:0",
+		Secret:   "secret",
+		Keys: []Key{
+			{Name: "nsupdate.", Algorithm: dns.HmacSHA256, Secret: testSecret, Domains: []string{"a.example.com."}, Permissions: all},
+			{Name: "readonly.", Algorithm: dns.HmacSHA256, Secret: testSecret, Domains: []string{"a.example.com."}, Permissions: all[:1]},
+			{Name: "createonly.", Algorithm: dns.HmacSHA256, Secret: testSecret, Domains: []string{"a.example.com."}, Permissions: all[:2]},
+		},
+	})
+	require.NoError(t, s.Start())
+	t.Cleanup(func() {
+		require.NoError(t, s.Shutdown(context.Background()))
+	})
+
+	exchange := func(t *testing.T, net, key string, m *dns.Msg) int {
+		c := &dns.Client{Net: net, TsigSecret: map[string]string{"nsupdate.": testSecret, "readonly.": testSecret, "createonly.": testSecret, "unknown.": testSecret}}
+		if key != "" {
+			m.SetTsig(key, dns.HmacSHA256, 300, 0)
+		}
+		resp, _, err := c.Exchange(m, s.Addr())
+		require.NoError(t, err)
+		return resp.Rcode
+	}
+	update := func(rrs ...string) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetUpdate("a.example.com.")
+		for _, r := range rrs {
+			rr, err := dns.NewRR(r)
+			require.NoError(t, err)
+			m.Insert([]dns.RR{rr})
+		}
+		return m
+	}
+
+	t.Run("add", func(t *testing.T) {
+		rcode := exchange(t, "udp", "nsupdate.", update("www.a.example.com. 300 IN A 1.2.3.4", `_acme-challenge.a.example.com. 60 IN TXT "token"`))
+		require.Equal(t, dns.RcodeSuccess, rcode)
+		www := records.get("www.a.example.com. A")
+		require.Equal(t, "1.2.3.4", www.Data)
+		require.Equal(t, uint32(300), www.Ttl)
+		require.Equal(t, `"token"`, records.get("_acme-challenge.a.example.com. TXT").Data)
+	})
+	t.Run("add over tcp", func(t *testing.T) {
+		rcode := exchange(t, "tcp", "nsupdate.", update("mail.a.example.com. 300 IN A 1.2.3.5"))
+		require.Equal(t, dns.RcodeSuccess, rcode)
+		require.NotNil(t, records.get("mail.a.example.com. A"))
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		require.Equal(t, dns.RcodeRefused, exchange(t, "udp", "", update("x.a.example.com. 300 IN A 1.2.3.4")))
+		require.Nil(t, records.get("x.a.example.com. A"))
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		require.Equal(t, dns.RcodeNotAuth, exchange(t, "udp", "unknown.", update("x.a.example.com. 300 IN A 1.2.3.4")))
+	})
+	t.Run("not permitted", func(t *testing.T) {
+		require.Equal(t, dns.RcodeRefused, exchange(t, "udp", "readonly.", update("x.a.example.com. 300 IN A 1.2.3.4")))
+		require.Nil(t, records.get("x.a.example.com. A"))
+	})
+	t.Run("outside of zone", func(t *testing.T) {
+		require.Equal(t, dns.RcodeNotZone, exchange(t, "udp", "nsupdate.", update("www.b.example.com. 300 IN A 1.2.3.4")))
+	})
+	t.Run("more than one value", func(t *testing.T) {
+		require.Equal(t, dns.RcodeNotImplemented, exchange(t, "udp", "nsupdate.", update("x.a.example.com. 300 IN A 1.2.3.4", "x.a.example.com. 300 IN A 1.2.3.5")))
+		require.Nil(t, records.get("x.a.example.com. A"))
+	})
+	t.Run("prerequisites", func(t *testing.T) {
+		m := update("www.a.example.com. 300 IN A 1.2.3.6")
+		rr, err := dns.NewRR("www.a.example.com. 0 IN A 1.2.3.4")
+		require.NoError(t, err)
+		m.Used([]dns.RR{rr})
+		require.Equal(t, dns.RcodeSuccess, exchange(t, "udp", "nsupdate.", m))
+		require.Equal(t, "1.2.3.6", records.get("www.a.example.com. A").Data)
+
+		// the value changed, the prerequisite fails
+		m = update("www.a.example.com. 300 IN A 1.2.3.7")
+		m.Used([]dns.RR{rr})
+		require.Equal(t, dns.RcodeNXRrset, exchange(t, "udp", "nsupdate.", m))
+		require.Equal(t, "1.2.3.6", records.get("www.a.example.com. A").Data)
+
+		m = update("new.a.example.com. 300 IN A 1.2.3.4")
+		m.NameUsed([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: "www.a.example.com."}}})
+		m.NameNotUsed([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: "new.a.example.com."}}})
+		require.Equal(t, dns.RcodeSuccess, exchange(t, "udp", "nsupdate.", m))
+
+		m = update("new.a.example.com. 300 IN A 1.2.3.5")
+		m.NameNotUsed([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: "new.a.example.com."}}})
+		require.Equal(t, dns.RcodeYXDomain, exchange(t, "udp", "nsupdate.", m))
+	})
+	t.Run("delete", func(t *testing.T) {
+		records.put(&v1.Record{Name: "old.a.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4", Ttl: 300})
+		records.put(&v1.Record{Name: "old.a.example.com.", Type: v1.RecordType_TXT, Data: `"old"`, Ttl: 300})
+
+		// the value does not match, the record is kept
+		m := new(dns.Msg)
+		m.SetUpdate("a.example.com.")
+		rr, err := dns.NewRR("old.a.example.com. 0 IN A 1.2.3.5")
+		require.NoError(t, err)
+		m.Remove([]dns.RR{rr})
+		require.Equal(t, dns.RcodeSuccess, exchange(t, "udp", "nsupdate.", m))
+		require.NotNil(t, records.get("old.a.example.com. A"))
+
+		rr, err = dns.NewRR("old.a.example.com. 0 IN A 1.2.3.4")
+		require.NoError(t, err)
+		m = new(dns.Msg)
+		m.SetUpdate("a.example.com.")
+		m.Remove([]dns.RR{rr})
+		require.Equal(t, dns.RcodeSuccess, exchange(t, "udp", "nsupdate.", m))
+		require.Nil(t, records.get("old.a.example.com. A"))
+		require.NotNil(t, records.get("old.a.example.com. TXT"))
+
+		m = new(dns.Msg)
+		m.SetUpdate("a.example.com.")
+		m.RemoveName([]dns.RR{&dns.ANY{Hdr: dns.RR_Header{Name: "old.a.example.com."}}})
+		require.Equal(t, dns.RcodeSuccess, exchange(t, "udp", "nsupdate.", m))
+		require.Nil(t, records.get("old.a.example.com. A"))
+		require.Nil(t, records.get("old.a.example.com. TXT"))
+	})
+	t.Run("denied change refuses the whole update", func(t *testing.T) {
+		m := update("denied.a.example.com. 300 IN A 1.2.3.4")
+		m.RemoveRRset([]dns.RR{&dns.A{Hdr: dns.RR_Header{Name: "www.a.example.com.", Rrtype: dns.TypeA}}})
+		require.Equal(t, dns.RcodeRefused, exchange(t, "udp", "createonly.", m))
+		require.Nil(t, records.get("denied.a.example.com. A"))
+		require.NotNil(t, records.get("www.a.example.com. A"))
+	})
+	t.Run("failed change undoes the update", func(t *testing.T) {
+		records.put(&v1.Record{Name: "keep.a.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4", Ttl: 600})
+
+		m := new(dns.Msg)
+		m.SetUpdate("a.example.com.")
+		m.RemoveRRset([]dns.RR{&dns.A{Hdr: dns.RR_Header{Name: "keep.a.example.com.", Rrtype: dns.TypeA}}})
+		for _, r := range []string{"undone.a.example.com. 300 IN A 1.2.3.5", "fail.a.example.com. 300 IN A 1.2.3.6"} {
+			rr, err := dns.NewRR(r)
+			require.NoError(t, err)
+			m.Insert([]dns.RR{rr})
+		}
+		require.Equal(t, dns.RcodeServerFailure, exchange(t, "udp", "nsupdate.", m))
+		require.Nil(t, records.get("undone.a.example.com. A"))
+		keep := records.get("keep.a.example.com. A")
+		require.NotNil(t, keep)
+		require.Equal(t, "1.2.3.4", keep.Data)
+		require.Equal(t, uint32(600), keep.Ttl)
+	})
+}
+
+// recordService keeps records with a single value by name and type in memory, creating a record named fail fails
+type recordService struct {
+	apiv1connect.UnimplementedRecordServiceHandler
+	lock    sync.Mutex
+	records map[string]*v1.Record
+	fail    string
+}
+
+func (s *recordService) get(key string) *v1.Record {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.records[key]
+}
+
+func (s *recordService) put(r *v1.Record) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.records[r.Name+" "+r.Type.String()] = r
+}
+
+func (s *recordService) List(ctx context.Context, rq *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	req := rq.Msg
+	resp := &v1.RecordServiceListResponse{}
+	for _, r := range s.records {
+		if (req.Name != nil && *req.Name != r.Name) || (req.Type != v1.RecordType_ANY && req.Type != r.Type) {
+			continue
+		}
+		resp.Records = append(resp.Records, r)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *recordService) Create(ctx context.Context, rq *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
+	req := rq.Msg
+	if req.Name == s.fail {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("backend failed"))
+	}
+	r := &v1.Record{Name: req.Name, Type: req.Type, Data: req.Data, Ttl: req.Ttl}
+	s.put(r)
+	return connect.NewResponse(&v1.RecordServiceCreateResponse{Record: r}), nil
+}
+
+func (s *recordService) Delete(ctx context.Context, rq *connect.Request[v1.RecordServiceDeleteRequest]) (*connect.Response[v1.RecordServiceDeleteResponse], error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.records, rq.Msg.Name+" "+rq.Msg.Type.String())
+	return connect.NewResponse(&v1.RecordServiceDeleteResponse{}), nil
+}
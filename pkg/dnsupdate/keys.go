@@ -0,0 +1,59 @@
+package dnsupdate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/miekg/dns"
+	"gopkg.in/yaml.v3"
+)
+
+// Key grants domains and permissions to the updates signed with a TSIG key, like a token
+type Key struct {
+	// Name of the key, e.g. nsupdate. it is fully qualified if the trailing dot is missing
+	Name string `yaml:"name"`
+	// Algorithm of the key, e.g. hmac-sha256, which is the default
+	Algorithm string `yaml:"algorithm"`
+	// Secret is the base64 encoded secret as written by tsig-keygen
+	Secret      string   `yaml:"secret"`
+	Domains     []string `yaml:"domains"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// LoadKeys reads a list of Key from a yaml file at path, names and algorithms are returned in canonical form
+func LoadKeys(path string) ([]Key, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read tsig keys %w", err)
+	}
+	var keys []Key
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	err = dec.Decode(&keys)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse tsig keys in %s %w", path, err)
+	}
+	names := map[string]bool{}
+	for i := range keys {
+		k := &keys[i]
+		if k.Name == "" {
+			return nil, fmt.Errorf("tsig key in %s without name", path)
+		}
+		k.Name = dns.CanonicalName(k.Name)
+		if names[k.Name] {
+			return nil, fmt.Errorf("tsig key %s is defined twice in %s", k.Name, path)
+		}
+		names[k.Name] = true
+		k.Algorithm, err = backend.TSIGAlgorithm(k.Algorithm)
+		if err != nil {
+			return nil, fmt.Errorf("tsig key %s %w", k.Name, err)
+		}
+		if _, err := base64.StdEncoding.DecodeString(k.Secret); err != nil || k.Secret == "" {
+			return nil, fmt.Errorf("tsig key %s must have a base64 encoded secret", k.Name)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,197 @@
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"go.uber.org/zap"
+)
+
+// DefaultBufferSize is the number of events which are kept to resume watches
+const DefaultBufferSize = 1000
+
+var (
+	// ErrTokenExpired is returned if the events after a resume token are no longer buffered,
+	// e.g. because the server restarted, the watcher must list again
+	ErrTokenExpired = errors.New("resume token expired, list and watch again")
+	// ErrSlowSubscriber closes a subscription which did not keep up with the published events
+	ErrSlowSubscriber = errors.New("watcher did not keep up with the events, resume the watch")
+)
+
+// Event is a change of a domain or of a record
+type Event struct {
+	Seq  uint64
+	Time time.Time
+	Type v1.EventType
+	// Zone the change belongs to
+	Zone string
+	// Domain is set for changes of domains, Record for changes of records
+	Domain *v1.Domain
+	Record *v1.Record
+}
+
+// Bus distributes the events published by the mutating handlers to the watchers.
+// The last events are buffered, a watcher which reconnects with a resume token gets the events it missed.
+// The buffer is kept in memory, resume tokens of another instance or of a previous run are expired.
+type Bus struct {
+	log   *zap.SugaredLogger
+	epoch string
+	size  int
+
+	lock        sync.Mutex
+	seq         uint64
+	buffer      []Event
+	subscribers map[*Subscription]bool
+}
+
+// NewBus creates a Bus which buffers size events
+func NewBus(log *zap.SugaredLogger, size int) *Bus {
+	if size <= 0 {
+		size = DefaultBufferSize
+	}
+	epoch := make([]byte, 4)
+	_, _ = rand.Read(epoch)
+	return &Bus{
+		log:         log.Named("events"),
+		epoch:       hex.EncodeToString(epoch),
+		size:        size,
+		subscribers: map[*Subscription]bool{},
+	}
+}
+
+// Publish assigns a sequence number to the events and sends them to all subscribers, a nil Bus discards them
+func (b *Bus) Publish(events ...Event) {
+	if b == nil {
+		return
+	}
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	now := time.Now()
+	for _, e := range events {
+		b.seq++
+		e.Seq = b.seq
+		e.Time = now
+		b.buffer = append(b.buffer, e)
+		if len(b.buffer) > b.size {
+			b.buffer = b.buffer[len(b.buffer)-b.size:]
+		}
+		for s := range b.subscribers {
+			select {
+			case s.c <- e:
+			default:
+				b.log.Warnw("closing slow subscription", "seq", e.Seq)
+				b.close(s, ErrSlowSubscriber)
+			}
+		}
+	}
+}
+
+// Subscribe returns a subscription which receives all events after resumeToken, or all future events if resumeToken is empty
+func (b *Bus) Subscribe(resumeToken string) (*Subscription, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var (
+		backlog []Event
+		seq     = b.seq
+		err     error
+	)
+	if resumeToken != "" {
+		seq, err = b.parseToken(resumeToken)
+		if err != nil {
+			return nil, err
+		}
+		if seq > b.seq {
+			return nil, ErrTokenExpired
+		}
+		// the event after the token must still be buffered
+		if seq < b.seq && (len(b.buffer) == 0 || b.buffer[0].Seq > seq+1) {
+			return nil, ErrTokenExpired
+		}
+		for _, e := range b.buffer {
+			if e.Seq > seq {
+				backlog = append(backlog, e)
+			}
+		}
+	}
+
+	s := &Subscription{
+		bus:   b,
+		c:     make(chan Event, b.size),
+		token: b.Token(seq),
+	}
+	for _, e := range backlog {
+		s.c <- e
+	}
+	b.subscribers[s] = true
+	return s, nil
+}
+
+// Token returns the resume token which continues after the event with seq
+func (b *Bus) Token(seq uint64) string {
+	return b.epoch + "." + strconv.FormatUint(seq, 10)
+}
+
+func (b *Bus) parseToken(token string) (uint64, error) {
+	epoch, seq, ok := strings.Cut(token, ".")
+	if !ok {
+		return 0, fmt.Errorf("malformed resume token %q", token)
+	}
+	n, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("malformed resume token %q", token)
+	}
+	if epoch != b.epoch {
+		return 0, ErrTokenExpired
+	}
+	return n, nil
+}
+
+// close must be called with the lock held
+func (b *Bus) close(s *Subscription, err error) {
+	if !b.subscribers[s] {
+		return
+	}
+	delete(b.subscribers, s)
+	s.err = err
+	close(s.c)
+}
+
+// Subscription receives the events of a Bus
+type Subscription struct {
+	bus   *Bus
+	c     chan Event
+	token string
+	err   error
+}
+
+// Events is closed if the subscription is closed, Err tells why
+func (s *Subscription) Events() <-chan Event {
+	return s.c
+}
+
+// Token is the resume token of the position the subscription started at, the events of the backlog follow it
+func (s *Subscription) Token() string {
+	return s.token
+}
+
+// Err returns why the events channel was closed
+func (s *Subscription) Err() error {
+	s.bus.lock.Lock()
+	defer s.bus.lock.Unlock()
+	return s.err
+}
+
+// Close stops the delivery of events
+func (s *Subscription) Close() {
+	s.bus.lock.Lock()
+	defer s.bus.lock.Unlock()
+	s.bus.close(s, nil)
+}
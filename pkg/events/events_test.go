@@ -0,0 +1,104 @@
+package events
+
+import (
+	"testing"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus(zaptest.NewLogger(t).Sugar(), 3)
+
+	record := func(name string) Event {
+		return Event{Type: v1.EventType_EVENT_TYPE_CREATED, Zone: "example.com.", Record: &v1.Record{Name: name}}
+	}
+	names := func(events []Event) []string {
+		var result []string
+		for _, e := range events {
+			result = append(result, e.Record.Name)
+		}
+		return result
+	}
+	receive := func(s *Subscription, n int) []Event {
+		var result []Event
+		for i := 0; i < n; i++ {
+			result = append(result, <-s.Events())
+		}
+		require.Empty(t, s.Events())
+		return result
+	}
+
+	// events without subscribers are buffered
+	bus.Publish(record("a"))
+
+	live, err := bus.Subscribe("")
+	require.NoError(t, err)
+	require.Equal(t, bus.Token(1), live.Token())
+
+	bus.Publish(record("b"), record("c"))
+	received := receive(live, 2)
+	require.Equal(t, []string{"b", "c"}, names(received))
+	require.Equal(t, uint64(3), received[1].Seq)
+	require.False(t, received[1].Time.IsZero())
+
+	t.Run("resume", func(t *testing.T) {
+		s, err := bus.Subscribe(bus.Token(1))
+		require.NoError(t, err)
+		defer s.Close()
+		require.Equal(t, bus.Token(1), s.Token())
+		require.Equal(t, []string{"b", "c"}, names(receive(s, 2)))
+	})
+
+	t.Run("resume at the end", func(t *testing.T) {
+		s, err := bus.Subscribe(bus.Token(3))
+		require.NoError(t, err)
+		defer s.Close()
+		require.Empty(t, s.Events())
+	})
+
+	bus.Publish(record("d"), record("e"))
+	require.Equal(t, []string{"d", "e"}, names(receive(live, 2)))
+
+	t.Run("expired", func(t *testing.T) {
+		// the buffer holds c, d and e
+		_, err := bus.Subscribe(bus.Token(1))
+		require.ErrorIs(t, err, ErrTokenExpired)
+
+		s, err := bus.Subscribe(bus.Token(2))
+		require.NoError(t, err)
+		defer s.Close()
+		require.Equal(t, []string{"c", "d", "e"}, names(receive(s, 3)))
+
+		_, err = bus.Subscribe(bus.Token(6))
+		require.ErrorIs(t, err, ErrTokenExpired)
+
+		other := NewBus(zaptest.NewLogger(t).Sugar(), 3)
+		_, err = bus.Subscribe(other.Token(3))
+		require.ErrorIs(t, err, ErrTokenExpired)
+
+		_, err = bus.Subscribe("garbage")
+		require.EqualError(t, err, `malformed resume token "garbage"`)
+	})
+
+	live.Close()
+	_, ok := <-live.Events()
+	require.False(t, ok)
+	require.NoError(t, live.Err())
+
+	t.Run("slow subscriber", func(t *testing.T) {
+		s, err := bus.Subscribe("")
+		require.NoError(t, err)
+		bus.Publish(record("f"), record("g"), record("h"), record("i"))
+		require.Equal(t, []string{"f", "g", "h"}, names([]Event{<-s.Events(), <-s.Events(), <-s.Events()}))
+		_, ok := <-s.Events()
+		require.False(t, ok)
+		require.ErrorIs(t, s.Err(), ErrSlowSubscriber)
+		// closing again is a noop
+		s.Close()
+	})
+
+	var nilBus *Bus
+	nilBus.Publish(record("x"))
+}
@@ -0,0 +1,336 @@
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// Prefix is the path prefix of all rest routes
+	Prefix = "/v1/"
+	// OpenAPIPath serves the OpenAPI document of all routes
+	OpenAPIPath = Prefix + "openapi.json"
+
+	maxBodySize = 1 << 20
+)
+
+// route maps a rest route onto a procedure of the api
+type route struct {
+	method    string
+	pattern   string
+	procedure string
+	summary   string
+	request   proto.Message
+	response  proto.Message
+	// within is a path parameter the name of the request must be a subdomain of,
+	// all other path parameters are fields of the request
+	within string
+}
+
+var routes = []route{
+	{
+		method: http.MethodGet, pattern: "/v1/domains", procedure: apiv1connect.DomainServiceListProcedure,
+		summary: "List domains", request: &v1.DomainServiceListRequest{}, response: &v1.DomainServiceListResponse{},
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/domains", procedure: apiv1connect.DomainServiceCreateProcedure,
+		summary: "Create a domain", request: &v1.DomainServiceCreateRequest{}, response: &v1.DomainServiceCreateResponse{},
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/domains/{name}", procedure: apiv1connect.DomainServiceGetProcedure,
+		summary: "Get a domain", request: &v1.DomainServiceGetRequest{}, response: &v1.DomainServiceGetResponse{},
+	},
+	{
+		method: http.MethodPut, pattern: "/v1/domains/{name}", procedure: apiv1connect.DomainServiceUpdateProcedure,
+		summary: "Update a domain", request: &v1.DomainServiceUpdateRequest{}, response: &v1.DomainServiceUpdateResponse{},
+	},
+	{
+		method: http.MethodDelete, pattern: "/v1/domains/{name}", procedure: apiv1connect.DomainServiceDeleteProcedure,
+		summary: "Delete a domain", request: &v1.DomainServiceDeleteRequest{}, response: &v1.DomainServiceDeleteResponse{},
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/domains/{domain}/records", procedure: apiv1connect.RecordServiceListProcedure,
+		summary: "List the records of a domain", request: &v1.RecordServiceListRequest{}, response: &v1.RecordServiceListResponse{},
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/domains/{domain}/records", procedure: apiv1connect.RecordServiceCreateProcedure,
+		summary: "Create a record", request: &v1.RecordServiceCreateRequest{}, response: &v1.RecordServiceCreateResponse{},
+		within: "domain",
+	},
+	{
+		method: http.MethodPut, pattern: "/v1/domains/{domain}/records", procedure: apiv1connect.RecordServiceUpdateProcedure,
+		summary: "Update a record", request: &v1.RecordServiceUpdateRequest{}, response: &v1.RecordServiceUpdateResponse{},
+		within: "domain",
+	},
+	{
+		method: http.MethodDelete, pattern: "/v1/domains/{domain}/records", procedure: apiv1connect.RecordServiceDeleteProcedure,
+		summary: "Delete a record", request: &v1.RecordServiceDeleteRequest{}, response: &v1.RecordServiceDeleteResponse{},
+		within: "domain",
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/domains/{domain}/records/verify", procedure: apiv1connect.RecordServiceVerifyProcedure,
+		summary: "Verify the propagation of a rrset", request: &v1.RecordServiceVerifyRequest{}, response: &v1.RecordServiceVerifyResponse{},
+		within: "domain",
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/challenges", procedure: apiv1connect.ChallengeServicePresentProcedure,
+		summary: "Present an ACME DNS-01 challenge", request: &v1.ChallengeServicePresentRequest{}, response: &v1.ChallengeServicePresentResponse{},
+	},
+	{
+		method: http.MethodDelete, pattern: "/v1/challenges", procedure: apiv1connect.ChallengeServiceCleanUpProcedure,
+		summary: "Clean up an ACME DNS-01 challenge", request: &v1.ChallengeServiceCleanUpRequest{}, response: &v1.ChallengeServiceCleanUpResponse{},
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/webhooks", procedure: apiv1connect.WebhookServiceListProcedure,
+		summary: "List the webhooks", request: &v1.WebhookServiceListRequest{}, response: &v1.WebhookServiceListResponse{},
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/webhooks", procedure: apiv1connect.WebhookServiceCreateProcedure,
+		summary: "Create a webhook", request: &v1.WebhookServiceCreateRequest{}, response: &v1.WebhookServiceCreateResponse{},
+	},
+	{
+		method: http.MethodGet, pattern: "/v1/webhooks/{id}", procedure: apiv1connect.WebhookServiceGetProcedure,
+		summary: "Get a webhook", request: &v1.WebhookServiceGetRequest{}, response: &v1.WebhookServiceGetResponse{},
+	},
+	{
+		method: http.MethodPut, pattern: "/v1/webhooks/{id}", procedure: apiv1connect.WebhookServiceUpdateProcedure,
+		summary: "Update a webhook", request: &v1.WebhookServiceUpdateRequest{}, response: &v1.WebhookServiceUpdateResponse{},
+	},
+	{
+		method: http.MethodDelete, pattern: "/v1/webhooks/{id}", procedure: apiv1connect.WebhookServiceDeleteProcedure,
+		summary: "Delete a webhook", request: &v1.WebhookServiceDeleteRequest{}, response: &v1.WebhookServiceDeleteResponse{},
+	},
+	{
+		method: http.MethodPost, pattern: "/v1/tokens", procedure: apiv1connect.TokenServiceCreateProcedure,
+		summary: "Create a token", request: &v1.TokenServiceCreateRequest{}, response: &v1.TokenServiceCreateResponse{},
+	},
+}
+
+// Gateway translates rest calls into connect calls with json encoding, which are served by next.
+// Authorization, rate limits and all other interceptors apply to them like to every other call.
+type Gateway struct {
+	log     *zap.SugaredLogger
+	next    http.Handler
+	openAPI []byte
+}
+
+// New creates a Gateway, next must serve the connect handlers of the api
+func New(log *zap.SugaredLogger, next http.Handler) (*Gateway, error) {
+	openAPI, err := json.MarshalIndent(openAPIDocument(routes), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create openapi document %w", err)
+	}
+	return &Gateway{
+		log:     log.Named("gateway"),
+		next:    next,
+		openAPI: openAPI,
+	}, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == OpenAPIPath {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(g.openAPI)
+		return
+	}
+
+	rt, params, allowed := match(r.Method, r.URL.Path)
+	if rt == nil {
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			writeError(w, http.StatusMethodNotAllowed, connect.CodeUnimplemented, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		writeError(w, http.StatusNotFound, connect.CodeNotFound, fmt.Errorf("no route for %s", r.URL.Path))
+		return
+	}
+
+	body, err := rt.requestBody(r, params)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, connect.CodeInvalidArgument, err)
+		return
+	}
+	g.log.Debugw("call", "method", r.Method, "path", r.URL.Path, "procedure", rt.procedure)
+
+	// the original request is cloned to keep its context, tls state and remote address
+	call := r.Clone(r.Context())
+	call.Method = http.MethodPost
+	call.URL.Path = rt.procedure
+	call.URL.RawPath = ""
+	call.URL.RawQuery = ""
+	call.RequestURI = rt.procedure
+	call.Body = io.NopCloser(bytes.NewReader(body))
+	call.ContentLength = int64(len(body))
+	call.Header.Del("Content-Length")
+	call.Header.Set("Content-Type", "application/json")
+	call.Header.Set("Connect-Protocol-Version", "1")
+	g.next.ServeHTTP(w, call)
+}
+
+// match returns the route of method and path together with the path parameters,
+// if only the method does not match, the allowed methods of the path are returned
+func match(method, path string) (*route, map[string]string, []string) {
+	var allowed []string
+	for i := range routes {
+		rt := &routes[i]
+		params, ok := matchPattern(rt.pattern, path)
+		if !ok {
+			continue
+		}
+		if rt.method != method {
+			allowed = append(allowed, rt.method)
+			continue
+		}
+		return rt, params, nil
+	}
+	return nil, nil, allowed
+}
+
+func matchPattern(pattern, path string) (map[string]string, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+	params := map[string]string{}
+	for i, p := range patternParts {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			if pathParts[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(p, "{}")] = pathParts[i]
+			continue
+		}
+		if p != pathParts[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// requestBody merges the json body, the path parameters and the query parameters into the json encoded request.
+// The request is validated strictly, unknown fields are rejected.
+func (rt *route) requestBody(r *http.Request, params map[string]string) ([]byte, error) {
+	fields := map[string]any{}
+	if r.Body != nil && r.ContentLength != 0 {
+		data, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read body %w", err)
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := json.Unmarshal(data, &fields); err != nil {
+				return nil, fmt.Errorf("body must be a json object %w", err)
+			}
+		}
+	}
+
+	desc := rt.request.ProtoReflect().Descriptor()
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fd := fieldByName(desc, k)
+		if fd == nil {
+			return nil, fmt.Errorf("unknown query parameter %q", k)
+		}
+		value, err := queryValue(fd, query[k])
+		if err != nil {
+			return nil, fmt.Errorf("query parameter %q %w", k, err)
+		}
+		fields[fd.JSONName()] = value
+	}
+
+	for k, v := range params {
+		if k == rt.within {
+			continue
+		}
+		fd := fieldByName(desc, k)
+		if fd == nil {
+			return nil, fmt.Errorf("unknown path parameter %q", k)
+		}
+		if existing, ok := fields[fd.JSONName()]; ok && existing != v {
+			return nil, fmt.Errorf("%s %q does not match the path %q", k, existing, v)
+		}
+		fields[fd.JSONName()] = v
+	}
+
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	msg := rt.request.ProtoReflect().New().Interface()
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return nil, fmt.Errorf("invalid request %w", err)
+	}
+
+	if rt.within != "" {
+		domain := params[rt.within]
+		name := msg.ProtoReflect().Get(desc.Fields().ByName("name")).String()
+		if !dns.IsSubDomain(dns.Fqdn(domain), dns.Fqdn(name)) {
+			return nil, fmt.Errorf("name %q is not within %s %q", name, rt.within, domain)
+		}
+	}
+	return body, nil
+}
+
+func fieldByName(desc protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	if fd := desc.Fields().ByJSONName(name); fd != nil {
+		return fd
+	}
+	return desc.Fields().ByName(protoreflect.Name(name))
+}
+
+// queryValue converts the values of a query parameter into the json representation of fd,
+// numbers and enums are accepted as strings by protojson
+func queryValue(fd protoreflect.FieldDescriptor, values []string) (any, error) {
+	convert := func(s string) (any, error) {
+		if fd.Kind() == protoreflect.BoolKind {
+			return strconv.ParseBool(s)
+		}
+		if fd.Kind() == protoreflect.MessageKind {
+			return nil, errors.New("is not supported as query parameter")
+		}
+		return s, nil
+	}
+	if !fd.IsList() {
+		return convert(values[len(values)-1])
+	}
+	var list []any
+	for _, value := range values {
+		// repeated values can be given multiple times or comma separated
+		for _, v := range strings.Split(value, ",") {
+			c, err := convert(v)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, c)
+		}
+	}
+	return list, nil
+}
+
+// writeError writes err in the json format of connect errors
+func writeError(w http.ResponseWriter, status int, code connect.Code, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"code": code.String(), "message": err.Error()})
+}
@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type domainService struct {
+	apiv1connect.UnimplementedDomainServiceHandler
+}
+
+func (d *domainService) Get(_ context.Context, req *connect.Request[v1.DomainServiceGetRequest]) (*connect.Response[v1.DomainServiceGetResponse], error) {
+	return connect.NewResponse(&v1.DomainServiceGetResponse{Domain: &v1.Domain{Id: req.Msg.Name, Name: req.Msg.Name}}), nil
+}
+
+type recordService struct {
+	apiv1connect.UnimplementedRecordServiceHandler
+	listed  *v1.RecordServiceListRequest
+	created *v1.RecordServiceCreateRequest
+}
+
+func (r *recordService) List(_ context.Context, req *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
+	r.listed = req.Msg
+	return connect.NewResponse(&v1.RecordServiceListResponse{Records: []*v1.Record{{Type: v1.RecordType_A, Name: "www.a.example.com.", Data: "1.2.3.4", Ttl: 300}}}), nil
+}
+
+func (r *recordService) Create(_ context.Context, req *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
+	r.created = req.Msg
+	return connect.NewResponse(&v1.RecordServiceCreateResponse{Record: &v1.Record{Type: req.Msg.Type, Name: req.Msg.Name, Data: req.Msg.Data, Ttl: req.Msg.Ttl}}), nil
+}
+
+func TestGateway(t *testing.T) {
+	log := zaptest.NewLogger(t).Sugar()
+	authz, err := auth.NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+	interceptors := connect.WithInterceptors(authz)
+
+	records := &recordService{}
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewDomainServiceHandler(&domainService{}, interceptors))
+	mux.Handle(apiv1connect.NewRecordServiceHandler(records, interceptors))
+	gw, err := New(log, mux)
+	require.NoError(t, err)
+	mux.Handle(Prefix, gw)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	jwtToken, err := token.NewJWTToken("metal-dns", "Tester", []string{"a.example.com."}, []string{
+		apiv1connect.DomainServiceGetProcedure,
+		apiv1connect.RecordServiceListProcedure,
+		apiv1connect.RecordServiceCreateProcedure,
+	}, time.Hour, "secret")
+	require.NoError(t, err)
+
+	call := func(method, path, body, jwtToken string) (int, string) {
+		var r io.Reader
+		if body != "" {
+			r = strings.NewReader(body)
+		}
+		req, err := http.NewRequest(method, server.URL+path, r)
+		require.NoError(t, err)
+		if jwtToken != "" {
+			req.Header.Set("Authorization", "Bearer "+jwtToken)
+		}
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(data)
+	}
+
+	t.Run("get domain", func(t *testing.T) {
+		code, body := call(http.MethodGet, "/v1/domains/a.example.com.", "", jwtToken)
+		require.Equal(t, http.StatusOK, code, body)
+		require.JSONEq(t, `{"domain":{"id":"a.example.com.","name":"a.example.com."}}`, body)
+	})
+	t.Run("get domain without token", func(t *testing.T) {
+		code, body := call(http.MethodGet, "/v1/domains/a.example.com.", "", "")
+		require.Equal(t, http.StatusUnauthorized, code, body)
+		require.Contains(t, body, `"code":"unauthenticated"`)
+	})
+	t.Run("get domain of other token", func(t *testing.T) {
+		code, body := call(http.MethodGet, "/v1/domains/b.example.com.", "", jwtToken)
+		require.Equal(t, http.StatusUnauthorized, code, body)
+	})
+	t.Run("list records", func(t *testing.T) {
+		code, body := call(http.MethodGet, "/v1/domains/a.example.com./records?type=A&name=www.a.example.com.", "", jwtToken)
+		require.Equal(t, http.StatusOK, code, body)
+		require.JSONEq(t, `{"records":[{"type":"A","name":"www.a.example.com.","data":"1.2.3.4","ttl":300}]}`, body)
+		require.Equal(t, "a.example.com.", records.listed.Domain)
+		require.Equal(t, v1.RecordType_A, records.listed.Type)
+		require.Equal(t, "www.a.example.com.", records.listed.GetName())
+	})
+	t.Run("list records with unknown query parameter", func(t *testing.T) {
+		code, body := call(http.MethodGet, "/v1/domains/a.example.com./records?kind=A", "", jwtToken)
+		require.Equal(t, http.StatusBadRequest, code, body)
+		require.Contains(t, body, `unknown query parameter \"kind\"`)
+	})
+	t.Run("create record", func(t *testing.T) {
+		code, body := call(http.MethodPost, "/v1/domains/a.example.com./records", `{"type":"A","name":"www.a.example.com.","data":"1.2.3.5","ttl":"600"}`, jwtToken)
+		require.Equal(t, http.StatusOK, code, body)
+		require.Equal(t, uint32(600), records.created.Ttl)
+		require.Equal(t, "1.2.3.5", records.created.Data)
+	})
+	t.Run("create record outside of domain", func(t *testing.T) {
+		code, body := call(http.MethodPost, "/v1/domains/a.example.com./records", `{"type":"A","name":"www.b.example.com.","data":"1.2.3.5"}`, jwtToken)
+		require.Equal(t, http.StatusBadRequest, code, body)
+		require.Contains(t, body, "is not within domain")
+	})
+	t.Run("create record with invalid body", func(t *testing.T) {
+		code, body := call(http.MethodPost, "/v1/domains/a.example.com./records", `{"type":"A","name":"www.a.example.com.","content":"1.2.3.5"}`, jwtToken)
+		require.Equal(t, http.StatusBadRequest, code, body)
+		code, body = call(http.MethodPost, "/v1/domains/a.example.com./records", `[]`, jwtToken)
+		require.Equal(t, http.StatusBadRequest, code, body)
+	})
+	t.Run("unknown route", func(t *testing.T) {
+		code, body := call(http.MethodGet, "/v1/zones", "", jwtToken)
+		require.Equal(t, http.StatusNotFound, code, body)
+	})
+	t.Run("method not allowed", func(t *testing.T) {
+		code, body := call(http.MethodPatch, "/v1/domains/a.example.com.", "{}", jwtToken)
+		require.Equal(t, http.StatusMethodNotAllowed, code, body)
+	})
+	t.Run("openapi", func(t *testing.T) {
+		code, body := call(http.MethodGet, OpenAPIPath, "", "")
+		require.Equal(t, http.StatusOK, code, body)
+		var doc struct {
+			OpenAPI string                                `json:"openapi"`
+			Paths   map[string]map[string]json.RawMessage `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &doc))
+		require.Equal(t, "3.0.3", doc.OpenAPI)
+		for _, rt := range routes {
+			require.Contains(t, doc.Paths[rt.pattern], strings.ToLower(rt.method), rt.pattern)
+		}
+	})
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	doc := openAPIDocument(routes)
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	require.Contains(t, schemas, "Record")
+	require.Contains(t, schemas, "TokenServiceCreateRequest")
+
+	record := schemas["Record"].(map[string]any)["properties"].(map[string]any)
+	require.Equal(t, map[string]any{"type": "integer", "format": "int64", "minimum": 0}, record["ttl"])
+	require.Contains(t, record["type"].(map[string]any)["enum"], "AAAA")
+
+	token := schemas["TokenServiceCreateRequest"].(map[string]any)["properties"].(map[string]any)
+	require.Equal(t, map[string]any{"type": "string", "example": "3600s"}, token["expires"])
+
+	list := doc["paths"].(map[string]any)["/v1/domains/{domain}/records"].(map[string]any)["get"].(map[string]any)
+	require.Equal(t, "RecordService.List", list["operationId"])
+	var names []string
+	for _, p := range list["parameters"].([]any) {
+		names = append(names, p.(map[string]any)["name"].(string))
+	}
+	require.Equal(t, []string{"domain", "type", "name"}, names)
+}
@@ -0,0 +1,184 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/metal-stack/v"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// openAPIDocument describes routes as OpenAPI 3.0 document, the schemas are derived from the protobuf messages
+func openAPIDocument(routes []route) map[string]any {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"code":    map[string]any{"type": "string", "description": "connect error code, e.g. not_found"},
+				"message": map[string]any{"type": "string"},
+			},
+		},
+	}
+	paths := map[string]any{}
+	for _, rt := range routes {
+		reqDesc := rt.request.ProtoReflect().Descriptor()
+		respDesc := rt.response.ProtoReflect().Descriptor()
+		addSchema(schemas, respDesc)
+
+		var parameters []any
+		pathParams := map[string]bool{}
+		for _, part := range strings.Split(rt.pattern, "/") {
+			if !strings.HasPrefix(part, "{") {
+				continue
+			}
+			name := strings.Trim(part, "{}")
+			pathParams[name] = true
+			parameters = append(parameters, map[string]any{
+				"name": name, "in": "path", "required": true, "schema": map[string]any{"type": "string"},
+			})
+		}
+
+		op := map[string]any{
+			"operationId": operationID(rt.procedure),
+			"summary":     rt.summary,
+			"tags":        []string{serviceName(rt.procedure)},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OK",
+					"content":     map[string]any{"application/json": map[string]any{"schema": ref(respDesc)}},
+				},
+				"default": map[string]any{
+					"description": "Error",
+					"content":     map[string]any{"application/json": map[string]any{"schema": map[string]any{"$ref": "#/components/schemas/Error"}}},
+				},
+			},
+		}
+
+		switch rt.method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+			addSchema(schemas, reqDesc)
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": ref(reqDesc)}},
+			}
+		default:
+			fields := reqDesc.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				if pathParams[string(fd.Name())] || fd.Kind() == protoreflect.MessageKind {
+					continue
+				}
+				parameters = append(parameters, map[string]any{
+					"name": fd.JSONName(), "in": "query", "schema": fieldSchema(schemas, fd),
+				})
+			}
+		}
+		if len(parameters) > 0 {
+			op["parameters"] = parameters
+		}
+
+		item, ok := paths[rt.pattern].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			paths[rt.pattern] = item
+		}
+		item[strings.ToLower(rt.method)] = op
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":       "metal-dns",
+			"description": "rest api of metal-dns, every route is served by the connect procedure given in the operationId",
+			"version":     v.Version,
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas": schemas,
+			"securitySchemes": map[string]any{
+				"bearerAuth": map[string]any{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+		"security": []any{map[string]any{"bearerAuth": []string{}}},
+	}
+}
+
+// operationID is the procedure without the package, e.g. RecordService.List
+func operationID(procedure string) string {
+	parts := strings.Split(strings.TrimPrefix(procedure, "/"), "/")
+	service := parts[0][strings.LastIndex(parts[0], ".")+1:]
+	return service + "." + parts[1]
+}
+
+func serviceName(procedure string) string {
+	return strings.Split(operationID(procedure), ".")[0]
+}
+
+func ref(desc protoreflect.MessageDescriptor) map[string]any {
+	return map[string]any{"$ref": "#/components/schemas/" + string(desc.Name())}
+}
+
+// addSchema adds the schema of desc and all messages it references to schemas
+func addSchema(schemas map[string]any, desc protoreflect.MessageDescriptor) {
+	name := string(desc.Name())
+	if _, ok := schemas[name]; ok {
+		return
+	}
+	properties := map[string]any{}
+	schema := map[string]any{"type": "object", "properties": properties}
+	// added before the fields to stop recursion
+	schemas[name] = schema
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(schemas, fd)
+	}
+}
+
+func fieldSchema(schemas map[string]any, fd protoreflect.FieldDescriptor) map[string]any {
+	var schema map[string]any
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		schema = map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		schema = map[string]any{"type": "integer", "format": "int64", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson encodes 64 bit integers as strings
+		schema = map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		schema = map[string]any{"type": "number"}
+	case protoreflect.BytesKind:
+		schema = map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		var values []string
+		enumValues := fd.Enum().Values()
+		for i := 0; i < enumValues.Len(); i++ {
+			values = append(values, string(enumValues.Get(i).Name()))
+		}
+		schema = map[string]any{"type": "string", "enum": values}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		schema = messageSchema(schemas, fd.Message())
+	default:
+		schema = map[string]any{"type": "string"}
+	}
+	if fd.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+// messageSchema returns the json representation of the well known types or a reference to the schema of desc
+func messageSchema(schemas map[string]any, desc protoreflect.MessageDescriptor) map[string]any {
+	switch desc.FullName() {
+	case "google.protobuf.Duration":
+		return map[string]any{"type": "string", "example": "3600s"}
+	case "google.protobuf.Timestamp":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "google.protobuf.Struct":
+		return map[string]any{"type": "object", "additionalProperties": true}
+	}
+	addSchema(schemas, desc)
+	return ref(desc)
+}
@@ -0,0 +1,171 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	grpchealth "github.com/bufbuild/connect-grpchealth-go"
+	"go.uber.org/zap"
+)
+
+var errNotProbed = errors.New("not probed yet")
+
+// Probe returns an error if the dependency it checks is not usable
+type Probe func(ctx context.Context) error
+
+// Checker is a grpchealth.Checker which periodically runs probes against the backends.
+// A service is reported NOT_SERVING as long as one of the probes it depends on fails,
+// the overall health of the server, the empty service, requires all probes to succeed.
+type Checker struct {
+	log      *zap.SugaredLogger
+	interval time.Duration
+	timeout  time.Duration
+
+	probes   map[string]Probe
+	services map[string][]string
+
+	mu      sync.RWMutex
+	results map[string]error
+}
+
+// NewChecker creates a Checker which runs its probes every interval, each probe is canceled after timeout
+func NewChecker(log *zap.SugaredLogger, interval, timeout time.Duration) *Checker {
+	return &Checker{
+		log:      log.Named("health"),
+		interval: interval,
+		timeout:  timeout,
+		probes:   map[string]Probe{},
+		services: map[string][]string{},
+		results:  map[string]error{},
+	}
+}
+
+// AddProbe registers a probe, it is reported as failed until it was run once.
+// Must be called before Run.
+func (c *Checker) AddProbe(name string, probe Probe) {
+	c.probes[name] = probe
+	c.results[name] = errNotProbed
+}
+
+// AddService registers a service which is healthy if all given probes succeed.
+// Must be called before Run.
+func (c *Checker) AddService(service string, probes ...string) {
+	c.services[service] = probes
+}
+
+// Run probes all backends immediately and then every interval until ctx is done
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.probe(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Checker) probe(ctx context.Context) {
+	var wg sync.WaitGroup
+	for name, probe := range c.probes {
+		name, probe := name, probe
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+			err := probe(probeCtx)
+
+			c.mu.Lock()
+			previous := c.results[name]
+			c.results[name] = err
+			c.mu.Unlock()
+
+			switch {
+			case err != nil && (previous == nil || previous == errNotProbed):
+				c.log.Warnw("probe failed", "probe", name, "error", err)
+			case err == nil && previous != nil:
+				c.log.Infow("probe succeeded", "probe", name)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Check implements grpchealth.Checker
+func (c *Checker) Check(_ context.Context, req *grpchealth.CheckRequest) (*grpchealth.CheckResponse, error) {
+	var probes []string
+	if req.Service == "" {
+		probes = c.probeNames()
+	} else {
+		var ok bool
+		probes, ok = c.services[req.Service]
+		if !ok {
+			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("unknown service %s", req.Service))
+		}
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, name := range probes {
+		if c.results[name] != nil {
+			return &grpchealth.CheckResponse{Status: grpchealth.StatusNotServing}, nil
+		}
+	}
+	return &grpchealth.CheckResponse{Status: grpchealth.StatusServing}, nil
+}
+
+// LivenessHandler serves /healthz, it succeeds as long as the server is able to answer http requests
+func (c *Checker) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprintln(w, "ok")
+	})
+}
+
+// ReadinessHandler serves /readyz, it fails with 503 and lists the failed probes if one of them fails.
+// The errors of the probes may contain urls of the backends, they are only logged.
+func (c *Checker) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		c.mu.RLock()
+		var (
+			lines []string
+			ready = true
+		)
+		for _, name := range c.probeNames() {
+			if c.results[name] != nil {
+				ready = false
+				lines = append(lines, fmt.Sprintf("[-]%s failed", name))
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("[+]%s ok", name))
+		}
+		c.mu.RUnlock()
+
+		if ready {
+			lines = append(lines, "ok")
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		fmt.Fprintln(w, strings.Join(lines, "\n"))
+	})
+}
+
+func (c *Checker) probeNames() []string {
+	names := make([]string, 0, len(c.probes))
+	for name := range c.probes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	grpchealth "github.com/bufbuild/connect-grpchealth-go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var backendDown atomic.Bool
+	c := NewChecker(zaptest.NewLogger(t).Sugar(), 10*time.Millisecond, time.Second)
+	c.AddProbe("backend", func(ctx context.Context) error {
+		if backendDown.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	c.AddService("api.v1.DomainService", "backend")
+	c.AddService("api.v1.TokenService")
+
+	mux := http.NewServeMux()
+	mux.Handle("/healthz", c.LivenessHandler())
+	mux.Handle("/readyz", c.ReadinessHandler())
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	status := func(service string) grpchealth.Status {
+		resp, err := c.Check(ctx, &grpchealth.CheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+	get := func(path string) (int, string) {
+		resp, err := server.Client().Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	// not probed yet
+	require.Equal(t, grpchealth.StatusNotServing, status(""))
+	require.Equal(t, grpchealth.StatusNotServing, status("api.v1.DomainService"))
+	require.Equal(t, grpchealth.StatusServing, status("api.v1.TokenService"))
+	code, body := get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "[-]backend failed\n", body)
+	code, _ = get("/healthz")
+	require.Equal(t, http.StatusOK, code)
+
+	go c.Run(ctx)
+	require.Eventually(t, func() bool { return status("") == grpchealth.StatusServing }, time.Second, 10*time.Millisecond)
+	require.Equal(t, grpchealth.StatusServing, status("api.v1.DomainService"))
+	code, body = get("/readyz")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "[+]backend ok\nok\n", body)
+
+	backendDown.Store(true)
+	require.Eventually(t, func() bool { return status("") == grpchealth.StatusNotServing }, time.Second, 10*time.Millisecond)
+	require.Equal(t, grpchealth.StatusNotServing, status("api.v1.DomainService"))
+	require.Equal(t, grpchealth.StatusServing, status("api.v1.TokenService"))
+	code, body = get("/readyz")
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.NotContains(t, body, "connection refused")
+	code, _ = get("/healthz")
+	require.Equal(t, http.StatusOK, code)
+
+	_, err := c.Check(ctx, &grpchealth.CheckRequest{Service: "api.v1.UnknownService"})
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestProbeTimeout(t *testing.T) {
+	c := NewChecker(zaptest.NewLogger(t).Sugar(), time.Second, 10*time.Millisecond)
+	c.AddProbe("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	c.probe(context.Background())
+	require.ErrorIs(t, c.results["slow"], context.DeadlineExceeded)
+}
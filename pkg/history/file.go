@@ -0,0 +1,139 @@
+package history
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FileStore keeps the revisions of every zone as json lines in a separate file in a directory
+type FileStore struct {
+	lock sync.Mutex
+	dir  string
+	// last is the id of the latest revision per zone, read from the file on first access
+	last map[string]uint64
+}
+
+// NewFileStore stores revisions in dir, which is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create history directory %w", err)
+	}
+	return &FileStore{
+		dir:  dir,
+		last: map[string]uint64{},
+	}, nil
+}
+
+func (s *FileStore) path(zone string) (string, error) {
+	name := strings.TrimSuffix(zone, ".")
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid zone name %q", zone)
+	}
+	return filepath.Join(s.dir, name+".jsonl"), nil
+}
+
+// Append writes rev to the end of the file of its zone and syncs the file
+func (s *FileStore) Append(ctx context.Context, rev *Revision) error {
+	path, err := s.path(rev.Zone)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	last, ok := s.last[rev.Zone]
+	if !ok {
+		revs, err := s.read(ctx, path)
+		if err != nil {
+			return err
+		}
+		if len(revs) > 0 {
+			last = revs[len(revs)-1].ID
+		}
+	}
+	rev.ID = last + 1
+
+	line, err := json.Marshal(rev)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open history of %s %w", rev.Zone, err)
+	}
+	defer f.Close()
+	_, err = f.Write(line)
+	if err != nil {
+		return err
+	}
+	err = f.Sync()
+	if err != nil {
+		return err
+	}
+	s.last[rev.Zone] = rev.ID
+	return nil
+}
+
+// List returns all revisions of zone, oldest first
+func (s *FileStore) List(ctx context.Context, zone string) ([]*Revision, error) {
+	path, err := s.path(zone)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.read(ctx, path)
+}
+
+// Get returns the revision of zone with id or ErrNotFound
+func (s *FileStore) Get(ctx context.Context, zone string, id uint64) (*Revision, error) {
+	revs, err := s.List(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+	for _, rev := range revs {
+		if rev.ID == id {
+			return rev, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (s *FileStore) read(ctx context.Context, path string) ([]*Revision, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open history %w", err)
+	}
+	defer f.Close()
+
+	var revs []*Revision
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var rev Revision
+		if err := json.Unmarshal(scanner.Bytes(), &rev); err != nil {
+			return nil, fmt.Errorf("history is corrupt %w", err)
+		}
+		revs = append(revs, &rev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
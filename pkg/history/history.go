@@ -0,0 +1,198 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/token"
+	"go.uber.org/zap"
+)
+
+// ErrNotFound is returned if a zone has no revision with the requested id
+var ErrNotFound = errors.New("revision not found")
+
+// mutatingProcedures change the rrsets of a zone, the zone is snapshotted before every call
+var mutatingProcedures = map[string]bool{
+	apiv1connect.DomainServiceUpdateProcedure:     true,
+	apiv1connect.DomainServiceDeleteProcedure:     true,
+	apiv1connect.DomainServiceRollbackProcedure:   true,
+	apiv1connect.RecordServiceCreateProcedure:     true,
+	apiv1connect.RecordServiceUpdateProcedure:     true,
+	apiv1connect.RecordServiceDeleteProcedure:     true,
+	apiv1connect.ChallengeServicePresentProcedure: true,
+	apiv1connect.ChallengeServiceCleanUpProcedure: true,
+}
+
+// Record is a single record of a rrset
+type Record struct {
+	Content  string `json:"content"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// RRset is a set of records with the same name and type
+type RRset struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	TTL     uint32   `json:"ttl"`
+	Records []Record `json:"records"`
+}
+
+func (r RRset) key() string {
+	return r.Name + "/" + r.Type
+}
+
+// Revision is the state of a zone before a mutating call
+type Revision struct {
+	ID        uint64    `json:"id"`
+	Zone      string    `json:"zone"`
+	Time      time.Time `json:"time"`
+	Subject   string    `json:"subject,omitempty"`
+	Procedure string    `json:"procedure"`
+	RRsets    []RRset   `json:"rrsets"`
+}
+
+// Store keeps the revisions of all zones
+type Store interface {
+	// Append stores rev as the next revision of its zone and sets its id
+	Append(ctx context.Context, rev *Revision) error
+	// List returns all revisions of zone, oldest first
+	List(ctx context.Context, zone string) ([]*Revision, error)
+	// Get returns the revision of zone with id or ErrNotFound
+	Get(ctx context.Context, zone string, id uint64) (*Revision, error)
+}
+
+// Change is the difference of a single rrset between two states of a zone,
+// Before or After is nil if the rrset does not exist in this state.
+type Change struct {
+	Name   string
+	Type   string
+	Before *RRset
+	After  *RRset
+}
+
+// Diff returns the changes which turn the rrsets from into the rrsets to, sorted by name and type
+func Diff(from, to []RRset) []Change {
+	before := map[string]RRset{}
+	for _, r := range from {
+		before[r.key()] = r
+	}
+	after := map[string]RRset{}
+	for _, r := range to {
+		after[r.key()] = r
+	}
+
+	var changes []Change
+	for k, b := range before {
+		b := b
+		a, ok := after[k]
+		if !ok {
+			changes = append(changes, Change{Name: b.Name, Type: b.Type, Before: &b})
+			continue
+		}
+		if !equal(a, b) {
+			a := a
+			changes = append(changes, Change{Name: b.Name, Type: b.Type, Before: &b, After: &a})
+		}
+	}
+	for k, a := range after {
+		a := a
+		if _, ok := before[k]; !ok {
+			changes = append(changes, Change{Name: a.Name, Type: a.Type, After: &a})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		return changes[i].Type < changes[j].Type
+	})
+	return changes
+}
+
+// equal compares two rrsets, the order of the records is not significant
+func equal(a, b RRset) bool {
+	if a.TTL != b.TTL || len(a.Records) != len(b.Records) {
+		return false
+	}
+	records := map[Record]int{}
+	for _, r := range a.Records {
+		records[r]++
+	}
+	for _, r := range b.Records {
+		if records[r] == 0 {
+			return false
+		}
+		records[r]--
+	}
+	return true
+}
+
+// Snapshotter reads the current rrsets of the zone which is modified by a mutating request
+type Snapshotter interface {
+	ZoneState(ctx context.Context, req any) (zone string, rrsets []RRset, err error)
+}
+
+// Interceptor stores a revision of the affected zone before every successful mutating call.
+// It must be installed after the authorizer to have access to the claims of the caller.
+type Interceptor struct {
+	log         *zap.SugaredLogger
+	store       Store
+	snapshotter Snapshotter
+}
+
+// NewInterceptor creates a interceptor which stores the revisions in store,
+// the state of the zone is read with snapshotter.
+func NewInterceptor(log *zap.SugaredLogger, store Store, snapshotter Snapshotter) *Interceptor {
+	return &Interceptor{
+		log:         log.Named("history"),
+		store:       store,
+		snapshotter: snapshotter,
+	}
+}
+
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		if req.Spec().IsClient || !mutatingProcedures[procedure] {
+			return next(ctx, req)
+		}
+
+		zone, rrsets, err := i.snapshotter.ZoneState(ctx, req.Any())
+		if err != nil {
+			// the zone does not exist yet or the backend is not reachable, there is nothing to restore
+			i.log.Debugw("unable to snapshot zone", "procedure", procedure, "error", err)
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		rev := &Revision{
+			Zone:      zone,
+			Time:      time.Now(),
+			Procedure: procedure,
+			RRsets:    rrsets,
+		}
+		if claims, ok := ctx.Value(token.DNSClaimsKey{}).(*token.DNSClaims); ok && claims != nil {
+			rev.Subject = claims.Subject
+		}
+		if err := i.store.Append(ctx, rev); err != nil {
+			i.log.Errorw("unable to store revision", "zone", zone, "procedure", procedure, "error", err)
+		}
+		return resp, nil
+	})
+}
+
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
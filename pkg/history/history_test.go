@@ -0,0 +1,154 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDiff(t *testing.T) {
+	www := RRset{Name: "www.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "1.2.3.4"}, {Content: "1.2.3.5"}}}
+	wwwReordered := RRset{Name: "www.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "1.2.3.5"}, {Content: "1.2.3.4"}}}
+	wwwChanged := RRset{Name: "www.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "1.2.3.6"}}}
+	wwwTTL := RRset{Name: "www.example.com.", Type: "A", TTL: 600, Records: []Record{{Content: "1.2.3.4"}, {Content: "1.2.3.5"}}}
+	mail := RRset{Name: "mail.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "1.2.3.7"}}}
+
+	tests := []struct {
+		name string
+		from []RRset
+		to   []RRset
+		want []Change
+	}{
+		{name: "equal", from: []RRset{www, mail}, to: []RRset{mail, www}, want: nil},
+		{name: "record order", from: []RRset{www}, to: []RRset{wwwReordered}, want: nil},
+		{name: "added", from: []RRset{www}, to: []RRset{www, mail}, want: []Change{{Name: mail.Name, Type: "A", After: &mail}}},
+		{name: "removed", from: []RRset{www, mail}, to: []RRset{www}, want: []Change{{Name: mail.Name, Type: "A", Before: &mail}}},
+		{name: "changed", from: []RRset{www}, to: []RRset{wwwChanged}, want: []Change{{Name: www.Name, Type: "A", Before: &www, After: &wwwChanged}}},
+		{name: "ttl", from: []RRset{www}, to: []RRset{wwwTTL}, want: []Change{{Name: www.Name, Type: "A", Before: &www, After: &wwwTTL}}},
+		{name: "sorted", from: nil, to: []RRset{www, mail}, want: []Change{{Name: mail.Name, Type: "A", After: &mail}, {Name: www.Name, Type: "A", After: &www}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Diff(tt.from, tt.to))
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	rrsets := []RRset{{Name: "www.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "1.2.3.4"}}}}
+	require.NoError(t, s.Append(ctx, &Revision{Zone: "example.com.", Procedure: "a", RRsets: rrsets}))
+	require.NoError(t, s.Append(ctx, &Revision{Zone: "example.com.", Procedure: "b"}))
+	require.NoError(t, s.Append(ctx, &Revision{Zone: "example.org.", Procedure: "c"}))
+
+	revs, err := s.List(ctx, "example.com.")
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	require.Equal(t, uint64(1), revs[0].ID)
+	require.Equal(t, uint64(2), revs[1].ID)
+	require.Equal(t, rrsets, revs[0].RRsets)
+
+	// ids continue after a restart
+	s, err = NewFileStore(dir)
+	require.NoError(t, err)
+	rev := &Revision{Zone: "example.com.", Procedure: "d"}
+	require.NoError(t, s.Append(ctx, rev))
+	require.Equal(t, uint64(3), rev.ID)
+
+	rev, err = s.Get(ctx, "example.org.", 1)
+	require.NoError(t, err)
+	require.Equal(t, "c", rev.Procedure)
+
+	_, err = s.Get(ctx, "example.org.", 2)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	revs, err = s.List(ctx, "unknown.com.")
+	require.NoError(t, err)
+	require.Empty(t, revs)
+
+	err = s.Append(ctx, &Revision{Zone: "../etc/passwd"})
+	require.Error(t, err)
+}
+
+type fakeSnapshotter struct {
+	rrsets []RRset
+	err    error
+}
+
+func (f *fakeSnapshotter) ZoneState(context.Context, any) (string, []RRset, error) {
+	return "example.com.", f.rrsets, f.err
+}
+
+type memStore struct {
+	revs []*Revision
+}
+
+func (m *memStore) Append(_ context.Context, rev *Revision) error {
+	rev.ID = uint64(len(m.revs) + 1)
+	m.revs = append(m.revs, rev)
+	return nil
+}
+
+func (m *memStore) List(context.Context, string) ([]*Revision, error) {
+	return m.revs, nil
+}
+
+func (m *memStore) Get(context.Context, string, uint64) (*Revision, error) {
+	return nil, ErrNotFound
+}
+
+type fakeRequest struct {
+	connect.AnyRequest
+	procedure string
+}
+
+func (f fakeRequest) Spec() connect.Spec {
+	return connect.Spec{Procedure: f.procedure}
+}
+
+func (f fakeRequest) Any() any {
+	return nil
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	store := &memStore{}
+	snapshotter := &fakeSnapshotter{rrsets: []RRset{{Name: "www.example.com.", Type: "A", TTL: 300, Records: []Record{{Content: "1.2.3.4"}}}}}
+	i := NewInterceptor(zaptest.NewLogger(t).Sugar(), store, snapshotter)
+
+	var callErr error
+	next := i.WrapUnary(func(context.Context, connect.AnyRequest) (connect.AnyResponse, error) {
+		return nil, callErr
+	})
+
+	_, err := next(ctx, fakeRequest{procedure: "/api.v1.RecordService/List"})
+	require.NoError(t, err)
+	require.Empty(t, store.revs, "reads must not create revisions")
+
+	_, err = next(ctx, fakeRequest{procedure: "/api.v1.RecordService/Create"})
+	require.NoError(t, err)
+	require.Len(t, store.revs, 1)
+	require.Equal(t, "example.com.", store.revs[0].Zone)
+	require.Equal(t, "/api.v1.RecordService/Create", store.revs[0].Procedure)
+	require.Equal(t, snapshotter.rrsets, store.revs[0].RRsets)
+
+	callErr = errors.New("backend failed")
+	_, err = next(ctx, fakeRequest{procedure: "/api.v1.RecordService/Delete"})
+	require.Error(t, err)
+	require.Len(t, store.revs, 1, "failed calls must not create revisions")
+
+	callErr = nil
+	snapshotter.err = errors.New("zone not found")
+	_, err = next(ctx, fakeRequest{procedure: "/api.v1.RecordService/Delete"})
+	require.NoError(t, err)
+	require.Len(t, store.revs, 1)
+}
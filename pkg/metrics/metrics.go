@@ -0,0 +1,228 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "metal_dns"
+
+// Metrics holds all prometheus collectors of metal-dns
+type Metrics struct {
+	requests         *prometheus.CounterVec
+	requestDuration  *prometheus.HistogramVec
+	decisions        *prometheus.CounterVec
+	decisionDuration *prometheus.HistogramVec
+	backendRequests  *prometheus.CounterVec
+	backendDuration  *prometheus.HistogramVec
+	rateLimited      *prometheus.CounterVec
+	rateLimitBuckets *prometheus.GaugeVec
+	mirrorSyncs      *prometheus.CounterVec
+	mirrorPending    *prometheus.GaugeVec
+	mirrorLag        *prometheus.GaugeVec
+}
+
+// New creates all metrics and registers them at reg
+func New(reg prometheus.Registerer) *Metrics {
+	f := promauto.With(reg)
+	return &Metrics{
+		requests: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of api requests by procedure and response code.",
+		}, []string{"procedure", "code"}),
+		requestDuration: f.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of api requests by procedure.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"procedure"}),
+		decisions: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "authz",
+			Name:      "decisions_total",
+			Help:      "Number of authorization decisions by procedure and result, result is one of allow, deny or error.",
+		}, []string{"procedure", "result"}),
+		decisionDuration: f.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "authz",
+			Name:      "eval_duration_seconds",
+			Help:      "Duration of the policy evaluation by procedure.",
+			Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1},
+		}, []string{"procedure"}),
+		backendRequests: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "backend",
+			Name:      "requests_total",
+			Help:      "Number of requests to the dns backend by method, path and status code, code is error if no response was received.",
+		}, []string{"method", "path", "code"}),
+		backendDuration: f.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "backend",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of requests to the dns backend by method and path.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "path"}),
+		rateLimited: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ratelimit",
+			Name:      "rejected_total",
+			Help:      "Number of calls rejected by the rate limit by procedure and key, key is one of token or ip.",
+		}, []string{"procedure", "key"}),
+		rateLimitBuckets: f.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "ratelimit",
+			Name:      "buckets",
+			Help:      "Number of tokens and client ips which are currently limited by a bucket which is not full.",
+		}, []string{"key"}),
+		mirrorSyncs: f.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "mirror",
+			Name:      "syncs_total",
+			Help:      "Number of zones replicated to a mirror by mirror and result, result is one of ok or error.",
+		}, []string{"mirror", "result"}),
+		mirrorPending: f.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "mirror",
+			Name:      "pending_zones",
+			Help:      "Number of zones with changes which are not yet replicated to a mirror.",
+		}, []string{"mirror"}),
+		mirrorLag: f.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "mirror",
+			Name:      "lag_seconds",
+			Help:      "Age of the oldest change which is not yet replicated to a mirror, 0 if the mirror is in sync.",
+		}, []string{"mirror"}),
+	}
+}
+
+// ObserveDecision records the result and evaluation time of a authorization decision
+func (m *Metrics) ObserveDecision(procedure string, allow bool, err error, duration time.Duration) {
+	result := "deny"
+	switch {
+	case err != nil:
+		result = "error"
+	case allow:
+		result = "allow"
+	}
+	m.decisions.WithLabelValues(procedure, result).Inc()
+	m.decisionDuration.WithLabelValues(procedure).Observe(duration.Seconds())
+}
+
+// ObserveRateLimited records a call rejected by the rate limit
+func (m *Metrics) ObserveRateLimited(procedure, key string) {
+	m.rateLimited.WithLabelValues(procedure, key).Inc()
+}
+
+// SetRateLimitBuckets records the number of buckets of the rate limit
+func (m *Metrics) SetRateLimitBuckets(key string, buckets int) {
+	m.rateLimitBuckets.WithLabelValues(key).Set(float64(buckets))
+}
+
+// ObserveMirrorSync records the result of a replication to a mirror
+func (m *Metrics) ObserveMirrorSync(mirror string, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.mirrorSyncs.WithLabelValues(mirror, result).Inc()
+}
+
+// SetMirrorQueue records the pending zones of a mirror and the age of the oldest change
+func (m *Metrics) SetMirrorQueue(mirror string, pending int, lag time.Duration) {
+	m.mirrorPending.WithLabelValues(mirror).Set(float64(pending))
+	m.mirrorLag.WithLabelValues(mirror).Set(lag.Seconds())
+}
+
+// Interceptor returns a connect interceptor which records count, duration and response code of every call
+func (m *Metrics) Interceptor() connect.Interceptor {
+	return &interceptor{m: m}
+}
+
+type interceptor struct {
+	m *Metrics
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		start := time.Now()
+		resp, err := next(ctx, req)
+		i.m.observeRequest(req.Spec().Procedure, err, time.Since(start))
+		return resp, err
+	})
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		start := time.Now()
+		err := next(ctx, conn)
+		i.m.observeRequest(conn.Spec().Procedure, err, time.Since(start))
+		return err
+	})
+}
+
+func (m *Metrics) observeRequest(procedure string, err error, duration time.Duration) {
+	code := "ok"
+	if err != nil {
+		code = connect.CodeOf(err).String()
+	}
+	m.requests.WithLabelValues(procedure, code).Inc()
+	m.requestDuration.WithLabelValues(procedure).Observe(duration.Seconds())
+}
+
+// Transport returns a http.RoundTripper which records count, duration and status code of all requests to the backend.
+// If next is nil, http.DefaultTransport is used.
+func (m *Metrics) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{m: m, next: next}
+}
+
+type transport struct {
+	m    *Metrics
+	next http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := pathTemplate(req.URL.Path)
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.m.backendDuration.WithLabelValues(req.Method, path).Observe(time.Since(start).Seconds())
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	t.m.backendRequests.WithLabelValues(req.Method, path, code).Inc()
+	return resp, err
+}
+
+// pathTemplate replaces server and zone names in powerdns api paths with placeholders
+// to keep the cardinality of the path label low,
+// e.g. /api/v1/servers/localhost/zones/example.com. becomes /api/v1/servers/{server}/zones/{zone}
+func pathTemplate(path string) string {
+	parts := strings.Split(path, "/")
+	for i := 1; i < len(parts); i++ {
+		switch parts[i-1] {
+		case "servers":
+			parts[i] = "{server}"
+		case "zones":
+			parts[i] = "{zone}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
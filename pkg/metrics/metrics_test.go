@@ -0,0 +1,130 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pathTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/api/v1/servers", want: "/api/v1/servers"},
+		{path: "/api/v1/servers/localhost", want: "/api/v1/servers/{server}"},
+		{path: "/api/v1/servers/localhost/zones", want: "/api/v1/servers/{server}/zones"},
+		{path: "/api/v1/servers/localhost/zones/example.com.", want: "/api/v1/servers/{server}/zones/{zone}"},
+		{path: "/api/v1/servers/localhost/zones/example.com./notify", want: "/api/v1/servers/{server}/zones/{zone}/notify"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			if got := pathTemplate(tt.path); got != tt.want {
+				t.Errorf("pathTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type tokenService struct {
+	err error
+}
+
+func (t *tokenService) Create(context.Context, *connect.Request[v1.TokenServiceCreateRequest]) (*connect.Response[v1.TokenServiceCreateResponse], error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	return connect.NewResponse(&v1.TokenServiceCreateResponse{Token: "token"}), nil
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	m := New(prometheus.NewRegistry())
+
+	ts := &tokenService{}
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewTokenServiceHandler(ts, connect.WithInterceptors(m.Interceptor())))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := apiv1connect.NewTokenServiceClient(server.Client(), server.URL)
+
+	_, err := c.Create(ctx, connect.NewRequest(&v1.TokenServiceCreateRequest{}))
+	require.NoError(t, err)
+	ts.err = connect.NewError(connect.CodePermissionDenied, errors.New("denied"))
+	_, err = c.Create(ctx, connect.NewRequest(&v1.TokenServiceCreateRequest{}))
+	require.Error(t, err)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.requests.WithLabelValues(apiv1connect.TokenServiceCreateProcedure, "ok")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.requests.WithLabelValues(apiv1connect.TokenServiceCreateProcedure, "permission_denied")))
+	require.Equal(t, 1, testutil.CollectAndCount(m.requestDuration))
+}
+
+func TestObserveDecision(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.ObserveDecision("/api.v1.DomainService/Get", true, nil, time.Millisecond)
+	m.ObserveDecision("/api.v1.DomainService/Get", false, nil, time.Millisecond)
+	m.ObserveDecision("/api.v1.DomainService/Get", false, nil, time.Millisecond)
+	m.ObserveDecision("/api.v1.DomainService/Get", false, errors.New("eval failed"), time.Millisecond)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.decisions.WithLabelValues("/api.v1.DomainService/Get", "allow")))
+	require.Equal(t, float64(2), testutil.ToFloat64(m.decisions.WithLabelValues("/api.v1.DomainService/Get", "deny")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.decisions.WithLabelValues("/api.v1.DomainService/Get", "error")))
+}
+
+func TestRateLimit(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.ObserveRateLimited("/api.v1.RecordService/Create", "token")
+	m.ObserveRateLimited("/api.v1.RecordService/Create", "token")
+	m.SetRateLimitBuckets("token", 3)
+	m.SetRateLimitBuckets("token", 2)
+
+	require.Equal(t, float64(2), testutil.ToFloat64(m.rateLimited.WithLabelValues("/api.v1.RecordService/Create", "token")))
+	require.Equal(t, float64(2), testutil.ToFloat64(m.rateLimitBuckets.WithLabelValues("token")))
+}
+
+func TestMirror(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	m.ObserveMirrorSync("bind", nil)
+	m.ObserveMirrorSync("bind", errors.New("connection refused"))
+	m.SetMirrorQueue("bind", 2, 90*time.Second)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.mirrorSyncs.WithLabelValues("bind", "ok")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.mirrorSyncs.WithLabelValues("bind", "error")))
+	require.Equal(t, float64(2), testutil.ToFloat64(m.mirrorPending.WithLabelValues("bind")))
+	require.Equal(t, float64(90), testutil.ToFloat64(m.mirrorLag.WithLabelValues("bind")))
+}
+
+func TestTransport(t *testing.T) {
+	m := New(prometheus.NewRegistry())
+
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer backend.Close()
+
+	c := &http.Client{Transport: m.Transport(nil)}
+	resp, err := c.Get(backend.URL + "/api/v1/servers/localhost/zones/example.com.")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = c.Get("http://127.0.0.1:0/api/v1/servers/localhost/zones")
+	require.Error(t, err)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.backendRequests.WithLabelValues(http.MethodGet, "/api/v1/servers/{server}/zones/{zone}", "404")))
+	require.Equal(t, float64(1), testutil.ToFloat64(m.backendRequests.WithLabelValues(http.MethodGet, "/api/v1/servers/{server}/zones", "error")))
+}
@@ -0,0 +1,123 @@
+// Package mirror replicates the zones of a primary backend asynchronously to mirror backends.
+// Changes of the primary mark their zone in a persistent queue, the zone is then compared with every mirror and the
+// differences are applied to the mirror. Reconcile does the same for all zones, e.g. before a migration.
+package mirror
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/miekg/dns"
+	"gopkg.in/yaml.v3"
+)
+
+// Mirror is a backend which receives the zones of the primary
+type Mirror struct {
+	// Backend is named after the mirror
+	Backend *backend.Backend
+	// Zones are the suffixes of the mirrored zones, all zones are mirrored if empty
+	Zones []string
+}
+
+// Mirrors returns true if the changes of zone are replicated to the mirror
+func (m Mirror) Mirrors(zone string) bool {
+	if len(m.Zones) == 0 {
+		return true
+	}
+	for _, suffix := range m.Zones {
+		if dns.IsSubDomain(dns.CanonicalName(suffix), dns.CanonicalName(zone)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Spec is a mirror in the yaml file of the mirrors, exactly one backend must be configured
+type Spec struct {
+	Name     string                  `yaml:"name"`
+	Zones    []string                `yaml:"zones"`
+	PowerDNS *PowerDNSConfig         `yaml:"powerdns"`
+	RFC2136  *backend.RFC2136Config  `yaml:"rfc2136"`
+	ZoneFile *backend.ZoneFileConfig `yaml:"zonefile"`
+}
+
+// PowerDNSConfig is the api of a powerdns server
+type PowerDNSConfig struct {
+	URL string `yaml:"url"`
+	// VHost of the server, localhost if empty
+	VHost  string `yaml:"vhost"`
+	APIKey string `yaml:"api-key"`
+}
+
+// Load reads the mirrors from a yaml file at path and creates their backends, httpClient is used for the powerdns api
+func Load(path string, httpClient *http.Client) ([]Mirror, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read mirrors %w", err)
+	}
+	var specs []Spec
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	err = dec.Decode(&specs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse mirrors in %s %w", path, err)
+	}
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no mirrors in %s", path)
+	}
+	var (
+		mirrors []Mirror
+		names   = map[string]bool{}
+	)
+	for _, c := range specs {
+		if c.Name == "" {
+			return nil, fmt.Errorf("mirror in %s without name", path)
+		}
+		if names[c.Name] {
+			return nil, fmt.Errorf("mirror %s is defined twice in %s", c.Name, path)
+		}
+		names[c.Name] = true
+		b, err := c.backend(httpClient)
+		if err != nil {
+			return nil, fmt.Errorf("mirror %s %w", c.Name, err)
+		}
+		b.Name = c.Name
+		mirrors = append(mirrors, Mirror{Backend: b, Zones: c.Zones})
+	}
+	return mirrors, nil
+}
+
+func (c Spec) backend(httpClient *http.Client) (*backend.Backend, error) {
+	configured := 0
+	for _, ok := range []bool{c.PowerDNS != nil, c.RFC2136 != nil, c.ZoneFile != nil} {
+		if ok {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return nil, fmt.Errorf("must have exactly one of powerdns, rfc2136 or zonefile")
+	}
+	switch {
+	case c.PowerDNS != nil:
+		u, err := url.Parse(c.PowerDNS.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("must have an http or https url, got %q", c.PowerDNS.URL)
+		}
+		vhost := c.PowerDNS.VHost
+		if vhost == "" {
+			vhost = "localhost"
+		}
+		return backend.NewPowerDNS(c.PowerDNS.URL, vhost, c.PowerDNS.APIKey, httpClient), nil
+	case c.RFC2136 != nil:
+		if c.RFC2136.Nameserver == "" || len(c.RFC2136.Zones) == 0 {
+			return nil, fmt.Errorf("rfc2136 needs a nameserver and zones")
+		}
+		return backend.NewRFC2136(*c.RFC2136)
+	default:
+		return backend.NewZoneFile(*c.ZoneFile)
+	}
+}
@@ -0,0 +1,378 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	primary, mirror := newZoneFile(t, "primary"), newZoneFile(t, "mirror")
+	addZone(t, primary, "a.example.com.")
+
+	t.Run("zone is created", func(t *testing.T) {
+		result, err := Sync(ctx, primary, mirror, "a.example.com.", Options{})
+		require.NoError(t, err)
+		require.True(t, result.Created)
+		_, err = mirror.Zones.Get(ctx, "a.example.com.")
+		require.True(t, backend.IsNotFound(err))
+
+		result, err = Sync(ctx, primary, mirror, "a.example.com", Options{Apply: true})
+		require.NoError(t, err)
+		require.True(t, result.Created)
+		require.Equal(t, []string{"1.2.3.4"}, content(t, mirror, "www.a.example.com.", powerdns.RRTypeA))
+	})
+	t.Run("in sync", func(t *testing.T) {
+		// the soa differs after the change of the primary, it is not compared
+		require.NoError(t, primary.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 300, []string{"1.2.3.4"}))
+		result, err := Sync(ctx, primary, mirror, "a.example.com.", Options{Apply: true})
+		require.NoError(t, err)
+		require.True(t, result.InSync())
+	})
+	t.Run("changes are applied", func(t *testing.T) {
+		require.NoError(t, primary.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 300, []string{"1.2.3.5"}))
+		require.NoError(t, primary.Records.Add(ctx, "a.example.com.", "mail.a.example.com.", powerdns.RRTypeMX, 300, []string{"10 mx.a.example.com."}))
+		require.NoError(t, mirror.Records.Add(ctx, "a.example.com.", "stale.a.example.com.", powerdns.RRTypeTXT, 300, []string{`"stale"`}))
+
+		result, err := Sync(ctx, primary, mirror, "a.example.com.", Options{})
+		require.NoError(t, err)
+		require.Len(t, result.Changes, 3)
+		require.Equal(t, []string{"1.2.3.4"}, content(t, mirror, "www.a.example.com.", powerdns.RRTypeA))
+
+		_, err = Sync(ctx, primary, mirror, "a.example.com.", Options{Apply: true})
+		require.NoError(t, err)
+		require.Equal(t, []string{"1.2.3.5"}, content(t, mirror, "www.a.example.com.", powerdns.RRTypeA))
+		require.Equal(t, []string{"10 mx.a.example.com."}, content(t, mirror, "mail.a.example.com.", powerdns.RRTypeMX))
+		require.Empty(t, content(t, mirror, "stale.a.example.com.", powerdns.RRTypeTXT))
+	})
+	t.Run("zone is deleted", func(t *testing.T) {
+		require.NoError(t, primary.Zones.Delete(ctx, "a.example.com."))
+		result, err := Sync(ctx, primary, mirror, "a.example.com.", Options{Apply: true})
+		require.NoError(t, err)
+		require.True(t, result.Kept)
+		require.True(t, result.InSync())
+		require.NotEmpty(t, content(t, mirror, "www.a.example.com.", powerdns.RRTypeA))
+
+		result, err = Sync(ctx, primary, mirror, "a.example.com.", Options{Apply: true, Prune: true})
+		require.NoError(t, err)
+		require.True(t, result.Deleted)
+		_, err = mirror.Zones.Get(ctx, "a.example.com.")
+		require.True(t, backend.IsNotFound(err))
+
+		result, err = Sync(ctx, primary, mirror, "a.example.com.", Options{Apply: true, Prune: true})
+		require.NoError(t, err)
+		require.True(t, result.InSync())
+	})
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	primary, mirror := newZoneFile(t, "primary"), newZoneFile(t, "mirror")
+	addZone(t, primary, "a.example.com.")
+	addZone(t, primary, "b.example.com.")
+	addZone(t, primary, "example.org.")
+	addZone(t, mirror, "old.example.com.")
+
+	m := Mirror{Backend: mirror, Zones: []string{"example.com."}}
+	results, err := Reconcile(ctx, primary, m, Options{})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.True(t, results[0].Created)
+	zones, err := mirror.Zones.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, zones, 1)
+
+	// zones which are only on the mirror are kept
+	results, err = Reconcile(ctx, primary, m, Options{Apply: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	zones, err = mirror.Zones.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, zones, 3)
+
+	results, err = Reconcile(ctx, primary, m, Options{Apply: true, Prune: true})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, "a.example.com.", results[0].Zone)
+	require.True(t, results[0].InSync())
+	require.Equal(t, "b.example.com.", results[1].Zone)
+	require.Equal(t, "old.example.com.", results[2].Zone)
+	require.True(t, results[2].Deleted)
+
+	zones, err = mirror.Zones.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, zones, 2)
+
+	results, err = Reconcile(ctx, primary, m, Options{Prune: true})
+	require.NoError(t, err)
+	for _, r := range results {
+		require.True(t, r.InSync(), r.Zone)
+	}
+}
+
+func TestReplicator(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	primary, target := newZoneFile(t, "primary"), newZoneFile(t, "mirror")
+	failing := &failingZones{Zones: target.Zones}
+	mirror := &backend.Backend{Name: "mirror", Zones: failing, Records: target.Records}
+
+	queue, err := NewFileQueue(t.TempDir())
+	require.NoError(t, err)
+	observer := &observer{lag: map[string]time.Duration{}, pending: map[string]int{}}
+	r := NewReplicator(zaptest.NewLogger(t).Sugar(), primary, []Mirror{
+		{Backend: mirror, Zones: []string{"example.com."}},
+	}, queue, Config{Backoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Interval: 10 * time.Millisecond}, observer)
+	b := r.Backend()
+	require.Equal(t, primary.Name, b.Name)
+
+	done := make(chan struct{})
+	go func() {
+		r.Run(ctx)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	_, err = b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("a.example.com."), Nameservers: []string{"ns1.a.example.com."}})
+	require.NoError(t, err)
+	require.NoError(t, b.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 300, []string{"1.2.3.4"}))
+	require.Eventually(t, func() bool {
+		return equal(contentOrNil(target, "www.a.example.com.", powerdns.RRTypeA), []string{"1.2.3.4"})
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return observer.get("mirror") == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// zones which are not mirrored are not queued
+	_, err = b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String("example.org."), Nameservers: []string{"ns1.example.org."}})
+	require.NoError(t, err)
+	entries, err := queue.List()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// failures are retried until the mirror is back
+	failing.set(errors.New("connection refused"))
+	require.NoError(t, b.Records.Add(ctx, "a.example.com.", "www.a.example.com.", powerdns.RRTypeA, 300, []string{"1.2.3.5"}))
+	require.Eventually(t, func() bool {
+		entries, err := queue.List()
+		return err == nil && len(entries) == 1 && entries[0].Attempts >= 2 && entries[0].LastError != ""
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return observer.get("mirror") == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"1.2.3.4"}, contentOrNil(target, "www.a.example.com.", powerdns.RRTypeA))
+
+	failing.set(nil)
+	require.Eventually(t, func() bool {
+		return equal(contentOrNil(target, "www.a.example.com.", powerdns.RRTypeA), []string{"1.2.3.5"})
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		entries, err := queue.List()
+		return err == nil && len(entries) == 0 && observer.get("mirror") == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileQueue(t *testing.T) {
+	dir := t.TempDir()
+	q, err := NewFileQueue(dir)
+	require.NoError(t, err)
+	start := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, q.Push("a.example.com.", []string{"bind", "files"}, start))
+	require.NoError(t, q.Push("b.example.com.", []string{"bind"}, start.Add(time.Second)))
+	// changes are coalesced, the oldest change is kept
+	require.NoError(t, q.Push("a.example.com.", []string{"bind"}, start.Add(2*time.Second)))
+
+	entries, err := q.List()
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	require.Equal(t, Entry{Mirror: "bind", Zone: "a.example.com.", Since: start, Seq: 2}, entries[0])
+	require.Equal(t, Entry{Mirror: "files", Zone: "a.example.com.", Since: start, Seq: 1}, entries[1])
+	require.Equal(t, "b.example.com.", entries[2].Zone)
+
+	next := start.Add(time.Minute)
+	require.NoError(t, q.Failed(entries[1], errors.New("connection refused"), next))
+	// the zone changed during the replication, the entry is kept
+	require.NoError(t, q.Push("b.example.com.", []string{"bind"}, start.Add(3*time.Second)))
+	require.NoError(t, q.Done(entries[2], start.Add(2*time.Second)))
+	require.NoError(t, q.Done(entries[0], start.Add(2*time.Second)))
+
+	// the entries are persisted
+	q, err = NewFileQueue(dir)
+	require.NoError(t, err)
+	entries, err = q.List()
+	require.NoError(t, err)
+	require.Equal(t, []Entry{
+		{Mirror: "files", Zone: "a.example.com.", Since: start, Seq: 1, Attempts: 1, NextAttempt: next, LastError: "connection refused"},
+		{Mirror: "bind", Zone: "b.example.com.", Since: start.Add(2 * time.Second), Seq: 2},
+	}, entries)
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	zones := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(`
+- name: pdns-new
+  powerdns:
+    url: http://pdns-new:8081
+    api-key: secret
+- name: files
+  zones: [example.com.]
+  zonefile:
+    directory: `+zones+`
+    reload-command: [true]
+- name: bind
+  rfc2136:
+    nameserver: bind:53
+    zones: [a.example.com.]
+    timeout: 5s
+`), 0600))
+	mirrors, err := Load(valid, nil)
+	require.NoError(t, err)
+	require.Len(t, mirrors, 3)
+	require.Equal(t, "pdns-new", mirrors[0].Backend.Name)
+	require.Equal(t, "files", mirrors[1].Backend.Name)
+	require.True(t, mirrors[1].Mirrors("a.example.com."))
+	require.False(t, mirrors[1].Mirrors("example.org."))
+	require.True(t, mirrors[0].Mirrors("example.org."))
+	require.Equal(t, "bind", mirrors[2].Backend.Name)
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "empty", content: "[]"},
+		{name: "unknown field", content: "- name: a\n  powerdns:\n    url: http://a\n    vhosts: a\n"},
+		{name: "without name", content: "- powerdns:\n    url: http://a\n"},
+		{name: "duplicate", content: "- name: a\n  powerdns:\n    url: http://a\n- name: a\n  powerdns:\n    url: http://b\n"},
+		{name: "without backend", content: "- name: a\n"},
+		{name: "two backends", content: "- name: a\n  powerdns:\n    url: http://a\n  zonefile:\n    directory: " + zones + "\n"},
+		{name: "invalid url", content: "- name: a\n  powerdns:\n    url: pdns:8081\n"},
+		{name: "rfc2136 without zones", content: "- name: a\n  rfc2136:\n    nameserver: bind:53\n"},
+		{name: "missing directory", content: "- name: a\n  zonefile:\n    directory: " + filepath.Join(zones, "missing") + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, "invalid.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			_, err := Load(path, nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func newZoneFile(t *testing.T, name string) *backend.Backend {
+	b, err := backend.NewZoneFile(backend.ZoneFileConfig{Directory: t.TempDir()})
+	require.NoError(t, err)
+	b.Name = name
+	return b
+}
+
+func addZone(t *testing.T, b *backend.Backend, zone string) {
+	ctx := context.Background()
+	_, err := b.Zones.Add(ctx, &powerdns.Zone{Name: powerdns.String(zone), Nameservers: []string{"ns1." + zone}})
+	require.NoError(t, err)
+	require.NoError(t, b.Records.Add(ctx, zone, "www."+zone, powerdns.RRTypeA, 300, []string{"1.2.3.4"}))
+}
+
+func content(t *testing.T, b *backend.Backend, name string, rrtype powerdns.RRType) []string {
+	zone, err := b.Zones.Get(context.Background(), "a.example.com.")
+	require.NoError(t, err)
+	return contentOf(zone, name, rrtype)
+}
+
+func contentOrNil(b *backend.Backend, name string, rrtype powerdns.RRType) []string {
+	zone, err := b.Zones.Get(context.Background(), "a.example.com.")
+	if err != nil {
+		return nil
+	}
+	return contentOf(zone, name, rrtype)
+}
+
+func contentOf(zone *powerdns.Zone, name string, rrtype powerdns.RRType) []string {
+	var result []string
+	for _, set := range zone.RRsets {
+		if *set.Name != name || *set.Type != rrtype {
+			continue
+		}
+		for _, r := range set.Records {
+			result = append(result, *r.Content)
+		}
+	}
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// failingZones fails to get zones while err is set
+type failingZones struct {
+	backend.Zones
+	lock sync.Mutex
+	err  error
+}
+
+func (f *failingZones) set(err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.err = err
+}
+
+func (f *failingZones) Get(ctx context.Context, domain string) (*powerdns.Zone, error) {
+	f.lock.Lock()
+	err := f.err
+	f.lock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return f.Zones.Get(ctx, domain)
+}
+
+type observer struct {
+	lock    sync.Mutex
+	lag     map[string]time.Duration
+	pending map[string]int
+}
+
+func (o *observer) ObserveMirrorSync(mirror string, err error) {}
+
+func (o *observer) SetMirrorQueue(mirror string, pending int, lag time.Duration) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.pending[mirror] = pending
+	o.lag[mirror] = lag
+}
+
+// get returns the pending zones of mirror, -1 if they were not observed yet
+func (o *observer) get(mirror string) int {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	pending, ok := o.pending[mirror]
+	if !ok {
+		return -1
+	}
+	return pending
+}
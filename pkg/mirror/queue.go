@@ -0,0 +1,183 @@
+package mirror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const queueFile = "queue.json"
+
+// Entry is a zone which changed on the primary and is not yet replicated to a mirror
+type Entry struct {
+	Mirror string `json:"mirror"`
+	Zone   string `json:"zone"`
+	// Since is the time of the oldest change which is not replicated
+	Since time.Time `json:"since"`
+	// Seq is incremented with every change of the zone, the entry is kept if the zone changed during a replication
+	Seq         uint64    `json:"seq"`
+	Attempts    int       `json:"attempts,omitempty"`
+	NextAttempt time.Time `json:"next_attempt,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+func (e *Entry) key() string {
+	return e.Mirror + " " + e.Zone
+}
+
+// FileQueue keeps the entries in a single json file, which is replaced atomically on every change.
+// The changes of a zone are coalesced into one entry per mirror.
+type FileQueue struct {
+	lock sync.Mutex
+	path string
+}
+
+// NewFileQueue stores the entries in dir, which is created if it does not exist
+func NewFileQueue(dir string) (*FileQueue, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create mirror queue directory %w", err)
+	}
+	return &FileQueue{path: filepath.Join(dir, queueFile)}, nil
+}
+
+// Push marks zone as changed at now for the mirrors
+func (q *FileQueue) Push(zone string, mirrors []string, now time.Time) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	entries, err := q.read()
+	if err != nil {
+		return err
+	}
+	for _, m := range mirrors {
+		e := &Entry{Mirror: m, Zone: zone}
+		if existing, ok := entries[e.key()]; ok {
+			// the next attempt is not delayed by a new change
+			existing.Seq++
+			continue
+		}
+		e.Since = now
+		e.Seq = 1
+		entries[e.key()] = e
+	}
+	return q.write(entries)
+}
+
+// List returns all entries, the oldest first
+func (q *FileQueue) List() ([]Entry, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	entries, err := q.read()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Since.Equal(result[j].Since) {
+			return result[i].key() < result[j].key()
+		}
+		return result[i].Since.Before(result[j].Since)
+	})
+	return result, nil
+}
+
+// Done removes e after it was replicated. If the zone changed since e was listed, the entry is kept
+// with the time the replication started, which precedes all changes which are not replicated.
+func (q *FileQueue) Done(e Entry, started time.Time) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	entries, err := q.read()
+	if err != nil {
+		return err
+	}
+	existing, ok := entries[e.key()]
+	if !ok {
+		return nil
+	}
+	if existing.Seq == e.Seq {
+		delete(entries, e.key())
+	} else {
+		existing.Since = started
+		existing.Attempts = 0
+		existing.NextAttempt = time.Time{}
+		existing.LastError = ""
+	}
+	return q.write(entries)
+}
+
+// Failed records a failed replication of e and when it is attempted next
+func (q *FileQueue) Failed(e Entry, cause error, next time.Time) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	entries, err := q.read()
+	if err != nil {
+		return err
+	}
+	existing, ok := entries[e.key()]
+	if !ok {
+		return nil
+	}
+	existing.Attempts++
+	existing.NextAttempt = next
+	existing.LastError = cause.Error()
+	return q.write(entries)
+}
+
+func (q *FileQueue) read() (map[string]*Entry, error) {
+	entries := map[string]*Entry{}
+	data, err := os.ReadFile(q.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read mirror queue %w", err)
+	}
+	var list []*Entry
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, fmt.Errorf("mirror queue is corrupt %w", err)
+	}
+	for _, e := range list {
+		entries[e.key()] = e
+	}
+	return entries, nil
+}
+
+// write replaces the file with a synced temporary file, readers never see a partial write
+func (q *FileQueue) write(entries map[string]*Entry) error {
+	list := make([]*Entry, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].key() < list[j].key()
+	})
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(q.path), queueFile+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write mirror queue %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write mirror queue %w", err)
+	}
+	return os.Rename(f.Name(), q.path)
+}
@@ -0,0 +1,279 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultBackoff    = time.Second
+	DefaultMaxBackoff = 5 * time.Minute
+	DefaultInterval   = 10 * time.Second
+)
+
+// Config configures the replication of a Replicator, zero values are replaced by the defaults
+type Config struct {
+	// Backoff is the wait before the first retry of a zone, it doubles with every further retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Interval is the maximum wait between two runs over the queue, changes start a run immediately
+	Interval time.Duration
+}
+
+// Observer gets notified about replications and the state of the queue
+type Observer interface {
+	ObserveMirrorSync(mirror string, err error)
+	SetMirrorQueue(mirror string, pending int, lag time.Duration)
+}
+
+// Replicator queues the changes of the primary and replicates them to the mirrors
+type Replicator struct {
+	log      *zap.SugaredLogger
+	primary  *backend.Backend
+	mirrors  []Mirror
+	queue    *FileQueue
+	config   Config
+	observer Observer
+	now      func() time.Time
+
+	wake chan struct{}
+}
+
+// NewReplicator creates a replicator from primary to mirrors with queue, observer is optional
+func NewReplicator(log *zap.SugaredLogger, primary *backend.Backend, mirrors []Mirror, queue *FileQueue, config Config, observer Observer) *Replicator {
+	if config.Backoff <= 0 {
+		config.Backoff = DefaultBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	return &Replicator{
+		log:      log.Named("mirror"),
+		primary:  primary,
+		mirrors:  mirrors,
+		queue:    queue,
+		config:   config,
+		observer: observer,
+		now:      time.Now,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Backend returns the primary, whose successful changes are queued for the mirrors.
+// Reads and the probe are served by the primary only.
+func (r *Replicator) Backend() *backend.Backend {
+	return &backend.Backend{
+		Name:    r.primary.Name,
+		Zones:   &replicatedZones{Zones: r.primary.Zones, r: r},
+		Records: &replicatedRecords{Records: r.primary.Records, r: r},
+		Probe:   r.primary.Probe,
+	}
+}
+
+// Run replicates the queued zones until ctx is done, a replication which was started is completed
+func (r *Replicator) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		r.replicate(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// changed queues zone for the mirrors which mirror it, the change of the primary succeeded even if this fails
+func (r *Replicator) changed(zone string) {
+	zone = dns.CanonicalName(zone)
+	var mirrors []string
+	for _, m := range r.mirrors {
+		if m.Mirrors(zone) {
+			mirrors = append(mirrors, m.Backend.Name)
+		}
+	}
+	if len(mirrors) == 0 {
+		return
+	}
+	err := r.queue.Push(zone, mirrors, r.now())
+	if err != nil {
+		r.log.Errorw("unable to queue change for the mirrors, reconcile them", "zone", zone, "mirrors", mirrors, "error", err)
+		return
+	}
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// replicate syncs the due entries of the queue once
+func (r *Replicator) replicate(ctx context.Context) {
+	defer r.observe()
+	entries, err := r.queue.List()
+	if err != nil {
+		r.log.Errorw("unable to read the queue", "error", err)
+		return
+	}
+	for _, e := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+		if e.NextAttempt.After(r.now()) {
+			continue
+		}
+		m := r.mirror(e.Mirror)
+		if m == nil {
+			r.log.Warnw("dropping the changes of a mirror which is not configured", "mirror", e.Mirror, "zone", e.Zone)
+			r.done(e, r.now())
+			continue
+		}
+		started := r.now()
+		// a replication which was started is completed on shutdown
+		syncCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		// the zone changed on the primary, if it is missing there it was deleted and is deleted on the mirror too
+		result, err := Sync(syncCtx, r.primary, m.Backend, e.Zone, Options{Apply: true, Prune: true})
+		cancel()
+		if r.observer != nil {
+			r.observer.ObserveMirrorSync(e.Mirror, err)
+		}
+		if errors.Is(err, backend.ErrUnsupported) {
+			r.log.Errorw("mirror cannot replicate the zone, dropping it", "mirror", e.Mirror, "zone", e.Zone, "error", err)
+			r.done(e, started)
+			continue
+		}
+		if err != nil {
+			backoff := r.config.Backoff
+			for i := 0; i < e.Attempts && backoff < r.config.MaxBackoff; i++ {
+				backoff *= 2
+			}
+			if backoff > r.config.MaxBackoff {
+				backoff = r.config.MaxBackoff
+			}
+			r.log.Infow("replication failed", "mirror", e.Mirror, "zone", e.Zone, "attempt", e.Attempts+1, "retry in", backoff, "error", err)
+			if err := r.queue.Failed(e, err, r.now().Add(backoff)); err != nil {
+				r.log.Errorw("unable to update the queue", "error", err)
+			}
+			continue
+		}
+		r.log.Debugw("replicated", "mirror", e.Mirror, "zone", e.Zone, "created", result.Created, "deleted", result.Deleted, "changes", len(result.Changes))
+		r.done(e, started)
+	}
+}
+
+func (r *Replicator) done(e Entry, started time.Time) {
+	if err := r.queue.Done(e, started); err != nil {
+		r.log.Errorw("unable to update the queue", "error", err)
+	}
+}
+
+// observe reports the pending zones and the age of the oldest change per mirror
+func (r *Replicator) observe() {
+	if r.observer == nil {
+		return
+	}
+	entries, err := r.queue.List()
+	if err != nil {
+		return
+	}
+	now := r.now()
+	for _, m := range r.mirrors {
+		pending := 0
+		var lag time.Duration
+		for _, e := range entries {
+			if e.Mirror != m.Backend.Name {
+				continue
+			}
+			pending++
+			if since := now.Sub(e.Since); since > lag {
+				lag = since
+			}
+		}
+		r.observer.SetMirrorQueue(m.Backend.Name, pending, lag)
+	}
+}
+
+func (r *Replicator) mirror(name string) *Mirror {
+	for i := range r.mirrors {
+		if r.mirrors[i].Backend.Name == name {
+			return &r.mirrors[i]
+		}
+	}
+	return nil
+}
+
+type replicatedZones struct {
+	backend.Zones
+	r *Replicator
+}
+
+func (z *replicatedZones) Add(ctx context.Context, zone *powerdns.Zone) (*powerdns.Zone, error) {
+	result, err := z.Zones.Add(ctx, zone)
+	if err == nil {
+		z.r.changed(powerdns.StringValue(zone.Name))
+	}
+	return result, err
+}
+
+func (z *replicatedZones) Change(ctx context.Context, domain string, zone *powerdns.Zone) error {
+	err := z.Zones.Change(ctx, domain, zone)
+	if err == nil {
+		z.r.changed(domain)
+	}
+	return err
+}
+
+func (z *replicatedZones) Delete(ctx context.Context, domain string) error {
+	err := z.Zones.Delete(ctx, domain)
+	if err == nil {
+		z.r.changed(domain)
+	}
+	return err
+}
+
+type replicatedRecords struct {
+	backend.Records
+	r *Replicator
+}
+
+func (r *replicatedRecords) Add(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	err := r.Records.Add(ctx, domain, name, recordType, ttl, content, options...)
+	if err == nil {
+		r.r.changed(domain)
+	}
+	return err
+}
+
+func (r *replicatedRecords) Change(ctx context.Context, domain string, name string, recordType powerdns.RRType, ttl uint32, content []string, options ...powerdns.RRsetOption) error {
+	err := r.Records.Change(ctx, domain, name, recordType, ttl, content, options...)
+	if err == nil {
+		r.r.changed(domain)
+	}
+	return err
+}
+
+func (r *replicatedRecords) Delete(ctx context.Context, domain string, name string, recordType powerdns.RRType) error {
+	err := r.Records.Delete(ctx, domain, name, recordType)
+	if err == nil {
+		r.r.changed(domain)
+	}
+	return err
+}
+
+func (r *replicatedRecords) Patch(ctx context.Context, domain string, rrSets *powerdns.RRsets) error {
+	err := r.Records.Patch(ctx, domain, rrSets)
+	if err == nil {
+		r.r.changed(domain)
+	}
+	return err
+}
@@ -0,0 +1,199 @@
+package mirror
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/joeig/go-powerdns/v3"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/history"
+	"github.com/majst01/metal-dns/pkg/propagation"
+	"github.com/miekg/dns"
+)
+
+// Options control which differences are applied to a mirror
+type Options struct {
+	// Apply changes the mirror, otherwise the differences are only reported
+	Apply bool
+	// Prune deletes zones which are missing on the primary from the mirror, otherwise they are kept
+	Prune bool
+}
+
+// Result is the difference of a zone between the primary and a mirror
+type Result struct {
+	Zone   string
+	Mirror string
+	// Created is true if the zone is missing on the mirror, Deleted if it is missing on the primary and pruned
+	Created bool
+	Deleted bool
+	// Kept is true if the zone is missing on the primary and kept on the mirror because it is not pruned
+	Kept bool
+	// Changes turn the rrsets of the mirror into the rrsets of the primary, the SOA is not compared
+	Changes []history.Change
+}
+
+// InSync returns true if the zone does not differ, zones which are kept on the mirror are not a difference
+func (r *Result) InSync() bool {
+	return !r.Created && !r.Deleted && len(r.Changes) == 0
+}
+
+// Sync compares zone on the primary and the mirror and applies the differences to the mirror if opts.Apply is set.
+// Zones missing on the mirror are created, zones missing on the primary are only deleted with opts.Prune.
+func Sync(ctx context.Context, primary *backend.Backend, mirror *backend.Backend, zone string, opts Options) (*Result, error) {
+	zone = dns.CanonicalName(zone)
+	result := &Result{Zone: zone, Mirror: mirror.Name}
+
+	// the powerdns client returns an empty zone together with not found
+	p, err := primary.Zones.Get(ctx, zone)
+	if backend.IsNotFound(err) {
+		p = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get zone %s from primary %w", zone, err)
+	}
+	m, err := mirror.Zones.Get(ctx, zone)
+	if backend.IsNotFound(err) {
+		m = nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get zone %s from mirror %s %w", zone, mirror.Name, err)
+	}
+
+	switch {
+	case p == nil && m == nil:
+		return result, nil
+	case p == nil && !opts.Prune:
+		result.Kept = true
+		return result, nil
+	case p == nil:
+		result.Deleted = true
+		result.Changes = history.Diff(rrsetsOf(m), nil)
+		if !opts.Apply {
+			return result, nil
+		}
+		err = mirror.Zones.Delete(ctx, zone)
+		if err != nil {
+			return nil, fmt.Errorf("unable to delete zone %s from mirror %s %w", zone, mirror.Name, err)
+		}
+		return result, nil
+	case m == nil:
+		result.Created = true
+		result.Changes = history.Diff(nil, rrsetsOf(p))
+		if !opts.Apply {
+			return result, nil
+		}
+		// the nameservers are part of the rrsets, the soa of the primary is copied
+		_, err = mirror.Zones.Add(ctx, &powerdns.Zone{
+			Name:   powerdns.String(dns.Fqdn(powerdns.StringValue(p.Name))),
+			Kind:   powerdns.ZoneKindPtr(powerdns.MasterZoneKind),
+			RRsets: p.RRsets,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to add zone %s to mirror %s %w", zone, mirror.Name, err)
+		}
+		return result, nil
+	}
+
+	result.Changes = history.Diff(rrsetsOf(m), rrsetsOf(p))
+	if !opts.Apply || len(result.Changes) == 0 {
+		return result, nil
+	}
+	sets := &powerdns.RRsets{}
+	for _, c := range result.Changes {
+		if c.After == nil {
+			sets.Sets = append(sets.Sets, powerdns.RRset{
+				Name:       powerdns.String(c.Name),
+				Type:       powerdns.RRTypePtr(powerdns.RRType(c.Type)),
+				ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeDelete),
+				Records:    []powerdns.Record{},
+			})
+			continue
+		}
+		set := powerdns.RRset{
+			Name:       powerdns.String(c.After.Name),
+			Type:       powerdns.RRTypePtr(powerdns.RRType(c.After.Type)),
+			TTL:        powerdns.Uint32(c.After.TTL),
+			ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace),
+			Records:    []powerdns.Record{},
+		}
+		for _, r := range c.After.Records {
+			set.Records = append(set.Records, powerdns.Record{Content: powerdns.String(r.Content), Disabled: powerdns.Bool(r.Disabled)})
+		}
+		sets.Sets = append(sets.Sets, set)
+	}
+	err = mirror.Records.Patch(ctx, zone, sets)
+	if err != nil {
+		return nil, fmt.Errorf("unable to patch zone %s on mirror %s %w", zone, mirror.Name, err)
+	}
+	return result, nil
+}
+
+// Reconcile syncs all zones of the primary which are mirrored, zones which fail are reported in the error.
+// The zones of the mirror are only compared with opts.Prune, a mirror may be shared with zones which are not managed by the primary.
+func Reconcile(ctx context.Context, primary *backend.Backend, mirror Mirror, opts Options) ([]*Result, error) {
+	backends := []*backend.Backend{primary}
+	if opts.Prune {
+		backends = append(backends, mirror.Backend)
+	}
+	zones := map[string]bool{}
+	for _, b := range backends {
+		list, err := b.Zones.List(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list zones of %s %w", b.Name, err)
+		}
+		for _, z := range list {
+			name := dns.CanonicalName(powerdns.StringValue(z.Name))
+			if mirror.Mirrors(name) {
+				zones[name] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(zones))
+	for z := range zones {
+		names = append(names, z)
+	}
+	sort.Strings(names)
+
+	var (
+		results []*Result
+		errs    []error
+	)
+	for _, z := range names {
+		result, err := Sync(ctx, primary, mirror.Backend, z, opts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	if len(errs) > 0 {
+		return results, fmt.Errorf("%d of %d zones failed, first error %w", len(errs), len(names), errs[0])
+	}
+	return results, nil
+}
+
+// rrsetsOf converts the rrsets of a zone to compare them, names and contents are normalized and the SOA is omitted
+// because every backend maintains its own serial
+func rrsetsOf(zone *powerdns.Zone) []history.RRset {
+	result := []history.RRset{}
+	for _, rset := range zone.RRsets {
+		if rset.Type == nil || *rset.Type == powerdns.RRTypeSOA {
+			continue
+		}
+		name := dns.CanonicalName(powerdns.StringValue(rset.Name))
+		r := history.RRset{
+			Name:    name,
+			Type:    string(*rset.Type),
+			TTL:     powerdns.Uint32Value(rset.TTL),
+			Records: []history.Record{},
+		}
+		for _, record := range rset.Records {
+			content := powerdns.StringValue(record.Content)
+			if normalized, err := propagation.Normalize(name, dns.StringToType[r.Type], []string{content}); err == nil {
+				content = normalized[0]
+			}
+			r.Records = append(r.Records, history.Record{Content: content, Disabled: powerdns.BoolValue(record.Disabled)})
+		}
+		result = append(result, r)
+	}
+	return result
+}
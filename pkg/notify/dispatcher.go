@@ -0,0 +1,232 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/majst01/metal-dns/pkg/events"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultAttempts    = 5
+	DefaultBackoff     = time.Second
+	DefaultMaxBackoff  = 5 * time.Minute
+	DefaultTimeout     = 10 * time.Second
+	DefaultConcurrency = 10
+)
+
+// Config configures the deliveries of a Dispatcher, zero values are replaced by the defaults
+type Config struct {
+	// Attempts is the number of posts of a delivery before it is written to the dead letter log
+	Attempts int
+	// Backoff is the wait before the first retry, it doubles with every further retry up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Timeout of a single post
+	Timeout time.Duration
+	// Concurrency is the number of posts in flight
+	Concurrency int
+	// Client posts the deliveries, it defaults to a client with the Transport of Targets which refuses internal addresses.
+	// Redirects are never followed, they could lead to addresses the webhook was not allowed to use.
+	Client *http.Client
+}
+
+// Dispatcher posts the events of a bus to the matching webhooks
+type Dispatcher struct {
+	log         *zap.SugaredLogger
+	store       Store
+	bus         *events.Bus
+	deadLetters *DeadLetterLog
+	config      Config
+
+	posts chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher for the events of bus and the webhooks of store,
+// deliveries which fail after all attempts are written to deadLetters
+func NewDispatcher(log *zap.SugaredLogger, store Store, bus *events.Bus, deadLetters *DeadLetterLog, config Config) *Dispatcher {
+	if config.Attempts <= 0 {
+		config.Attempts = DefaultAttempts
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = DefaultBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = DefaultMaxBackoff
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultConcurrency
+	}
+	client := &http.Client{Transport: (&Targets{}).Transport()}
+	if config.Client != nil {
+		c := *config.Client
+		client = &c
+	}
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	config.Client = client
+	return &Dispatcher{
+		log:         log.Named("notify"),
+		store:       store,
+		bus:         bus,
+		deadLetters: deadLetters,
+		config:      config,
+		posts:       make(chan struct{}, config.Concurrency),
+	}
+}
+
+// Run dispatches the events until ctx is done and waits for the pending deliveries,
+// deliveries which are still retried then are written to the dead letter log
+func (d *Dispatcher) Run(ctx context.Context) {
+	defer d.wg.Wait()
+	resumeToken := ""
+	for {
+		sub, err := d.bus.Subscribe(resumeToken)
+		if err != nil {
+			d.log.Errorw("events were lost, continuing with the next event", "error", err)
+			resumeToken = ""
+			continue
+		}
+		resumeToken = d.consume(ctx, sub)
+		sub.Close()
+		if ctx.Err() != nil {
+			return
+		}
+		d.log.Warnw("resuming the events", "error", sub.Err())
+	}
+}
+
+// consume dispatches the events of sub until it is closed or ctx is done and returns the token to resume at
+func (d *Dispatcher) consume(ctx context.Context, sub *events.Subscription) string {
+	resumeToken := sub.Token()
+	for {
+		select {
+		case <-ctx.Done():
+			return resumeToken
+		case e, ok := <-sub.Events():
+			if !ok {
+				return resumeToken
+			}
+			resumeToken = d.bus.Token(e.Seq)
+			d.dispatch(ctx, e, resumeToken)
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, e events.Event, id string) {
+	webhooks, err := d.store.List(ctx)
+	if err != nil {
+		d.log.Errorw("unable to list webhooks", "event", id, "error", err)
+		return
+	}
+	var body []byte
+	for _, w := range webhooks {
+		if !w.Matches(e) {
+			continue
+		}
+		if body == nil {
+			body, err = NewPayload(id, e)
+			if err != nil {
+				d.log.Errorw("unable to encode event", "event", id, "error", err)
+				return
+			}
+		}
+		d.wg.Add(1)
+		go func(w *Webhook) {
+			defer d.wg.Done()
+			d.deliver(ctx, w, id, strings.TrimPrefix(e.Type.String(), "EVENT_TYPE_"), body)
+		}(w)
+	}
+}
+
+// deliver posts body to w until it succeeds, fails permanently or all attempts are used
+func (d *Dispatcher) deliver(ctx context.Context, w *Webhook, id, eventType string, body []byte) {
+	backoff := d.config.Backoff
+	attempt := 1
+	for ; ; attempt++ {
+		retry, err := d.post(w, id, eventType, body)
+		if err == nil {
+			d.log.Debugw("delivered", "webhook", w.ID, "event", id, "attempt", attempt)
+			return
+		}
+		d.log.Infow("delivery failed", "webhook", w.ID, "event", id, "attempt", attempt, "error", err)
+		if !retry || attempt >= d.config.Attempts {
+			d.deadLetter(w, attempt, err, body)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			d.deadLetter(w, attempt, fmt.Errorf("shutdown before the next attempt, last error %w", err), body)
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > d.config.MaxBackoff {
+			backoff = d.config.MaxBackoff
+		}
+	}
+}
+
+// post sends a signed delivery, it returns whether a failed post should be retried
+func (d *Dispatcher) post(w *Webhook, id, eventType string, body []byte) (bool, error) {
+	d.posts <- struct{}{}
+	defer func() { <-d.posts }()
+
+	// a delivery which was started is completed on shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), d.config.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, id)
+	req.Header.Set(SignatureHeader, Sign(w.Secret, time.Now(), body))
+
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		// the address of the webhook will not be allowed by retrying
+		return !errors.Is(err, ErrForbiddenTarget), err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("unexpected status %s", resp.Status)
+	// other client errors will not go away by retrying
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return retry, err
+}
+
+func (d *Dispatcher) deadLetter(w *Webhook, attempts int, err error, body []byte) {
+	d.log.Warnw("delivery failed permanently", "webhook", w.ID, "attempts", attempts, "error", err)
+	if d.deadLetters == nil {
+		return
+	}
+	appendErr := d.deadLetters.Append(&DeadLetter{
+		Time:     time.Now(),
+		Webhook:  w.ID,
+		URL:      w.URL,
+		Attempts: attempts,
+		Error:    err.Error(),
+		Payload:  body,
+	})
+	if appendErr != nil {
+		d.log.Errorw("unable to write dead letter", "webhook", w.ID, "error", errors.Join(err, appendErr))
+	}
+}
@@ -0,0 +1,192 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type delivery struct {
+	header http.Header
+	body   []byte
+}
+
+func TestDispatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := zaptest.NewLogger(t).Sugar()
+	dir := t.TempDir()
+
+	var (
+		lock       sync.Mutex
+		deliveries = map[string][]delivery{}
+		received   = make(chan string, 100)
+	)
+	// /ok accepts every delivery, /flaky fails the first attempt, /broken fails always and /gone rejects deliveries
+	failed := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		deliveries[r.URL.Path] = append(deliveries[r.URL.Path], delivery{header: r.Header.Clone(), body: body})
+		status := http.StatusOK
+		switch r.URL.Path {
+		case "/flaky":
+			if !failed[r.Header.Get(DeliveryHeader)] {
+				failed[r.Header.Get(DeliveryHeader)] = true
+				status = http.StatusServiceUnavailable
+			}
+		case "/broken":
+			status = http.StatusInternalServerError
+		case "/gone":
+			status = http.StatusGone
+		}
+		lock.Unlock()
+		w.WriteHeader(status)
+		select {
+		case received <- r.URL.Path:
+		default:
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	for _, w := range []*Webhook{
+		{ID: "ok", URL: server.URL + "/ok", Domains: []string{"a.example.com."}, Secret: "ok-secret"},
+		{ID: "flaky", URL: server.URL + "/flaky", Domains: []string{"*.example.com."}, Secret: "flaky-secret"},
+		{ID: "broken", URL: server.URL + "/broken", Domains: []string{"a.example.com."}, EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_DELETED}},
+		{ID: "gone", URL: server.URL + "/gone", Domains: []string{"b.example.com."}},
+	} {
+		require.NoError(t, store.Put(ctx, w))
+	}
+	deadLetters, err := NewDeadLetterLog(dir)
+	require.NoError(t, err)
+	defer deadLetters.Close()
+
+	bus := events.NewBus(log, 10)
+	loopback := &Targets{Allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}}
+	d := NewDispatcher(log, store, bus, deadLetters, Config{Attempts: 3, Backoff: 10 * time.Millisecond, Client: &http.Client{Transport: loopback.Transport()}})
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx)
+		close(done)
+	}()
+
+	// wait for the subscription of the dispatcher
+	require.Eventually(t, func() bool {
+		bus.Publish(events.Event{Type: v1.EventType_EVENT_TYPE_CREATED, Zone: "a.example.com.", Record: &v1.Record{Name: "www.a.example.com."}})
+		select {
+		case <-received:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 20*time.Millisecond)
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return len(deliveries["/ok"]) > 0 && len(deliveries["/flaky"]) > 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	first := deliveries["/ok"][0]
+	lock.Unlock()
+	require.Equal(t, "CREATED", first.header.Get(EventHeader))
+	require.Equal(t, "application/json", first.header.Get("Content-Type"))
+	require.NoError(t, Verify("ok-secret", first.header.Get(SignatureHeader), first.body, time.Minute))
+	require.ErrorIs(t, Verify("flaky-secret", first.header.Get(SignatureHeader), first.body, time.Minute), ErrInvalidSignature)
+
+	bus.Publish(
+		events.Event{Type: v1.EventType_EVENT_TYPE_DELETED, Zone: "a.example.com.", Record: &v1.Record{Name: "www.a.example.com."}},
+		events.Event{Type: v1.EventType_EVENT_TYPE_CREATED, Zone: "b.example.com.", Domain: &v1.Domain{Name: "b.example.com."}},
+	)
+
+	// broken is attempted three times, gone once
+	require.Eventually(t, func() bool {
+		letters, err := ReadDeadLetters(dir)
+		require.NoError(t, err)
+		return len(letters) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	letters, err := ReadDeadLetters(dir)
+	require.NoError(t, err)
+	byWebhook := map[string]*DeadLetter{}
+	for _, l := range letters {
+		byWebhook[l.Webhook] = l
+	}
+	require.Equal(t, 3, byWebhook["broken"].Attempts)
+	require.Equal(t, "unexpected status 500 Internal Server Error", byWebhook["broken"].Error)
+	require.Contains(t, string(byWebhook["broken"].Payload), `"type":"DELETED"`)
+	require.Equal(t, 1, byWebhook["gone"].Attempts)
+	require.Equal(t, server.URL+"/gone", byWebhook["gone"].URL)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("dispatcher did not stop")
+	}
+}
+
+func TestDispatcherRefusesTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	log := zaptest.NewLogger(t).Sugar()
+	dir := t.TempDir()
+
+	var (
+		lock  sync.Mutex
+		paths []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		paths = append(paths, r.URL.Path)
+		lock.Unlock()
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "/internal", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer server.Close()
+
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(ctx, &Webhook{ID: "loopback", URL: server.URL + "/loopback", Domains: []string{"a.example.com."}}))
+	deadLetters, err := NewDeadLetterLog(dir)
+	require.NoError(t, err)
+	defer deadLetters.Close()
+
+	bus := events.NewBus(log, 10)
+	d := NewDispatcher(log, store, bus, deadLetters, Config{Attempts: 3, Backoff: 10 * time.Millisecond})
+	go d.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		bus.Publish(events.Event{Type: v1.EventType_EVENT_TYPE_CREATED, Zone: "a.example.com.", Record: &v1.Record{Name: "www.a.example.com."}})
+		letters, err := ReadDeadLetters(dir)
+		require.NoError(t, err)
+		return len(letters) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+	letters, err := ReadDeadLetters(dir)
+	require.NoError(t, err)
+	require.Equal(t, 1, letters[0].Attempts, "refused addresses are not retried")
+	require.Contains(t, letters[0].Error, ErrForbiddenTarget.Error())
+
+	// redirects are not followed even to allowed addresses
+	loopback := &Targets{Allowed: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}}
+	d = NewDispatcher(log, store, bus, nil, Config{Client: &http.Client{Transport: loopback.Transport()}})
+	retry, err := d.post(&Webhook{URL: server.URL + "/redirect"}, "1", "CREATED", []byte("{}"))
+	require.False(t, retry)
+	require.EqualError(t, err, "unexpected status 307 Temporary Redirect")
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(t, []string{"/redirect"}, paths)
+}
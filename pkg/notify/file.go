@@ -0,0 +1,210 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	webhooksFile    = "webhooks.json"
+	deadLettersFile = "dead-letters.jsonl"
+)
+
+// FileStore keeps all webhooks in a single json file, which is replaced atomically on every change
+type FileStore struct {
+	lock sync.Mutex
+	path string
+}
+
+// NewFileStore stores the webhooks in dir, which is created if it does not exist
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create webhook directory %w", err)
+	}
+	return &FileStore{path: filepath.Join(dir, webhooksFile)}, nil
+}
+
+// List returns all webhooks ordered by their creation
+func (s *FileStore) List(ctx context.Context) ([]*Webhook, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	webhooks, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*Webhook, 0, len(webhooks))
+	for _, w := range webhooks {
+		result = append(result, w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Get returns the webhook with id or ErrNotFound
+func (s *FileStore) Get(ctx context.Context, id string) (*Webhook, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	webhooks, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	w, ok := webhooks[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return w, nil
+}
+
+// Put creates or replaces w
+func (s *FileStore) Put(ctx context.Context, w *Webhook) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	webhooks, err := s.read()
+	if err != nil {
+		return err
+	}
+	webhooks[w.ID] = w
+	return s.write(webhooks)
+}
+
+// Delete removes the webhook with id or returns ErrNotFound
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	webhooks, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := webhooks[id]; !ok {
+		return ErrNotFound
+	}
+	delete(webhooks, id)
+	return s.write(webhooks)
+}
+
+func (s *FileStore) read() (map[string]*Webhook, error) {
+	webhooks := map[string]*Webhook{}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return webhooks, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read webhooks %w", err)
+	}
+	err = json.Unmarshal(data, &webhooks)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks are corrupt %w", err)
+	}
+	return webhooks, nil
+}
+
+// write replaces the file with a synced temporary file, readers never see a partial write
+func (s *FileStore) write(webhooks map[string]*Webhook) error {
+	data, err := json.MarshalIndent(webhooks, "", "  ")
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(s.path), webhooksFile+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write webhooks %w", err)
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write webhooks %w", err)
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// DeadLetter is a delivery which failed after all attempts
+type DeadLetter struct {
+	Time     time.Time       `json:"time"`
+	Webhook  string          `json:"webhook"`
+	URL      string          `json:"url"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	Payload  json.RawMessage `json:"payload"`
+}
+
+// DeadLetterLog appends failed deliveries as json lines to a file, they can be replayed from there
+type DeadLetterLog struct {
+	lock sync.Mutex
+	f    *os.File
+}
+
+// NewDeadLetterLog opens or creates the dead letter log in dir
+func NewDeadLetterLog(dir string) (*DeadLetterLog, error) {
+	f, err := os.OpenFile(filepath.Join(dir, deadLettersFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open dead letter log %w", err)
+	}
+	return &DeadLetterLog{f: f}, nil
+}
+
+// Append writes d to the end of the log and syncs the file
+func (l *DeadLetterLog) Append(d *DeadLetter) error {
+	line, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	_, err = l.f.Write(line)
+	if err != nil {
+		return err
+	}
+	return l.f.Sync()
+}
+
+// Close closes the dead letter log
+func (l *DeadLetterLog) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.f.Close()
+}
+
+// ReadDeadLetters returns the dead letters of the log in dir in the order they were written
+func ReadDeadLetters(dir string) ([]*DeadLetter, error) {
+	f, err := os.Open(filepath.Join(dir, deadLettersFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open dead letter log %w", err)
+	}
+	defer f.Close()
+
+	var result []*DeadLetter
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var d DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			return nil, fmt.Errorf("dead letter log is corrupt %w", err)
+		}
+		result = append(result, &d)
+	}
+	return result, scanner.Err()
+}
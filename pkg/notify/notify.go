@@ -0,0 +1,189 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/events"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// SignatureHeader carries the timestamp and the HMAC-SHA256 signature of a delivery in the form t=<unix seconds>,v1=<hex>
+	SignatureHeader = "X-Metal-Dns-Signature"
+	// EventHeader is the type of the event, e.g. CREATED
+	EventHeader = "X-Metal-Dns-Event"
+	// DeliveryHeader is the id of the event, it is the same for all attempts and webhooks
+	DeliveryHeader = "X-Metal-Dns-Delivery"
+)
+
+var (
+	// ErrNotFound is returned if no webhook with the requested id exists
+	ErrNotFound = errors.New("webhook not found")
+	// ErrInvalidSignature is returned by Verify if a delivery was not signed with the secret
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Webhook posts the changes of the zones which match one of its domains to its url
+type Webhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Domains are patterns of zones, a * matches any labels, e.g. *.example.com.
+	Domains []string `json:"domains"`
+	// EventTypes limit the posted events, all changes are posted if empty
+	EventTypes  []v1.EventType `json:"event_types,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Secret      string         `json:"secret"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// Matches returns true if e is posted to w
+func (w *Webhook) Matches(e events.Event) bool {
+	if e.Type == v1.EventType_EVENT_TYPE_BOOKMARK {
+		return false
+	}
+	if len(w.EventTypes) > 0 {
+		found := false
+		for _, t := range w.EventTypes {
+			if t == e.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, pattern := range w.Domains {
+		if MatchDomain(pattern, e.Zone) {
+			return true
+		}
+	}
+	return false
+}
+
+// Store keeps the webhooks
+type Store interface {
+	List(ctx context.Context) ([]*Webhook, error)
+	// Get returns the webhook with id or ErrNotFound
+	Get(ctx context.Context, id string) (*Webhook, error)
+	// Put creates or replaces w
+	Put(ctx context.Context, w *Webhook) error
+	// Delete removes the webhook with id or returns ErrNotFound
+	Delete(ctx context.Context, id string) error
+}
+
+// ValidateDomain checks that pattern is a fully qualified domain name, which may contain * as wildcard
+func ValidateDomain(pattern string) error {
+	if !strings.HasSuffix(pattern, ".") || len(pattern) < 2 {
+		return fmt.Errorf("domain pattern %q must be fully qualified and end with a dot", pattern)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("domain pattern %q is malformed %w", pattern, err)
+	}
+	return nil
+}
+
+// MatchDomain returns true if zone matches pattern, a * matches any labels
+func MatchDomain(pattern, zone string) bool {
+	ok, err := path.Match(strings.ToLower(pattern), strings.ToLower(zone))
+	return err == nil && ok
+}
+
+// GenerateSecret returns a random secret to sign deliveries with
+func GenerateSecret() (string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// Payload is the json body of a delivery
+type Payload struct {
+	// ID is the id of the event, receivers can use it to detect duplicates
+	ID   string    `json:"id"`
+	Type string    `json:"type"`
+	Zone string    `json:"zone"`
+	Time time.Time `json:"time"`
+	// Domain and Record are encoded like the api does
+	Domain json.RawMessage `json:"domain,omitempty"`
+	Record json.RawMessage `json:"record,omitempty"`
+}
+
+// NewPayload encodes e, id identifies the event
+func NewPayload(id string, e events.Event) ([]byte, error) {
+	p := Payload{
+		ID:   id,
+		Type: strings.TrimPrefix(e.Type.String(), "EVENT_TYPE_"),
+		Zone: e.Zone,
+		Time: e.Time,
+	}
+	var err error
+	if e.Domain != nil {
+		p.Domain, err = protojson.Marshal(e.Domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if e.Record != nil {
+		p.Record, err = protojson.Marshal(e.Record)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(p)
+}
+
+// Sign returns the value of the SignatureHeader for body, the signed content is the timestamp, a dot and the body
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	return "t=" + t + ",v1=" + signature(secret, t, body)
+}
+
+// Verify checks the value of the SignatureHeader of a delivery, signatures older than tolerance are rejected to prevent replays.
+// A zero tolerance accepts signatures of any age.
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var t, sig string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			t = value
+		case "v1":
+			sig = value
+		}
+	}
+	unix, err := strconv.ParseInt(t, 10, 64)
+	if err != nil || sig == "" {
+		return fmt.Errorf("malformed signature header %q", header)
+	}
+	if !hmac.Equal([]byte(sig), []byte(signature(secret, t, body))) {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && time.Since(time.Unix(unix, 0)) > tolerance {
+		return fmt.Errorf("signature is older than %s", tolerance)
+	}
+	return nil
+}
+
+func signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,127 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Now()
+	header := Sign("secret", now, body)
+
+	require.NoError(t, Verify("secret", header, body, time.Minute))
+	require.ErrorIs(t, Verify("other", header, body, time.Minute), ErrInvalidSignature)
+	require.ErrorIs(t, Verify("secret", header, []byte(`{"id":"2"}`), time.Minute), ErrInvalidSignature)
+	require.EqualError(t, Verify("secret", "v1=abc", body, time.Minute), `malformed signature header "v1=abc"`)
+
+	old := Sign("secret", now.Add(-time.Hour), body)
+	require.EqualError(t, Verify("secret", old, body, time.Minute), "signature is older than 1m0s")
+	require.NoError(t, Verify("secret", old, body, 0))
+}
+
+func TestMatches(t *testing.T) {
+	created := events.Event{Type: v1.EventType_EVENT_TYPE_CREATED, Zone: "a.example.com."}
+	deleted := events.Event{Type: v1.EventType_EVENT_TYPE_DELETED, Zone: "a.example.com."}
+
+	tests := []struct {
+		name    string
+		webhook Webhook
+		event   events.Event
+		want    bool
+	}{
+		{name: "exact", webhook: Webhook{Domains: []string{"a.example.com."}}, event: created, want: true},
+		{name: "case insensitive", webhook: Webhook{Domains: []string{"A.Example.com."}}, event: created, want: true},
+		{name: "wildcard", webhook: Webhook{Domains: []string{"*.example.com."}}, event: created, want: true},
+		{name: "wildcard does not match the parent", webhook: Webhook{Domains: []string{"*.a.example.com."}}, event: created, want: false},
+		{name: "other domain", webhook: Webhook{Domains: []string{"b.example.com."}}, event: created, want: false},
+		{name: "event type", webhook: Webhook{Domains: []string{"*"}, EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_DELETED}}, event: deleted, want: true},
+		{name: "other event type", webhook: Webhook{Domains: []string{"*"}, EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_DELETED}}, event: created, want: false},
+		{name: "bookmark", webhook: Webhook{Domains: []string{"*"}}, event: events.Event{Type: v1.EventType_EVENT_TYPE_BOOKMARK}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.webhook.Matches(tt.event))
+		})
+	}
+}
+
+func TestValidateDomain(t *testing.T) {
+	require.NoError(t, ValidateDomain("example.com."))
+	require.NoError(t, ValidateDomain("*.example.com."))
+	require.EqualError(t, ValidateDomain("example.com"), `domain pattern "example.com" must be fully qualified and end with a dot`)
+	require.EqualError(t, ValidateDomain("[.example.com."), `domain pattern "[.example.com." is malformed syntax error in pattern`)
+}
+
+func TestNewPayload(t *testing.T) {
+	now := time.Date(2023, 8, 1, 12, 0, 0, 0, time.UTC)
+	body, err := NewPayload("abc.1", events.Event{
+		Type:   v1.EventType_EVENT_TYPE_CREATED,
+		Time:   now,
+		Zone:   "a.example.com.",
+		Record: &v1.Record{Name: "www.a.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4", Ttl: 300},
+	})
+	require.NoError(t, err)
+
+	var p map[string]any
+	require.NoError(t, json.Unmarshal(body, &p))
+	require.Equal(t, map[string]any{
+		"id":   "abc.1",
+		"type": "CREATED",
+		"zone": "a.example.com.",
+		"time": "2023-08-01T12:00:00Z",
+		"record": map[string]any{
+			"name": "www.a.example.com.",
+			"type": "A",
+			"data": "1.2.3.4",
+			"ttl":  float64(300),
+		},
+	}, p)
+}
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewFileStore(dir)
+	require.NoError(t, err)
+
+	webhooks, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, webhooks)
+
+	now := time.Now().UTC()
+	a := &Webhook{ID: "a", URL: "https://a.example.com/hook", Domains: []string{"a.example.com."}, Secret: "s", CreatedAt: now.Add(time.Second)}
+	b := &Webhook{ID: "b", URL: "https://b.example.com/hook", Domains: []string{"*.b.example.com."}, CreatedAt: now}
+	require.NoError(t, store.Put(ctx, a))
+	require.NoError(t, store.Put(ctx, b))
+
+	// a new store reads the file written by the first one
+	store, err = NewFileStore(dir)
+	require.NoError(t, err)
+	webhooks, err = store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, webhooks, 2)
+	require.Equal(t, "b", webhooks[0].ID)
+	require.Equal(t, "a", webhooks[1].ID)
+
+	got, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "s", got.Secret)
+
+	a.URL = "https://a.example.com/other"
+	require.NoError(t, store.Put(ctx, a))
+	got, err = store.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "https://a.example.com/other", got.URL)
+
+	require.NoError(t, store.Delete(ctx, "a"))
+	_, err = store.Get(ctx, "a")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, store.Delete(ctx, "a"), ErrNotFound)
+}
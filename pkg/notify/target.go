@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenTarget is returned if a webhook url resolves to an address which is not allowed, see Targets
+var ErrForbiddenTarget = errors.New("loopback, private and link-local addresses are not allowed as webhook target")
+
+// internalNetworks are refused in addition to the loopback, private, link-local, multicast and unspecified addresses
+var internalNetworks = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	// shared address space of carrier grade nat, often used for cluster internal networks
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// Targets decides to which addresses webhooks may post. Loopback, private and link-local addresses are refused,
+// otherwise any token allowed to create webhooks could make the server post to internal services.
+// The zero value refuses them all.
+type Targets struct {
+	// Allowed are internal networks which may be posted to nevertheless
+	Allowed []netip.Prefix
+}
+
+// ParseTargets creates targets which allow the networks given in cidr notation, e.g. 10.0.0.0/8
+func ParseTargets(networks []string) (*Targets, error) {
+	t := &Targets{}
+	for _, n := range networks {
+		p, err := netip.ParsePrefix(n)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q %w", n, err)
+		}
+		t.Allowed = append(t.Allowed, p.Masked())
+	}
+	return t, nil
+}
+
+// Allows returns true if webhooks may post to addr
+func (t *Targets) Allows(addr netip.Addr) bool {
+	addr = addr.Unmap().WithZone("")
+	for _, p := range t.Allowed {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() {
+		return false
+	}
+	for _, p := range internalNetworks {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL returns ErrForbiddenTarget if the host of rawURL is or resolves to an address which is not allowed.
+// Hosts which can not be resolved yet are accepted, the addresses are checked again with every post, see Transport.
+func (t *Targets) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	host := u.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return t.check(addr)
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := t.check(addr); err != nil {
+			return fmt.Errorf("host %s %w", host, err)
+		}
+	}
+	return nil
+}
+
+// Transport returns a transport which refuses to connect to addresses that are not allowed.
+// The addresses are checked after the resolution of every dial, a changed dns record can not bypass the check.
+// Proxies of the environment are not used, they would connect to the webhook instead.
+func (t *Targets) Transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			return t.check(addrPort.Addr())
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return transport
+}
+
+func (t *Targets) check(addr netip.Addr) error {
+	if !t.Allows(addr) {
+		return fmt.Errorf("address %s %w", addr, ErrForbiddenTarget)
+	}
+	return nil
+}
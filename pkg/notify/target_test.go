@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTargets(t *testing.T) {
+	targets, err := ParseTargets([]string{"10.1.0.0/16", "fd00::1/64"})
+	require.NoError(t, err)
+	require.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.1.0.0/16"), netip.MustParsePrefix("fd00::/64")}, targets.Allowed)
+
+	for addr, want := range map[string]bool{
+		"1.2.3.4":          true,
+		"2001:db8::1":      true,
+		"127.0.0.1":        false,
+		"::1":              false,
+		"::ffff:127.0.0.1": false,
+		"10.0.0.1":         false,
+		"10.1.2.3":         true,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"fe80::1%eth0":     false,
+		"fd00::2":          true,
+		"fd01::2":          false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"224.0.0.1":        false,
+	} {
+		require.Equal(t, want, targets.Allows(netip.MustParseAddr(addr)), addr)
+	}
+
+	_, err = ParseTargets([]string{"10.0.0.1"})
+	require.EqualError(t, err, `invalid network "10.0.0.1" netip.ParsePrefix("10.0.0.1"): no '/'`)
+}
+
+func TestTargetsCheckURL(t *testing.T) {
+	targets := &Targets{}
+	ctx := context.Background()
+	require.NoError(t, targets.CheckURL(ctx, "https://1.2.3.4/hook"))
+	require.NoError(t, targets.CheckURL(ctx, "https://unresolvable.invalid/hook"))
+	require.ErrorIs(t, targets.CheckURL(ctx, "http://169.254.169.254/latest/meta-data"), ErrForbiddenTarget)
+	require.ErrorIs(t, targets.CheckURL(ctx, "http://[::1]:8080/"), ErrForbiddenTarget)
+	require.ErrorIs(t, targets.CheckURL(ctx, "http://localhost:8080/"), ErrForbiddenTarget)
+}
@@ -0,0 +1,16 @@
+package api.v1.metalstack.io.authz
+
+# entries are additionally filtered by the domains of the token in the service
+e = {"permission": permissions["/api.v1.AuditService/List"], "public": false} {
+	input.method == "/api.v1.AuditService/List"
+	input.method == token.payload.permissions[_]
+	audit_domain_allowed
+}
+
+audit_domain_allowed {
+	not input.request.domain
+}
+
+audit_domain_allowed {
+	in_domain(input.request.domain, token.payload.domains[_])
+}
@@ -0,0 +1,46 @@
+package api.v1.metalstack.io.authz
+
+test_list_audit_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.AuditService/List",
+		"request": {},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_list_audit_of_domain_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.AuditService/List",
+		"request": {"domain": "www.a.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_list_audit_of_other_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.AuditService/List",
+		"request": {"domain": "c.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_list_audit_of_neighbour_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.AuditService/List",
+		"request": {"domain": "xa.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_list_audit_not_allowed_with_wrong_jwt {
+	not decision.allow with input as {
+		"method": "/api.v1.AuditService/List",
+		"request": {},
+		"token": jwt_with_wrong_secret,
+	}
+		with data.secret as secret
+}
@@ -0,0 +1,6 @@
+package api.v1.metalstack.io.authz
+
+# every valid token is allowed to explain its own decisions
+e = {"permission": permissions["/api.v1.AuthzService/Explain"], "public": false} {
+	input.method == "/api.v1.AuthzService/Explain"
+}
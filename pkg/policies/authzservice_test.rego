@@ -0,0 +1,19 @@
+package api.v1.metalstack.io.authz
+
+test_explain_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.AuthzService/Explain",
+		"request": {"method": "/api.v1.DomainService/Get"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_explain_not_allowed_with_wrong_jwt {
+	not decision.allow with input as {
+		"method": "/api.v1.AuthzService/Explain",
+		"request": {"method": "/api.v1.DomainService/Get"},
+		"token": jwt_with_wrong_secret,
+	}
+		with data.secret as secret
+}
@@ -0,0 +1,19 @@
+package api.v1.metalstack.io.authz
+
+# challenges can only be placed below the domains of the token, compared by labels, and only at _acme-challenge names
+e = {"permission": permissions["/api.v1.ChallengeService/Present"], "public": false} {
+	input.method == "/api.v1.ChallengeService/Present"
+	input.method == token.payload.permissions[_]
+	challenge_name_allowed
+}
+
+e = {"permission": permissions["/api.v1.ChallengeService/CleanUp"], "public": false} {
+	input.method == "/api.v1.ChallengeService/CleanUp"
+	input.method == token.payload.permissions[_]
+	challenge_name_allowed
+}
+
+challenge_name_allowed {
+	startswith(input.request.name, "_acme-challenge.")
+	in_domain(input.request.name, token.payload.domains[_])
+}
@@ -0,0 +1,64 @@
+package api.v1.metalstack.io.authz
+
+test_present_challenge_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.ChallengeService/Present",
+		"request": {"name": "_acme-challenge.www.a.example.com", "value": "abc"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_present_challenge_of_other_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.ChallengeService/Present",
+		"request": {"name": "_acme-challenge.www.c.example.com", "value": "abc"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_present_challenge_of_neighbour_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.ChallengeService/Present",
+		"request": {"name": "_acme-challenge.xa.example.com", "value": "abc"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_cleanup_challenge_of_neighbour_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.ChallengeService/CleanUp",
+		"request": {"name": "_acme-challenge.xa.example.com", "value": "abc"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_present_challenge_without_prefix_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.ChallengeService/Present",
+		"request": {"name": "www.a.example.com", "value": "abc"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_cleanup_challenge_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.ChallengeService/CleanUp",
+		"request": {"name": "_acme-challenge.a.example.com", "value": "abc"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_cleanup_challenge_not_allowed_with_wrong_jwt {
+	not decision.allow with input as {
+		"method": "/api.v1.ChallengeService/CleanUp",
+		"request": {"name": "_acme-challenge.a.example.com", "value": "abc"},
+		"token": jwt_with_wrong_secret,
+	}
+		with data.secret as secret
+}
@@ -32,3 +32,8 @@ decision = {"allow": false, "isAdmin": false, "reason": reason} {
 decision = {"allow": true, "isAdmin": false} {
 	e.public
 }
+
+decision = {"allow": false, "isAdmin": false, "reason": reason} {
+	not e
+	reason := sprintf("no rule allows %s with this token and request", [input.method])
+}
@@ -29,6 +29,30 @@ e = {"permission": permissions["/api.v1.DomainService/Delete"], "public": false}
 	input.request.name == token.payload.domains[_]
 }
 
+e = {"permission": permissions["/api.v1.DomainService/ListRevisions"], "public": false} {
+	input.method == "/api.v1.DomainService/ListRevisions"
+	input.method == token.payload.permissions[_]
+	input.request.name == token.payload.domains[_]
+}
+
+e = {"permission": permissions["/api.v1.DomainService/DiffRevisions"], "public": false} {
+	input.method == "/api.v1.DomainService/DiffRevisions"
+	input.method == token.payload.permissions[_]
+	input.request.name == token.payload.domains[_]
+}
+
+e = {"permission": permissions["/api.v1.DomainService/Rollback"], "public": false} {
+	input.method == "/api.v1.DomainService/Rollback"
+	input.method == token.payload.permissions[_]
+	input.request.name == token.payload.domains[_]
+}
+
+# the domains of the watch are filtered by the domains of the token like the ones of list
+e = {"permission": permissions["/api.v1.DomainService/Watch"], "public": false} {
+	input.method == "/api.v1.DomainService/Watch"
+	input.method == token.payload.permissions[_]
+}
+
 domain_name_allowed {
 	some i
 	domain := token.payload.domains[i]
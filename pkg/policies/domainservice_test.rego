@@ -55,3 +55,48 @@ test_create_domains_not_allowed {
 	}
 		with data.secret as secret
 }
+
+test_list_revisions_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.DomainService/ListRevisions",
+		"request": {"name": "a.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_diff_revisions_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.DomainService/DiffRevisions",
+		"request": {"name": "a.example.com", "from": 1},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_rollback_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.DomainService/Rollback",
+		"request": {"name": "a.example.com", "revision": 1},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_rollback_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.DomainService/Rollback",
+		"request": {"name": "example.com", "revision": 1},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_watch_domains_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.DomainService/Watch",
+		"request": {"domains": ["a.example.com"]},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
@@ -23,10 +23,24 @@ jwt := io.jwt.encode_sign(
 			"/api.v1.DomainService/Create",
 			"/api.v1.DomainService/Update",
 			"/api.v1.DomainService/Delete",
+			"/api.v1.DomainService/ListRevisions",
+			"/api.v1.DomainService/DiffRevisions",
+			"/api.v1.DomainService/Rollback",
+			"/api.v1.DomainService/Watch",
 			"/api.v1.RecordService/List",
 			"/api.v1.RecordService/Create",
 			"/api.v1.RecordService/Update",
 			"/api.v1.RecordService/Delete",
+			"/api.v1.RecordService/Verify",
+			"/api.v1.RecordService/Watch",
+			"/api.v1.ChallengeService/Present",
+			"/api.v1.ChallengeService/CleanUp",
+			"/api.v1.WebhookService/Create",
+			"/api.v1.WebhookService/Get",
+			"/api.v1.WebhookService/List",
+			"/api.v1.WebhookService/Update",
+			"/api.v1.WebhookService/Delete",
+			"/api.v1.AuditService/List",
 		],
 	},
 	{
@@ -1,16 +1,56 @@
 package api.v1.metalstack.io.authz
 
-permissions = {
-	"/api.v1.TokenService/Create",
-	"/api.v1.DomainService/Get",
-	"/api.v1.DomainService/List",
-	"/api.v1.DomainService/Create",
-	"/api.v1.DomainService/Update",
-	"/api.v1.DomainService/Delete",
-	"/api.v1.RecordService/List",
-	"/api.v1.RecordService/Create",
-	"/api.v1.RecordService/Update",
-	"/api.v1.RecordService/Delete",
-}
+import future.keywords.contains
+
+# permissions is a partial set, modules loaded from the policy path can add permissions for further methods
+permissions contains "/api.v1.AuthzService/Explain"
+
+permissions contains "/api.v1.TokenService/Create"
+
+permissions contains "/api.v1.DomainService/Get"
+
+permissions contains "/api.v1.DomainService/List"
+
+permissions contains "/api.v1.DomainService/Create"
+
+permissions contains "/api.v1.DomainService/Update"
+
+permissions contains "/api.v1.DomainService/Delete"
+
+permissions contains "/api.v1.DomainService/ListRevisions"
+
+permissions contains "/api.v1.DomainService/DiffRevisions"
+
+permissions contains "/api.v1.DomainService/Rollback"
+
+permissions contains "/api.v1.DomainService/Watch"
+
+permissions contains "/api.v1.RecordService/List"
+
+permissions contains "/api.v1.RecordService/Create"
+
+permissions contains "/api.v1.RecordService/Update"
+
+permissions contains "/api.v1.RecordService/Delete"
+
+permissions contains "/api.v1.RecordService/Verify"
+
+permissions contains "/api.v1.RecordService/Watch"
+
+permissions contains "/api.v1.ChallengeService/Present"
+
+permissions contains "/api.v1.ChallengeService/CleanUp"
+
+permissions contains "/api.v1.WebhookService/Create"
+
+permissions contains "/api.v1.WebhookService/Get"
+
+permissions contains "/api.v1.WebhookService/List"
+
+permissions contains "/api.v1.WebhookService/Update"
+
+permissions contains "/api.v1.WebhookService/Delete"
+
+permissions contains "/api.v1.AuditService/List"
 
 # FIXME: verify that all permissions have a one rule
@@ -21,7 +21,28 @@ e = {"permission": permissions["/api.v1.RecordService/Update"], "public": false}
 e = {"permission": permissions["/api.v1.RecordService/Delete"], "public": false} {
 	input.method == "/api.v1.RecordService/Delete"
 	input.method == token.payload.permissions[_]
-	input.request.name == token.payload.domains[_]
+	in_domain(input.request.name, token.payload.domains[_])
+}
+
+e = {"permission": permissions["/api.v1.RecordService/Verify"], "public": false} {
+	input.method == "/api.v1.RecordService/Verify"
+	input.method == token.payload.permissions[_]
+	endswith(input.request.name, token.payload.domains[_])
+}
+
+e = {"permission": permissions["/api.v1.RecordService/Watch"], "public": false} {
+	input.method == "/api.v1.RecordService/Watch"
+	input.method == token.payload.permissions[_]
+	record_watch_allowed
+}
+
+# without domain the records of all domains of the token are watched
+record_watch_allowed {
+	object.get(input.request, "domain", "") == ""
+}
+
+record_watch_allowed {
+	in_domain(input.request.domain, token.payload.domains[_])
 }
 
 domain_name_allowed {
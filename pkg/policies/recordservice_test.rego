@@ -37,3 +37,93 @@ test_create_records_allowed {
 	}
 		with data.secret as secret
 }
+
+test_delete_records_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.RecordService/Delete",
+		"request": {"name": "www.a.example.com."},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_delete_domain_records_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.RecordService/Delete",
+		"request": {"name": "a.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_delete_records_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.RecordService/Delete",
+		"request": {"name": "www.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_delete_records_of_neighbour_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.RecordService/Delete",
+		"request": {"name": "www.xa.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_verify_records_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.RecordService/Verify",
+		"request": {"name": "www.a.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_verify_records_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.RecordService/Verify",
+		"request": {"name": "www.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_watch_records_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.RecordService/Watch",
+		"request": {"domain": "a.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_watch_records_of_all_domains_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.RecordService/Watch",
+		"request": {},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_watch_records_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.RecordService/Watch",
+		"request": {"domain": "example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_watch_records_of_neighbour_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.RecordService/Watch",
+		"request": {"domain": "xa.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
@@ -10,3 +10,12 @@ is_token_valid {
 token := {"valid": valid, "payload": payload} {
 	[valid, _, payload] := io.jwt.decode_verify(input.token, {"secret": data.secret})
 }
+
+# in_domain is true if name is domain or a name below it, the labels must match completely and trailing dots are ignored
+in_domain(name, domain) {
+	trim_suffix(name, ".") == trim_suffix(domain, ".")
+}
+
+in_domain(name, domain) {
+	endswith(trim_suffix(name, "."), concat("", [".", trim_suffix(domain, ".")]))
+}
@@ -0,0 +1,44 @@
+package api.v1.metalstack.io.authz
+
+# webhooks can only be created for patterns below the domains of the token,
+# the service hides webhooks of other domains from get, list, update and delete
+e = {"permission": permissions["/api.v1.WebhookService/Create"], "public": false} {
+	input.method == "/api.v1.WebhookService/Create"
+	input.method == token.payload.permissions[_]
+	webhook_domains_allowed
+}
+
+e = {"permission": permissions["/api.v1.WebhookService/Get"], "public": false} {
+	input.method == "/api.v1.WebhookService/Get"
+	input.method == token.payload.permissions[_]
+}
+
+e = {"permission": permissions["/api.v1.WebhookService/List"], "public": false} {
+	input.method == "/api.v1.WebhookService/List"
+	input.method == token.payload.permissions[_]
+}
+
+e = {"permission": permissions["/api.v1.WebhookService/Update"], "public": false} {
+	input.method == "/api.v1.WebhookService/Update"
+	input.method == token.payload.permissions[_]
+	webhook_domains_allowed
+}
+
+e = {"permission": permissions["/api.v1.WebhookService/Delete"], "public": false} {
+	input.method == "/api.v1.WebhookService/Delete"
+	input.method == token.payload.permissions[_]
+}
+
+webhook_domains_allowed {
+	count(input.request.domains) > 0
+	not webhook_domain_denied
+}
+
+webhook_domain_denied {
+	domain := input.request.domains[_]
+	not webhook_domain_allowed(domain)
+}
+
+webhook_domain_allowed(domain) {
+	endswith(domain, token.payload.domains[_])
+}
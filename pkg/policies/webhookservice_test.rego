@@ -0,0 +1,55 @@
+package api.v1.metalstack.io.authz
+
+test_create_webhook_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.WebhookService/Create",
+		"request": {"url": "https://hooks.example.com", "domains": ["a.example.com", "*.b.example.com"]},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_create_webhook_of_other_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.WebhookService/Create",
+		"request": {"url": "https://hooks.example.com", "domains": ["a.example.com", "c.example.com"]},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_create_webhook_without_domains_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.WebhookService/Create",
+		"request": {"url": "https://hooks.example.com"},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_update_webhook_of_other_domain_not_allowed {
+	not decision.allow with input as {
+		"method": "/api.v1.WebhookService/Update",
+		"request": {"id": "1", "url": "https://hooks.example.com", "domains": ["*.example.com"]},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_list_webhooks_allowed {
+	decision.allow with input as {
+		"method": "/api.v1.WebhookService/List",
+		"request": {},
+		"token": jwt,
+	}
+		with data.secret as secret
+}
+
+test_delete_webhook_not_allowed_without_permission {
+	not decision.allow with input as {
+		"method": "/api.v1.WebhookService/Delete",
+		"request": {"id": "1"},
+		"token": jwt_with_wrong_domains,
+	}
+		with data.secret as secret
+}
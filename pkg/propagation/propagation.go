@@ -0,0 +1,221 @@
+package propagation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const (
+	DefaultTimeout      = 60 * time.Second
+	DefaultInterval     = 2 * time.Second
+	DefaultQueryTimeout = 2 * time.Second
+)
+
+// Config configures a Verifier, zero values are replaced by the defaults
+type Config struct {
+	// Nameservers are queried instead of the nameservers in the NS records of a zone, in the form host:port
+	Nameservers []string
+	// Timeout is the longest duration to wait for a rrset to propagate
+	Timeout time.Duration
+	// Interval between two rounds of queries
+	Interval time.Duration
+	// QueryTimeout is the timeout of a single query
+	QueryTimeout time.Duration
+	// Resolver looks up the addresses of the nameservers, defaults to net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// Verifier queries the authoritative nameservers of a zone whether they serve a rrset
+type Verifier struct {
+	log    *zap.SugaredLogger
+	config Config
+	udp    *dns.Client
+	tcp    *dns.Client
+}
+
+// New creates a Verifier
+func New(log *zap.SugaredLogger, config Config) *Verifier {
+	if config.Timeout == 0 {
+		config.Timeout = DefaultTimeout
+	}
+	if config.Interval == 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.QueryTimeout == 0 {
+		config.QueryTimeout = DefaultQueryTimeout
+	}
+	if config.Resolver == nil {
+		config.Resolver = net.DefaultResolver
+	}
+	return &Verifier{
+		log:    log.Named("propagation"),
+		config: config,
+		udp:    &dns.Client{Net: "udp", Timeout: config.QueryTimeout},
+		tcp:    &dns.Client{Net: "tcp", Timeout: config.QueryTimeout},
+	}
+}
+
+// Timeout is the longest duration Wait may be called with
+func (v *Verifier) Timeout() time.Duration {
+	return v.config.Timeout
+}
+
+// Wait queries the nameservers until all of them serve the expected rrset or timeout passes, with a zero timeout they are queried once.
+// nameservers are the targets of the NS records of the zone, expected is the content of the rrset, empty if the rrset must not exist.
+// The status of the last round of queries is returned, it is not an error if the rrset did not propagate.
+func (v *Verifier) Wait(ctx context.Context, nameservers []string, name string, rrtype uint16, expected []string, timeout time.Duration) (*v1.Propagation, error) {
+	want, err := Normalize(name, rrtype, expected)
+	if err != nil {
+		return nil, err
+	}
+	if len(v.config.Nameservers) == 0 && len(nameservers) == 0 {
+		return nil, fmt.Errorf("no nameservers found for %s", name)
+	}
+	if timeout > v.config.Timeout {
+		timeout = v.config.Timeout
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		result := v.check(ctx, nameservers, name, rrtype, want)
+		if result.Propagated || time.Now().Add(v.config.Interval).After(deadline) {
+			v.log.Debugw("propagation", "name", name, "type", dns.TypeToString[rrtype], "propagated", result.Propagated)
+			return result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(v.config.Interval):
+		}
+	}
+}
+
+// check queries all addresses of the nameservers once
+func (v *Verifier) check(ctx context.Context, nameservers []string, name string, rrtype uint16, want []string) *v1.Propagation {
+	statuses := v.targets(ctx, nameservers)
+	var wg sync.WaitGroup
+	for _, status := range statuses {
+		if status.Error != "" {
+			continue
+		}
+		status := status
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := v.query(ctx, status.Address, name, rrtype)
+			if err != nil {
+				status.Error = err.Error()
+				return
+			}
+			status.Data = data
+			status.Propagated = equal(data, want)
+		}()
+	}
+	wg.Wait()
+
+	result := &v1.Propagation{Propagated: len(statuses) > 0, Nameservers: statuses}
+	for _, status := range statuses {
+		if !status.Propagated {
+			result.Propagated = false
+		}
+	}
+	return result
+}
+
+// targets returns a status for every address of the nameservers, a nameserver which cannot be resolved is reported with the error
+func (v *Verifier) targets(ctx context.Context, nameservers []string) []*v1.NameserverStatus {
+	var statuses []*v1.NameserverStatus
+	if len(v.config.Nameservers) > 0 {
+		for _, ns := range v.config.Nameservers {
+			statuses = append(statuses, &v1.NameserverStatus{Nameserver: ns, Address: ns})
+		}
+		return statuses
+	}
+	for _, ns := range nameservers {
+		addrs, err := v.config.Resolver.LookupHost(ctx, strings.TrimSuffix(ns, "."))
+		if err != nil {
+			statuses = append(statuses, &v1.NameserverStatus{Nameserver: ns, Error: err.Error()})
+			continue
+		}
+		sort.Strings(addrs)
+		for _, addr := range addrs {
+			statuses = append(statuses, &v1.NameserverStatus{Nameserver: ns, Address: net.JoinHostPort(addr, "53")})
+		}
+	}
+	return statuses
+}
+
+// query returns the content of the rrset served by address, retried with tcp if the udp answer is truncated
+func (v *Verifier) query(ctx context.Context, address, name string, rrtype uint16) ([]string, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(name), rrtype)
+	m.RecursionDesired = false
+
+	in, _, err := v.udp.ExchangeContext(ctx, m, address)
+	if err == nil && in.Truncated {
+		in, _, err = v.tcp.ExchangeContext(ctx, m, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if in.Rcode != dns.RcodeSuccess && in.Rcode != dns.RcodeNameError {
+		return nil, fmt.Errorf("query failed with %s", dns.RcodeToString[in.Rcode])
+	}
+	if !in.Authoritative {
+		return nil, errors.New("answer is not authoritative")
+	}
+	data := []string{}
+	for _, rr := range in.Answer {
+		if rr.Header().Rrtype == rrtype && strings.EqualFold(rr.Header().Name, dns.Fqdn(name)) {
+			data = append(data, rdata(rr))
+		}
+	}
+	sort.Strings(data)
+	return data, nil
+}
+
+// Normalize parses the content of a rrset, which makes it comparable with the answers of the nameservers
+func Normalize(name string, rrtype uint16, data []string) ([]string, error) {
+	typ, ok := dns.TypeToString[rrtype]
+	if !ok {
+		return nil, fmt.Errorf("unknown record type %d", rrtype)
+	}
+	result := []string{}
+	for _, d := range data {
+		rr, err := dns.NewRR(fmt.Sprintf("%s 0 IN %s %s", dns.Fqdn(name), typ, d))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s record %q %w", typ, d, err)
+		}
+		if rr == nil {
+			return nil, fmt.Errorf("%s record must not be empty", typ)
+		}
+		result = append(result, rdata(rr))
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func rdata(rr dns.RR) string {
+	return strings.TrimPrefix(rr.String(), rr.Header().String())
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
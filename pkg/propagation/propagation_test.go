@@ -0,0 +1,174 @@
+package propagation
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// nameserver is an authoritative dns.Server with records which can be changed while it is running
+type nameserver struct {
+	lock    sync.Mutex
+	records map[string][]dns.RR
+	addr    string
+}
+
+func startNameserver(t *testing.T) *nameserver {
+	ns := &nameserver{records: map[string][]dns.RR{}}
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ns.addr = pc.LocalAddr().String()
+
+	started := make(chan struct{})
+	server := &dns.Server{PacketConn: pc, Handler: ns, NotifyStartedFunc: func() { close(started) }}
+	go func() {
+		_ = server.ActivateAndServe()
+	}()
+	<-started
+	t.Cleanup(func() {
+		_ = server.Shutdown()
+	})
+	return ns
+}
+
+func (ns *nameserver) set(t *testing.T, name string, rrtype uint16, records ...string) {
+	var rrs []dns.RR
+	for _, r := range records {
+		rr, err := dns.NewRR(r)
+		require.NoError(t, err)
+		rrs = append(rrs, rr)
+	}
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+	ns.records[name+" "+dns.TypeToString[rrtype]] = rrs
+}
+
+func (ns *nameserver) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	ns.lock.Lock()
+	defer ns.lock.Unlock()
+	m := new(dns.Msg)
+	m.SetReply(r)
+	m.Authoritative = true
+	q := r.Question[0]
+	rrs, ok := ns.records[q.Name+" "+dns.TypeToString[q.Qtype]]
+	if !ok || len(rrs) == 0 {
+		m.Rcode = dns.RcodeNameError
+	}
+	m.Answer = rrs
+	_ = w.WriteMsg(m)
+}
+
+func TestWait(t *testing.T) {
+	ns1 := startNameserver(t)
+	ns2 := startNameserver(t)
+	ns1.set(t, "www.example.com.", dns.TypeA, "www.example.com. 300 IN A 1.2.3.4")
+
+	v := New(zaptest.NewLogger(t).Sugar(), Config{
+		Nameservers: []string{ns1.addr, ns2.addr},
+		Timeout:     5 * time.Second,
+		Interval:    10 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	t.Run("not propagated", func(t *testing.T) {
+		result, err := v.Wait(ctx, nil, "www.example.com.", dns.TypeA, []string{"1.2.3.4"}, 0)
+		require.NoError(t, err)
+		require.False(t, result.Propagated)
+		require.Len(t, result.Nameservers, 2)
+		require.True(t, result.Nameservers[0].Propagated)
+		require.Equal(t, []string{"1.2.3.4"}, result.Nameservers[0].Data)
+		require.False(t, result.Nameservers[1].Propagated)
+		require.Empty(t, result.Nameservers[1].Data)
+		require.Empty(t, result.Nameservers[1].Error)
+	})
+
+	t.Run("propagated while waiting", func(t *testing.T) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			ns2.set(t, "www.example.com.", dns.TypeA, "www.example.com. 300 IN A 1.2.3.4")
+		}()
+		result, err := v.Wait(ctx, nil, "www.example.com.", dns.TypeA, []string{"1.2.3.4"}, time.Second)
+		require.NoError(t, err)
+		require.True(t, result.Propagated)
+		for _, status := range result.Nameservers {
+			require.True(t, status.Propagated)
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		ns1.set(t, "www.example.com.", dns.TypeA)
+		result, err := v.Wait(ctx, nil, "www.example.com.", dns.TypeA, nil, 0)
+		require.NoError(t, err)
+		require.False(t, result.Propagated)
+		require.True(t, result.Nameservers[0].Propagated)
+		require.False(t, result.Nameservers[1].Propagated)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		start := time.Now()
+		result, err := v.Wait(ctx, nil, "www.example.com.", dns.TypeA, []string{"1.2.3.5"}, 100*time.Millisecond)
+		require.NoError(t, err)
+		require.False(t, result.Propagated)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := v.Wait(ctx, nil, "www.example.com.", dns.TypeA, []string{"1.2.3.5"}, time.Second)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestWaitUnreachable(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := pc.LocalAddr().String()
+	require.NoError(t, pc.Close())
+
+	v := New(zaptest.NewLogger(t).Sugar(), Config{Nameservers: []string{addr}, QueryTimeout: 100 * time.Millisecond})
+	result, err := v.Wait(context.Background(), nil, "www.example.com.", dns.TypeA, []string{"1.2.3.4"}, 0)
+	require.NoError(t, err)
+	require.False(t, result.Propagated)
+	require.Equal(t, addr, result.Nameservers[0].Address)
+	require.NotEmpty(t, result.Nameservers[0].Error)
+
+	v = New(zaptest.NewLogger(t).Sugar(), Config{})
+	_, err = v.Wait(context.Background(), nil, "www.example.com.", dns.TypeA, []string{"1.2.3.4"}, 0)
+	require.EqualError(t, err, "no nameservers found for www.example.com.")
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		rrtype  uint16
+		data    []string
+		want    []string
+		wantErr string
+	}{
+		{name: "www.example.com.", rrtype: dns.TypeA, data: []string{"1.2.3.5", "1.2.3.4"}, want: []string{"1.2.3.4", "1.2.3.5"}},
+		{name: "www.example.com.", rrtype: dns.TypeAAAA, data: []string{"2001:db8:0::1"}, want: []string{"2001:db8::1"}},
+		{name: "www.example.com.", rrtype: dns.TypeTXT, data: []string{`"hello world"`}, want: []string{`"hello world"`}},
+		{name: "example.com.", rrtype: dns.TypeMX, data: []string{"10 mx.example.com."}, want: []string{"10 mx.example.com."}},
+		{name: "www.example.com.", rrtype: dns.TypeA, data: nil, want: []string{}},
+		{name: "www.example.com.", rrtype: dns.TypeA, data: []string{"not an ip"}, wantErr: `unable to parse A record "not an ip"`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(dns.TypeToString[tt.rrtype], func(t *testing.T) {
+			got, err := Normalize(tt.name, tt.rrtype, tt.data)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/token"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// KeyToken limits the calls of every token, tokens of client certificates are limited by subject
+	KeyToken = "token"
+	// KeyIP limits the calls of every client ip
+	KeyIP = "ip"
+)
+
+// writeProcedures are limited by the write limit, all others by the read limit
+var writeProcedures = map[string]bool{
+	apiv1connect.DomainServiceCreateProcedure:     true,
+	apiv1connect.DomainServiceUpdateProcedure:     true,
+	apiv1connect.DomainServiceDeleteProcedure:     true,
+	apiv1connect.DomainServiceRollbackProcedure:   true,
+	apiv1connect.RecordServiceCreateProcedure:     true,
+	apiv1connect.RecordServiceUpdateProcedure:     true,
+	apiv1connect.RecordServiceDeleteProcedure:     true,
+	apiv1connect.ChallengeServicePresentProcedure: true,
+	apiv1connect.ChallengeServiceCleanUpProcedure: true,
+	apiv1connect.WebhookServiceCreateProcedure:    true,
+	apiv1connect.WebhookServiceUpdateProcedure:    true,
+	apiv1connect.WebhookServiceDeleteProcedure:    true,
+	apiv1connect.TokenServiceCreateProcedure:      true,
+}
+
+// Limit is a token bucket which is refilled with Rate calls per second up to Burst calls, a zero Rate is unlimited
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses a limit in the form rate:burst, e.g. 10:20, burst defaults to the rounded up rate.
+// An empty string or 0 is unlimited.
+func ParseLimit(s string) (Limit, error) {
+	if s == "" || s == "0" {
+		return Limit{}, nil
+	}
+	perSecond, burst, hasBurst := strings.Cut(s, ":")
+	r, err := strconv.ParseFloat(perSecond, 64)
+	if err != nil || r < 0 {
+		return Limit{}, fmt.Errorf("limit %q must be rate:burst with a positive rate", s)
+	}
+	l := Limit{Rate: r, Burst: int(math.Ceil(r))}
+	if hasBurst {
+		l.Burst, err = strconv.Atoi(burst)
+		if err != nil || l.Burst < 1 {
+			return Limit{}, fmt.Errorf("limit %q must be rate:burst with a burst of at least 1", s)
+		}
+	}
+	return l, nil
+}
+
+// Config defines the limits, which apply to each key separately
+type Config struct {
+	Read  Limit
+	Write Limit
+	// Procedures overrides the read or write limit of single procedures
+	Procedures map[string]Limit
+}
+
+// Observer gets notified about rejected calls and the number of buckets
+type Observer interface {
+	ObserveRateLimited(procedure, key string)
+	SetRateLimitBuckets(key string, buckets int)
+}
+
+// Limiter limits the calls per token and client ip with token buckets
+type Limiter struct {
+	log      *zap.SugaredLogger
+	config   Config
+	observer Observer
+	now      func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	key     string
+	limiter *rate.Limiter
+}
+
+// New creates a Limiter, observer is optional
+func New(log *zap.SugaredLogger, config Config, observer Observer) *Limiter {
+	return &Limiter{
+		log:      log.Named("ratelimit"),
+		config:   config,
+		observer: observer,
+		now:      time.Now,
+		buckets:  map[string]*bucket{},
+	}
+}
+
+// Run removes buckets which are full again every interval until ctx is done,
+// removing them is the same as starting with a new bucket on the next call.
+func (l *Limiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.cleanup()
+		}
+	}
+}
+
+func (l *Limiter) cleanup() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	count := map[string]int{KeyToken: 0, KeyIP: 0}
+	for id, b := range l.buckets {
+		if b.limiter.TokensAt(now) >= float64(b.limiter.Burst()) {
+			delete(l.buckets, id)
+			continue
+		}
+		count[b.key]++
+	}
+	if l.observer != nil {
+		for key, n := range count {
+			l.observer.SetRateLimitBuckets(key, n)
+		}
+	}
+}
+
+// IPInterceptor limits the calls per client ip, it should run before the authorizer
+// to protect the policy evaluation as well.
+func (l *Limiter) IPInterceptor() connect.Interceptor {
+	return &interceptor{l: l, key: KeyIP, id: func(_ context.Context, peer connect.Peer) string {
+		host, _, err := net.SplitHostPort(peer.Addr)
+		if err != nil {
+			return peer.Addr
+		}
+		return host
+	}}
+}
+
+// TokenInterceptor limits the calls per token, it must run after the authorizer which provides the claims.
+// Calls without a token, e.g. to public procedures, are not limited.
+func (l *Limiter) TokenInterceptor() connect.Interceptor {
+	return &interceptor{l: l, key: KeyToken, id: func(ctx context.Context, _ connect.Peer) string {
+		claims := token.ClaimsFromContext(ctx)
+		if claims == nil {
+			return ""
+		}
+		// a new token is created for every call with a client certificate or a TSIG key
+		if claims.Issuer == auth.ClientCertIssuer || claims.Issuer == auth.TSIGIssuer {
+			return claims.Subject
+		}
+		return claims.ID
+	}}
+}
+
+// allow takes a call of procedure from the bucket of id, it returns a error with a retry hint if the bucket is empty
+func (l *Limiter) allow(key, id, procedure string) error {
+	limit, class := l.limit(procedure)
+	if limit.Rate <= 0 || id == "" {
+		return nil
+	}
+
+	now := l.now()
+	l.mu.Lock()
+	bucketID := key + "/" + id + "/" + class
+	b, ok := l.buckets[bucketID]
+	if !ok {
+		b = &bucket{key: key, limiter: rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)}
+		l.buckets[bucketID] = b
+	}
+	l.mu.Unlock()
+
+	r := b.limiter.ReserveN(now, 1)
+	delay := r.DelayFrom(now)
+	if delay == 0 {
+		return nil
+	}
+	r.CancelAt(now)
+
+	if l.observer != nil {
+		l.observer.ObserveRateLimited(procedure, key)
+	}
+	l.log.Debugw("rate limited", "procedure", procedure, "key", key, "id", id, "retry", delay)
+
+	err := connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("rate limit per %s exceeded for %s, retry in %s", key, procedure, delay.Round(time.Millisecond)))
+	err.Meta().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	if detail, derr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); derr == nil {
+		err.AddDetail(detail)
+	}
+	return err
+}
+
+// limit returns the limit of procedure and the name of the bucket it shares with other procedures
+func (l *Limiter) limit(procedure string) (Limit, string) {
+	if limit, ok := l.config.Procedures[procedure]; ok {
+		return limit, procedure
+	}
+	if writeProcedures[procedure] {
+		return l.config.Write, "write"
+	}
+	return l.config.Read, "read"
+}
+
+type interceptor struct {
+	l   *Limiter
+	key string
+	id  func(ctx context.Context, peer connect.Peer) string
+}
+
+func (i *interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return connect.UnaryFunc(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IsClient {
+			return next(ctx, req)
+		}
+		if err := i.l.allow(i.key, i.id(ctx, req.Peer()), req.Spec().Procedure); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	})
+}
+
+func (i *interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler takes a single call for the whole stream
+func (i *interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return connect.StreamingHandlerFunc(func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.l.allow(i.key, i.id(ctx, conn.Peer()), conn.Spec().Procedure); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	})
+}
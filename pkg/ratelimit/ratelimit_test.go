@@ -0,0 +1,203 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		limit   string
+		want    Limit
+		wantErr bool
+	}{
+		{limit: "", want: Limit{}},
+		{limit: "0", want: Limit{}},
+		{limit: "10:20", want: Limit{Rate: 10, Burst: 20}},
+		{limit: "0.5", want: Limit{Rate: 0.5, Burst: 1}},
+		{limit: "5", want: Limit{Rate: 5, Burst: 5}},
+		{limit: "fast", wantErr: true},
+		{limit: "-1:5", wantErr: true},
+		{limit: "10:0", wantErr: true},
+		{limit: "10:many", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.limit, func(t *testing.T) {
+			got, err := ParseLimit(tt.limit)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+type recordService struct {
+	apiv1connect.UnimplementedRecordServiceHandler
+}
+
+func (r *recordService) List(context.Context, *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
+	return connect.NewResponse(&v1.RecordServiceListResponse{}), nil
+}
+
+func (r *recordService) Create(context.Context, *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
+	return connect.NewResponse(&v1.RecordServiceCreateResponse{}), nil
+}
+
+// withClaims simulates the authorizer, the claims are taken from the request headers
+type withClaims struct {
+	connect.Interceptor
+}
+
+func (w withClaims) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		claims := &token.DNSClaims{}
+		claims.Subject = req.Header().Get("Subject")
+		claims.Issuer = req.Header().Get("Issuer")
+		claims.ID = req.Header().Get("Jti")
+		return next(token.ContextWithClaims(ctx, claims), req)
+	}
+}
+
+func (w withClaims) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+type observer struct {
+	mu       sync.Mutex
+	rejected map[string]int
+	buckets  map[string]int
+}
+
+func (o *observer) ObserveRateLimited(procedure, key string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.rejected[procedure+" "+key]++
+}
+
+func (o *observer) SetRateLimitBuckets(key string, buckets int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.buckets[key] = buckets
+}
+
+func TestTokenInterceptor(t *testing.T) {
+	ctx := context.Background()
+	o := &observer{rejected: map[string]int{}, buckets: map[string]int{}}
+	l := New(zaptest.NewLogger(t).Sugar(), Config{
+		Read:  Limit{Rate: 1, Burst: 2},
+		Write: Limit{Rate: 1, Burst: 1},
+		Procedures: map[string]Limit{
+			apiv1connect.RecordServiceUpdateProcedure: {},
+		},
+	}, o)
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewRecordServiceHandler(&recordService{}, connect.WithInterceptors(withClaims{}, l.TokenInterceptor())))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	c := apiv1connect.NewRecordServiceClient(server.Client(), server.URL)
+
+	list := func(issuer, subject, jti string) error {
+		req := connect.NewRequest(&v1.RecordServiceListRequest{Domain: "a.example.com."})
+		req.Header().Set("Issuer", issuer)
+		req.Header().Set("Subject", subject)
+		req.Header().Set("Jti", jti)
+		_, err := c.List(ctx, req)
+		return err
+	}
+	create := func(jti string) error {
+		req := connect.NewRequest(&v1.RecordServiceCreateRequest{Name: "www.a.example.com."})
+		req.Header().Set("Jti", jti)
+		_, err := c.Create(ctx, req)
+		return err
+	}
+
+	require.NoError(t, list("Tester", "metal-dns", "a"))
+	require.NoError(t, list("Tester", "metal-dns", "a"))
+	err := list("Tester", "metal-dns", "a")
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+
+	var connectErr *connect.Error
+	require.ErrorAs(t, err, &connectErr)
+	require.Equal(t, "1", connectErr.Meta().Get("Retry-After"))
+	require.Len(t, connectErr.Details(), 1)
+	detail, err := connectErr.Details()[0].Value()
+	require.NoError(t, err)
+	require.Equal(t, time.Second, detail.(*errdetails.RetryInfo).RetryDelay.AsDuration())
+
+	// writes and other tokens have their own buckets
+	require.NoError(t, create("a"))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(create("a")))
+	require.NoError(t, list("Tester", "metal-dns", "b"))
+
+	// tokens of client certificates are limited by subject
+	require.NoError(t, list(auth.ClientCertIssuer, "CN=external-dns", "c1"))
+	require.NoError(t, list(auth.ClientCertIssuer, "CN=external-dns", "c2"))
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(list(auth.ClientCertIssuer, "CN=external-dns", "c3")))
+
+	// unlimited procedure
+	for i := 0; i < 5; i++ {
+		req := connect.NewRequest(&v1.RecordServiceUpdateRequest{Name: "www.a.example.com."})
+		req.Header().Set("Jti", "a")
+		_, err = c.Update(ctx, req)
+		require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	}
+
+	now = now.Add(time.Second)
+	require.NoError(t, list("Tester", "metal-dns", "a"))
+	require.NoError(t, create("a"))
+
+	require.Equal(t, map[string]int{
+		apiv1connect.RecordServiceListProcedure + " token":   2,
+		apiv1connect.RecordServiceCreateProcedure + " token": 1,
+	}, o.rejected)
+
+	l.cleanup()
+	require.Equal(t, map[string]int{KeyToken: 3, KeyIP: 0}, o.buckets)
+	now = now.Add(time.Minute)
+	l.cleanup()
+	require.Equal(t, map[string]int{KeyToken: 0, KeyIP: 0}, o.buckets)
+	require.Empty(t, l.buckets)
+}
+
+func TestIPInterceptor(t *testing.T) {
+	ctx := context.Background()
+	l := New(zaptest.NewLogger(t).Sugar(), Config{Read: Limit{Rate: 1, Burst: 1}}, nil)
+	now := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewRecordServiceHandler(&recordService{}, connect.WithInterceptors(l.IPInterceptor())))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// every call uses a new connection but comes from the same ip
+	list := func() error {
+		c := apiv1connect.NewRecordServiceClient(&http.Client{Transport: &http.Transport{}}, server.URL)
+		_, err := c.List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{}))
+		return err
+	}
+	require.NoError(t, list())
+	err := list()
+	require.Equal(t, connect.CodeResourceExhausted, connect.CodeOf(err))
+	require.Contains(t, l.buckets, "ip/127.0.0.1/read")
+}
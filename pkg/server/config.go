@@ -0,0 +1,251 @@
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/notify"
+	"github.com/majst01/metal-dns/pkg/ratelimit"
+)
+
+// Validate checks the configuration for errors which would otherwise only show up at runtime,
+// all errors are reported at once. The errors refer to the command line flags of the server.
+func (c DialConfig) Validate() error {
+	var errs []error
+	check := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	check(validateAddress("http-endpoint", c.HttpServerEndpoint, true))
+	check(validateAddress("metrics-endpoint", c.MetricsServerEndpoint, false))
+
+	if c.Secret == "" {
+		check(errors.New("secret must not be empty"))
+	}
+
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		check(errors.New("tls-cert and tls-key must be given together"))
+	}
+	if c.TLSClientCA != "" && c.TLSCert == "" {
+		check(errors.New("tls-client-ca requires tls-cert and tls-key"))
+	}
+	if c.TLSClientSubjects != "" && c.TLSClientCA == "" {
+		check(errors.New("tls-client-subjects requires tls-client-ca"))
+	}
+	check(validateFile("tls-cert", c.TLSCert))
+	check(validateFile("tls-key", c.TLSKey))
+	check(validateFile("tls-client-ca", c.TLSClientCA))
+	check(validateFile("tls-client-subjects", c.TLSClientSubjects))
+	check(validateFile("policy-path", c.PolicyPath))
+
+	switch c.Backend {
+	case "", BackendPowerDNS:
+		if c.PdnsServers != "" {
+			check(validateFile("pdns-servers", c.PdnsServers))
+			break
+		}
+		check(validateURL("pdns-api-url", c.PdnsApiUrl, true))
+		if c.PdnsApiVHost == "" {
+			check(errors.New("pdns-api-vhost must not be empty"))
+		}
+	case BackendRFC2136:
+		check(validateAddress("rfc2136-nameserver", c.RFC2136Nameserver, true))
+		if len(c.RFC2136Zones) == 0 {
+			check(errors.New("rfc2136-zones must not be empty"))
+		}
+		if _, err := backend.TSIGAlgorithm(c.RFC2136TSIGAlgorithm); err != nil {
+			check(fmt.Errorf("rfc2136-tsig-algorithm %w", err))
+		}
+		if c.RFC2136TSIGKeyName != "" {
+			if _, err := base64.StdEncoding.DecodeString(c.RFC2136TSIGSecret); err != nil || c.RFC2136TSIGSecret == "" {
+				check(errors.New("rfc2136-tsig-secret must be base64 encoded"))
+			}
+		}
+		if c.RFC2136Timeout <= 0 {
+			check(fmt.Errorf("rfc2136-timeout must be positive, got %s", c.RFC2136Timeout))
+		}
+	case BackendZoneFile:
+		if c.ZoneFileDirectory == "" {
+			check(errors.New("zonefile-directory must not be empty"))
+		} else if info, err := os.Stat(c.ZoneFileDirectory); err != nil {
+			check(fmt.Errorf("zonefile-directory %w", err))
+		} else if !info.IsDir() {
+			check(fmt.Errorf("zonefile-directory %s is not a directory", c.ZoneFileDirectory))
+		}
+		if c.ZoneFileReloadTimeout <= 0 {
+			check(fmt.Errorf("zonefile-reload-timeout must be positive, got %s", c.ZoneFileReloadTimeout))
+		}
+	default:
+		check(fmt.Errorf("backend %q must be one of %s, %s or %s", c.Backend, BackendPowerDNS, BackendRFC2136, BackendZoneFile))
+	}
+	check(validateURL("decision-log-url", c.DecisionLogURL, false))
+
+	for _, origin := range c.CORSAllowedOrigins {
+		check(validateOrigin(origin))
+	}
+	if len(c.CORSAllowedHeaders) > 0 && len(c.CORSAllowedOrigins) == 0 {
+		check(errors.New("cors-allowed-headers requires cors-allowed-origins"))
+	}
+	if c.CORSMaxAge < 0 {
+		check(fmt.Errorf("cors-max-age must not be negative, got %s", c.CORSMaxAge))
+	}
+
+	_, err := c.rateLimitConfig()
+	check(err)
+	for _, key := range c.RateLimitBy {
+		if key != ratelimit.KeyToken && key != ratelimit.KeyIP {
+			check(fmt.Errorf("rate-limit-by %q must be one of %s or %s", key, ratelimit.KeyToken, ratelimit.KeyIP))
+		}
+	}
+
+	if c.HealthCheckInterval <= 0 {
+		check(fmt.Errorf("health-check-interval must be positive, got %s", c.HealthCheckInterval))
+	}
+	if c.HealthCheckTimeout <= 0 {
+		check(fmt.Errorf("health-check-timeout must be positive, got %s", c.HealthCheckTimeout))
+	}
+
+	for _, ns := range c.PropagationNameservers {
+		check(validateAddress("propagation-nameservers", ns, true))
+	}
+	if c.PropagationTimeout <= 0 {
+		check(fmt.Errorf("propagation-timeout must be positive, got %s", c.PropagationTimeout))
+	}
+	if c.PropagationInterval <= 0 {
+		check(fmt.Errorf("propagation-interval must be positive, got %s", c.PropagationInterval))
+	}
+
+	if c.WatchBufferSize <= 0 {
+		check(fmt.Errorf("watch-buffer-size must be positive, got %d", c.WatchBufferSize))
+	}
+
+	if c.WebhookDir != "" {
+		if c.WebhookAttempts <= 0 {
+			check(fmt.Errorf("webhook-attempts must be positive, got %d", c.WebhookAttempts))
+		}
+		if c.WebhookBackoff <= 0 {
+			check(fmt.Errorf("webhook-backoff must be positive, got %s", c.WebhookBackoff))
+		}
+		if c.WebhookTimeout <= 0 {
+			check(fmt.Errorf("webhook-timeout must be positive, got %s", c.WebhookTimeout))
+		}
+		_, err := notify.ParseTargets(c.WebhookAllowedNetworks)
+		if err != nil {
+			check(fmt.Errorf("webhook-allowed-networks %w", err))
+		}
+	}
+
+	check(validateFile("mirrors", c.Mirrors))
+	if c.Mirrors != "" {
+		if c.MirrorQueueDir == "" {
+			check(errors.New("mirrors requires mirror-queue-dir"))
+		}
+		if c.MirrorBackoff <= 0 {
+			check(fmt.Errorf("mirror-backoff must be positive, got %s", c.MirrorBackoff))
+		}
+	}
+
+	check(validateAddress("dns-update-endpoint", c.DNSUpdateEndpoint, false))
+	if (c.DNSUpdateEndpoint == "") != (c.DNSUpdateKeys == "") {
+		check(errors.New("dns-update-endpoint and dns-update-keys must be given together"))
+	}
+	check(validateFile("dns-update-keys", c.DNSUpdateKeys))
+
+	if c.TraceSampleRatio < 0 || c.TraceSampleRatio > 1 {
+		check(fmt.Errorf("trace-sample-ratio must be between 0 and 1, got %v", c.TraceSampleRatio))
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateAddress(name, address string, required bool) error {
+	if address == "" {
+		if required {
+			return fmt.Errorf("%s must not be empty", name)
+		}
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return fmt.Errorf("%s %w", name, err)
+	}
+	return nil
+}
+
+func validateURL(name, raw string, required bool) error {
+	if raw == "" {
+		if required {
+			return fmt.Errorf("%s must not be empty", name)
+		}
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%s %q is not a url %w", name, raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s %q must be a http or https url", name, raw)
+	}
+	return nil
+}
+
+// rateLimitConfig parses the rate limits, procedures are given as procedure=rate:burst
+func (c DialConfig) rateLimitConfig() (ratelimit.Config, error) {
+	var (
+		config = ratelimit.Config{Procedures: map[string]ratelimit.Limit{}}
+		errs   []error
+		err    error
+	)
+	config.Read, err = ratelimit.ParseLimit(c.RateLimitRead)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("rate-limit-read %w", err))
+	}
+	config.Write, err = ratelimit.ParseLimit(c.RateLimitWrite)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("rate-limit-write %w", err))
+	}
+	for _, p := range c.RateLimitProcedures {
+		procedure, limit, ok := strings.Cut(p, "=")
+		if !ok || !strings.HasPrefix(procedure, "/") {
+			errs = append(errs, fmt.Errorf("rate-limit-procedure %q must be /package.Service/Method=rate:burst", p))
+			continue
+		}
+		config.Procedures[procedure], err = ratelimit.ParseLimit(limit)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("rate-limit-procedure %w", err))
+		}
+	}
+	return config, errors.Join(errs...)
+}
+
+// validateOrigin accepts * and origins like https://app.example.com, which may contain one * as wildcard
+func validateOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+		return fmt.Errorf("cors-allowed-origins %q is not an origin like https://app.example.com", origin)
+	}
+	if strings.Count(origin, "*") > 1 {
+		return fmt.Errorf("cors-allowed-origins %q must not contain more than one wildcard", origin)
+	}
+	return nil
+}
+
+func validateFile(name, path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("%s %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialConfigValidate(t *testing.T) {
+	dir := t.TempDir()
+	cert := filepath.Join(dir, "tls.crt")
+	require.NoError(t, os.WriteFile(cert, nil, 0600))
+
+	valid := DialConfig{
+		HttpServerEndpoint: "localhost:8080",
+		Secret:             "secret",
+		PdnsApiUrl:         "http://localhost:8081",
+		PdnsApiVHost:       "localhost",
+		TraceSampleRatio:   1,
+
+		HealthCheckInterval: 10 * time.Second,
+		HealthCheckTimeout:  5 * time.Second,
+		PropagationTimeout:  time.Minute,
+		PropagationInterval: 2 * time.Second,
+		WatchBufferSize:     1000,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *DialConfig)
+		wantErr []string
+	}{
+		{
+			name:   "valid",
+			modify: func(c *DialConfig) {},
+		},
+		{
+			name: "invalid endpoints",
+			modify: func(c *DialConfig) {
+				c.HttpServerEndpoint = "localhost"
+				c.MetricsServerEndpoint = "localhost"
+			},
+			wantErr: []string{"http-endpoint address localhost: missing port in address", "metrics-endpoint address localhost: missing port in address"},
+		},
+		{
+			name: "tls key missing",
+			modify: func(c *DialConfig) {
+				c.TLSCert = cert
+			},
+			wantErr: []string{"tls-cert and tls-key must be given together"},
+		},
+		{
+			name: "tls files missing",
+			modify: func(c *DialConfig) {
+				c.TLSCert = filepath.Join(dir, "missing.crt")
+				c.TLSKey = filepath.Join(dir, "missing.key")
+				c.TLSClientSubjects = cert
+			},
+			wantErr: []string{"tls-cert stat", "tls-key stat", "tls-client-subjects requires tls-client-ca"},
+		},
+		{
+			name: "pdns",
+			modify: func(c *DialConfig) {
+				c.PdnsApiUrl = "localhost:8081"
+				c.PdnsApiVHost = ""
+			},
+			wantErr: []string{`pdns-api-url "localhost:8081" must be a http or https url`, "pdns-api-vhost must not be empty"},
+		},
+		{
+			name: "cors",
+			modify: func(c *DialConfig) {
+				c.CORSAllowedOrigins = []string{"*", "https://*.example.com", "app.example.com", "https://app.example.com/path"}
+				c.CORSMaxAge = -time.Second
+			},
+			wantErr: []string{
+				`cors-allowed-origins "app.example.com" is not an origin`,
+				`cors-allowed-origins "https://app.example.com/path" is not an origin`,
+				"cors-max-age must not be negative, got -1s",
+			},
+		},
+		{
+			name: "cors headers without origins",
+			modify: func(c *DialConfig) {
+				c.CORSAllowedHeaders = []string{"Authorization"}
+			},
+			wantErr: []string{"cors-allowed-headers requires cors-allowed-origins"},
+		},
+		{
+			name: "rate limits",
+			modify: func(c *DialConfig) {
+				c.RateLimitRead = "fast"
+				c.RateLimitWrite = "10:20"
+				c.RateLimitProcedures = []string{"/api.v1.RecordService/Create=1:5", "RecordService/Delete=1", "/api.v1.RecordService/Update=1:0"}
+				c.RateLimitBy = []string{"token", "user"}
+			},
+			wantErr: []string{
+				`rate-limit-read limit "fast" must be rate:burst`,
+				`rate-limit-procedure "RecordService/Delete=1" must be /package.Service/Method=rate:burst`,
+				`rate-limit-procedure limit "1:0" must be rate:burst with a burst of at least 1`,
+				`rate-limit-by "user" must be one of token or ip`,
+			},
+		},
+		{
+			name: "health check interval",
+			modify: func(c *DialConfig) {
+				c.HealthCheckInterval = 0
+				c.HealthCheckTimeout = -time.Second
+			},
+			wantErr: []string{
+				"health-check-interval must be positive, got 0s",
+				"health-check-timeout must be positive, got -1s",
+			},
+		},
+		{
+			name: "propagation",
+			modify: func(c *DialConfig) {
+				c.PropagationNameservers = []string{"127.0.0.1"}
+				c.PropagationTimeout = 0
+				c.PropagationInterval = -time.Second
+			},
+			wantErr: []string{
+				"propagation-nameservers address 127.0.0.1: missing port in address",
+				"propagation-timeout must be positive, got 0s",
+				"propagation-interval must be positive, got -1s",
+			},
+		},
+		{
+			name: "watch buffer size",
+			modify: func(c *DialConfig) {
+				c.WatchBufferSize = 0
+			},
+			wantErr: []string{"watch-buffer-size must be positive, got 0"},
+		},
+		{
+			name: "webhooks",
+			modify: func(c *DialConfig) {
+				c.WebhookDir = dir
+				c.WebhookAllowedNetworks = []string{"10.0.0.0"}
+			},
+			wantErr: []string{"webhook-attempts must be positive, got 0", "webhook-backoff must be positive, got 0s", "webhook-timeout must be positive, got 0s", `webhook-allowed-networks invalid network "10.0.0.0" netip.ParsePrefix("10.0.0.0"): no '/'`},
+		},
+		{
+			name: "dns update",
+			modify: func(c *DialConfig) {
+				c.DNSUpdateEndpoint = "53"
+			},
+			wantErr: []string{"dns-update-endpoint address 53: missing port in address", "dns-update-endpoint and dns-update-keys must be given together"},
+		},
+		{
+			name: "pdns servers",
+			modify: func(c *DialConfig) {
+				c.PdnsApiUrl = ""
+				c.PdnsServers = "testdata/servers.yaml"
+			},
+			wantErr: []string{"pdns-servers stat testdata/servers.yaml: no such file or directory"},
+		},
+		{
+			name: "mirrors",
+			modify: func(c *DialConfig) {
+				c.Mirrors = "config.go"
+			},
+			wantErr: []string{"mirrors requires mirror-queue-dir", "mirror-backoff must be positive, got 0s"},
+		},
+		{
+			name: "unknown backend",
+			modify: func(c *DialConfig) {
+				c.Backend = "bind"
+			},
+			wantErr: []string{`backend "bind" must be one of powerdns, rfc2136 or zonefile`},
+		},
+		{
+			name: "rfc2136",
+			modify: func(c *DialConfig) {
+				c.Backend = BackendRFC2136
+				c.RFC2136TSIGKeyName = "metal-dns."
+				c.RFC2136TSIGAlgorithm = "hmac-md5"
+			},
+			wantErr: []string{
+				"rfc2136-nameserver must not be empty",
+				"rfc2136-zones must not be empty",
+				`rfc2136-tsig-algorithm unsupported tsig algorithm "hmac-md5"`,
+				"rfc2136-tsig-secret must be base64 encoded",
+				"rfc2136-timeout must be positive, got 0s",
+			},
+		},
+		{
+			name: "zonefile",
+			modify: func(c *DialConfig) {
+				c.Backend = BackendZoneFile
+				c.ZoneFileDirectory = "config.go"
+			},
+			wantErr: []string{
+				"zonefile-directory config.go is not a directory",
+				"zonefile-reload-timeout must be positive, got 0s",
+			},
+		},
+		{
+			name: "sample ratio",
+			modify: func(c *DialConfig) {
+				c.TraceSampleRatio = 1.5
+			},
+			wantErr: []string{"trace-sample-ratio must be between 0 and 1, got 1.5"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+			err := c.Validate()
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				require.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
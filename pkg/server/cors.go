@@ -0,0 +1,65 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/rs/cors"
+)
+
+// defaultCORSAllowedHeaders are the headers connect, grpc-web and the trace propagation send
+var defaultCORSAllowedHeaders = []string{
+	"Authorization",
+	"Content-Type",
+	"Connect-Protocol-Version",
+	"Connect-Timeout-Ms",
+	"Connect-Accept-Encoding",
+	"Connect-Content-Encoding",
+	"Grpc-Timeout",
+	"X-Grpc-Web",
+	"X-User-Agent",
+	"Traceparent",
+	"Tracestate",
+}
+
+// withCORS wraps next with the CORS configuration, next is returned unchanged if no origin is allowed,
+// browsers then refuse cross origin calls.
+func (c DialConfig) withCORS(next http.Handler) http.Handler {
+	if len(c.CORSAllowedOrigins) == 0 {
+		return next
+	}
+	allowedHeaders := c.CORSAllowedHeaders
+	if len(allowedHeaders) == 0 {
+		allowedHeaders = defaultCORSAllowedHeaders
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: c.CORSAllowedOrigins,
+		AllowedMethods: []string{
+			http.MethodHead,
+			http.MethodGet,
+			http.MethodPost,
+			http.MethodPut,
+			http.MethodPatch,
+			http.MethodDelete,
+		},
+		AllowedHeaders: allowedHeaders,
+		ExposedHeaders: []string{
+			// Content-Type is in the default safelist.
+			"Accept",
+			"Accept-Encoding",
+			"Accept-Post",
+			"Connect-Accept-Encoding",
+			"Connect-Content-Encoding",
+			"Connect-Protocol-Version",
+			"Content-Encoding",
+			"Grpc-Accept-Encoding",
+			"Grpc-Encoding",
+			"Grpc-Message",
+			"Grpc-Status",
+			"Grpc-Status-Details-Bin",
+		},
+		// Any changes to ExposedHeaders won't take effect until the cached data expires.
+		// FF caps this value at 24h, and modern Chrome caps it at 2h.
+		MaxAge: int(c.CORSMaxAge / time.Second),
+	}).Handler(next)
+}
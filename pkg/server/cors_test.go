@@ -0,0 +1,116 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/service"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCORS(t *testing.T) {
+	log := zaptest.NewLogger(t).Sugar()
+	authz, err := auth.NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewTokenServiceHandler(service.NewTokenService(log, "secret"), connect.WithInterceptors(authz)))
+
+	const procedure = "/api.v1.TokenService/Create"
+
+	preflight := func(handler http.Handler, origin, headers string) *http.Response {
+		req := httptest.NewRequest(http.MethodOptions, procedure, nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		if headers != "" {
+			req.Header.Set("Access-Control-Request-Headers", headers)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Result()
+	}
+
+	tests := []struct {
+		name            string
+		config          DialConfig
+		origin          string
+		headers         string
+		wantAllowOrigin string
+		wantMaxAge      string
+	}{
+		{
+			name:    "disabled by default",
+			origin:  "https://evil.example.com",
+			headers: "authorization,content-type",
+		},
+		{
+			name:            "allowed origin",
+			config:          DialConfig{CORSAllowedOrigins: []string{"https://app.example.com"}, CORSMaxAge: time.Hour},
+			origin:          "https://app.example.com",
+			headers:         "authorization,content-type,connect-protocol-version",
+			wantAllowOrigin: "https://app.example.com",
+			wantMaxAge:      "3600",
+		},
+		{
+			name:    "other origin",
+			config:  DialConfig{CORSAllowedOrigins: []string{"https://app.example.com"}},
+			origin:  "https://evil.example.com",
+			headers: "authorization,content-type",
+		},
+		{
+			name:            "wildcard origin",
+			config:          DialConfig{CORSAllowedOrigins: []string{"https://*.example.com"}},
+			origin:          "https://app.example.com",
+			headers:         "authorization",
+			wantAllowOrigin: "https://app.example.com",
+		},
+		{
+			name:    "header not allowed by default",
+			config:  DialConfig{CORSAllowedOrigins: []string{"https://app.example.com"}},
+			origin:  "https://app.example.com",
+			headers: "authorization,x-custom",
+		},
+		{
+			name:            "configured headers",
+			config:          DialConfig{CORSAllowedOrigins: []string{"https://app.example.com"}, CORSAllowedHeaders: []string{"Authorization", "Content-Type", "X-Custom"}},
+			origin:          "https://app.example.com",
+			headers:         "authorization,x-custom",
+			wantAllowOrigin: "https://app.example.com",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			resp := preflight(tt.config.withCORS(mux), tt.origin, tt.headers)
+			defer resp.Body.Close()
+			require.Equal(t, tt.wantAllowOrigin, resp.Header.Get("Access-Control-Allow-Origin"))
+			require.Equal(t, tt.wantMaxAge, resp.Header.Get("Access-Control-Max-Age"))
+			if tt.wantAllowOrigin != "" {
+				require.Equal(t, http.StatusNoContent, resp.StatusCode)
+				require.Equal(t, http.MethodPost, resp.Header.Get("Access-Control-Allow-Methods"))
+				require.Equal(t, "", resp.Header.Get("Access-Control-Allow-Credentials"))
+			}
+		})
+	}
+
+	t.Run("actual request", func(t *testing.T) {
+		config := DialConfig{CORSAllowedOrigins: []string{"https://app.example.com"}}
+		req := httptest.NewRequest(http.MethodPost, procedure, strings.NewReader("{}"))
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		config.withCORS(mux).ServeHTTP(rec, req)
+		resp := rec.Result()
+		defer resp.Body.Close()
+		// the call reaches the service and is rejected by the authorizer, the browser may read the error
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		require.Equal(t, "https://app.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Contains(t, resp.Header.Get("Access-Control-Expose-Headers"), "Connect-Protocol-Version")
+	})
+}
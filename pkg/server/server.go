@@ -7,23 +7,49 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/bufbuild/connect-go"
 	grpchealth "github.com/bufbuild/connect-grpchealth-go"
 	grpcreflect "github.com/bufbuild/connect-grpcreflect-go"
-	"github.com/rs/cors"
+	otelconnect "github.com/bufbuild/connect-opentelemetry-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 
 	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/audit"
 	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/dnsupdate"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/gateway"
+	"github.com/majst01/metal-dns/pkg/health"
+	"github.com/majst01/metal-dns/pkg/history"
+	"github.com/majst01/metal-dns/pkg/metrics"
+	"github.com/majst01/metal-dns/pkg/mirror"
+	"github.com/majst01/metal-dns/pkg/notify"
+	"github.com/majst01/metal-dns/pkg/propagation"
+	"github.com/majst01/metal-dns/pkg/ratelimit"
 	"github.com/majst01/metal-dns/pkg/service"
+	"github.com/majst01/metal-dns/pkg/tracing"
 	"github.com/metal-stack/v"
 	"go.uber.org/zap"
 )
 
+const (
+	// BackendPowerDNS stores the domains and records in powerdns
+	BackendPowerDNS = "powerdns"
+	// BackendRFC2136 manages the zones of a nameserver like BIND or Knot with dynamic updates and zone transfers
+	BackendRFC2136 = "rfc2136"
+	// BackendZoneFile writes RFC 1035 zone files to a directory, e.g. for the file plugin of CoreDNS
+	BackendZoneFile = "zonefile"
+)
+
 type Server struct {
 	log *zap.SugaredLogger
 	c   DialConfig
@@ -33,9 +59,106 @@ type DialConfig struct {
 	HttpServerEndpoint string
 	Secret             string
 
+	// TLSCert and TLSKey enable TLS on the HttpServerEndpoint, both are reloaded on change
+	TLSCert string
+	TLSKey  string
+	// TLSClientCA verifies client certificates, clients without certificate can still authenticate with a token
+	TLSClientCA string
+	// TLSClientSubjects is a yaml file which maps client certificate subjects to domains and permissions
+	TLSClientSubjects string
+
+	DecisionLogFile string
+	DecisionLogURL  string
+
+	PolicyPath string
+
+	// AuditLogFile records all mutating calls, the audit log is disabled if empty
+	AuditLogFile string
+	// HistoryDir stores a revision of every zone before it is modified, rollback is disabled if empty
+	HistoryDir string
+
+	// OtlpEndpoint is the OTLP/HTTP collector traces are sent to, tracing is disabled if empty
+	OtlpEndpoint     string
+	OtlpInsecure     bool
+	TraceSampleRatio float64
+
+	// CORSAllowedOrigins are the origins browsers may call the api from, CORS is disabled if empty
+	CORSAllowedOrigins []string
+	// CORSAllowedHeaders are the request headers browsers may send, defaults to the headers used by connect clients
+	CORSAllowedHeaders []string
+	// CORSMaxAge is the duration browsers may cache the result of a preflight request
+	CORSMaxAge time.Duration
+
+	// RateLimitRead and RateLimitWrite are the limits of reads and writes per key in the form rate:burst, empty is unlimited
+	RateLimitRead  string
+	RateLimitWrite string
+	// RateLimitProcedures override the limit of single procedures in the form procedure=rate:burst
+	RateLimitProcedures []string
+	// RateLimitBy are the keys calls are limited by, token and/or ip, calls are not limited if empty
+	RateLimitBy []string
+
+	// HealthCheckInterval is the interval in which the backends are probed
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout is the time after which a single probe of a backend fails
+	HealthCheckTimeout time.Duration
+
+	// PropagationNameservers are queried to verify the propagation of records instead of the nameservers of the zone, in the form host:port
+	PropagationNameservers []string
+	// PropagationTimeout is the longest duration to wait for a record to propagate
+	PropagationTimeout time.Duration
+	// PropagationInterval is the interval in which the nameservers are queried while waiting
+	PropagationInterval time.Duration
+
+	// WatchBufferSize is the number of events which are kept to resume watches
+	WatchBufferSize int
+
+	// WebhookDir stores the webhooks and the dead letter log of failed deliveries, webhooks are disabled if empty
+	WebhookDir string
+	// WebhookAttempts is the number of posts of a delivery, retries wait WebhookBackoff which doubles after every retry
+	WebhookAttempts int
+	WebhookBackoff  time.Duration
+	// WebhookTimeout is the timeout of a single post
+	WebhookTimeout time.Duration
+	// WebhookAllowedNetworks are internal networks in cidr notation webhooks may post to, see notify.Targets
+	WebhookAllowedNetworks []string
+
+	// DNSUpdateEndpoint accepts RFC 2136 dynamic updates over udp and tcp, dynamic updates are disabled if empty
+	DNSUpdateEndpoint string
+	// DNSUpdateKeys is a yaml file with the TSIG keys of dynamic updates and their domains and permissions
+	DNSUpdateKeys string
+
+	// MetricsServerEndpoint serves /metrics on a separate address, if empty /metrics is served on the HttpServerEndpoint
+	MetricsServerEndpoint string
+
+	// Backend stores the domains and records, BackendPowerDNS if empty
+	Backend string
+
 	PdnsApiUrl      string
 	PdnsApiPassword string
 	PdnsApiVHost    string
+	// PdnsServers is a yaml file of named powerdns servers and the zones they store, it replaces PdnsApiUrl, PdnsApiPassword and PdnsApiVHost
+	PdnsServers string
+
+	// RFC2136Nameserver receives the dynamic updates and zone transfers of the RFC2136Zones, in the form host:port
+	RFC2136Nameserver string
+	RFC2136Zones      []string
+	// RFC2136TSIGKeyName signs the updates and zone transfers with the base64 encoded RFC2136TSIGSecret, they are not signed if empty
+	RFC2136TSIGKeyName   string
+	RFC2136TSIGAlgorithm string
+	RFC2136TSIGSecret    string
+	RFC2136Timeout       time.Duration
+
+	// ZoneFileDirectory contains the zone files of the zonefile backend
+	ZoneFileDirectory string
+	// ZoneFileReloadCommand is run after each change of a zone file, split at white space, nothing is run if empty
+	ZoneFileReloadCommand string
+	ZoneFileReloadTimeout time.Duration
+
+	// Mirrors is a yaml file of backends the changes are replicated to asynchronously, pending changes are queued in MirrorQueueDir
+	Mirrors        string
+	MirrorQueueDir string
+	// MirrorBackoff is the wait before the first retry of a failed replication, it doubles with every retry
+	MirrorBackoff time.Duration
 }
 
 func New(log *zap.SugaredLogger, config DialConfig) (*Server, error) {
@@ -48,98 +171,365 @@ func New(log *zap.SugaredLogger, config DialConfig) (*Server, error) {
 func (s *Server) Serve() error {
 	s.log.Infow("starting metal-dns", "version", v.V, "address", s.c.HttpServerEndpoint)
 
-	authz, err := auth.NewOpaAuther(s.log, s.c.Secret)
+	if err := s.c.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+
+	decisionLog, decisionLogFile, err := auth.NewDecisionLogger(s.log, auth.DecisionLogConfig{
+		File: s.c.DecisionLogFile,
+		URL:  s.c.DecisionLogURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decision log %w", err)
+	}
+	defer decisionLogFile.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	shutdownTracing, err := tracing.New(ctx, s.log, "metal-dns", tracing.Config{
+		Endpoint:    s.c.OtlpEndpoint,
+		Insecure:    s.c.OtlpInsecure,
+		SampleRatio: s.c.TraceSampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing %w", err)
+	}
+
+	m := metrics.New(prometheus.DefaultRegisterer)
+
+	authzOpts := []auth.Option{auth.WithDecisionLog(decisionLog), auth.WithDecisionObserver(m)}
+	if s.c.PolicyPath != "" {
+		authzOpts = append(authzOpts, auth.WithPolicyPath(s.c.PolicyPath))
+	}
+	if s.c.TLSClientSubjects != "" {
+		subjects, err := auth.LoadClientSubjects(s.c.TLSClientSubjects)
+		if err != nil {
+			return err
+		}
+		authzOpts = append(authzOpts, auth.WithClientSubjects(subjects))
+	}
+	authz, err := auth.NewOpaAuther(s.log, s.c.Secret, authzOpts...)
 	if err != nil {
 		return fmt.Errorf("failed to create authorizer %w", err)
 	}
+	if s.c.PolicyPath != "" {
+		err = authz.WatchPolicies(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to watch policies %w", err)
+		}
+	}
 
-	interceptors := connect.WithInterceptors(authz)
+	rateLimitConfig, err := s.c.rateLimitConfig()
+	if err != nil {
+		return err
+	}
+	limiter := ratelimit.New(s.log, rateLimitConfig, m)
+	go limiter.Run(ctx, time.Minute)
+
+	// remote trace context is trusted, callers of metal-dns are part of the same platform
+	otelInterceptor := otelconnect.NewInterceptor(otelconnect.WithTrustRemote(), otelconnect.WithoutMetrics())
+	chain := []connect.Interceptor{otelInterceptor, m.Interceptor()}
+	// limit by ip before and by token after the authorizer, which provides the claims
+	if contains(s.c.RateLimitBy, ratelimit.KeyIP) {
+		chain = append(chain, limiter.IPInterceptor())
+	}
+	chain = append(chain, authz)
+	if contains(s.c.RateLimitBy, ratelimit.KeyToken) {
+		chain = append(chain, limiter.TokenInterceptor())
+	}
 
-	domainService := service.NewDomainService(s.log, s.c.PdnsApiUrl, s.c.PdnsApiVHost, s.c.PdnsApiPassword, nil)
-	recordService := service.NewRecordService(s.log, s.c.PdnsApiUrl, s.c.PdnsApiVHost, s.c.PdnsApiPassword, nil)
+	backendClient := &http.Client{Transport: otelhttp.NewTransport(m.Transport(nil))}
+	b, err := s.c.NewBackend(backendClient)
+	if err != nil {
+		return err
+	}
+	s.log.Infow("using backend", "backend", b.Name)
+	replicatorDone := make(chan struct{})
+	if s.c.Mirrors != "" {
+		mirrors, err := mirror.Load(s.c.Mirrors, backendClient)
+		if err != nil {
+			return err
+		}
+		queue, err := mirror.NewFileQueue(s.c.MirrorQueueDir)
+		if err != nil {
+			return err
+		}
+		replicator := mirror.NewReplicator(s.log, b, mirrors, queue, mirror.Config{Backoff: s.c.MirrorBackoff}, m)
+		// the services write to the primary, which queues the changes for the mirrors
+		b = replicator.Backend()
+		go func() {
+			replicator.Run(ctx)
+			close(replicatorDone)
+		}()
+	} else {
+		close(replicatorDone)
+	}
+	bus := events.NewBus(s.log, s.c.WatchBufferSize)
+	domainService := service.NewDomainService(s.log, b).WithEvents(bus)
+	verifier := propagation.New(s.log, propagation.Config{
+		Nameservers: s.c.PropagationNameservers,
+		Timeout:     s.c.PropagationTimeout,
+		Interval:    s.c.PropagationInterval,
+	})
+	recordService := service.NewRecordService(s.log, b).WithPropagation(verifier).WithEvents(bus)
+	challengeService := service.NewChallengeService(s.log, b).WithEvents(bus)
 	tokenService := service.NewTokenService(s.log, s.c.Secret)
+	authzService := service.NewAuthzService(s.log, authz)
+
+	var auditStore *audit.FileStore
+	if s.c.AuditLogFile != "" {
+		auditStore, err = audit.NewFileStore(s.c.AuditLogFile)
+		if err != nil {
+			return fmt.Errorf("failed to create audit log %w", err)
+		}
+		defer auditStore.Close()
+		// must run after the authorizer, only authorized calls with known claims are recorded
+		chain = append(chain, audit.NewInterceptor(s.log, auditStore, recordService))
+	}
+	if s.c.HistoryDir != "" {
+		historyStore, err := history.NewFileStore(s.c.HistoryDir)
+		if err != nil {
+			return fmt.Errorf("failed to create history %w", err)
+		}
+		domainService.WithHistory(historyStore)
+		chain = append(chain, history.NewInterceptor(s.log, historyStore, domainService))
+	}
+	var (
+		webhookStore   *notify.FileStore
+		webhookTargets *notify.Targets
+		dispatcherDone = make(chan struct{})
+	)
+	if s.c.WebhookDir != "" {
+		webhookTargets, err = notify.ParseTargets(s.c.WebhookAllowedNetworks)
+		if err != nil {
+			return err
+		}
+		webhookStore, err = notify.NewFileStore(s.c.WebhookDir)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook store %w", err)
+		}
+		deadLetters, err := notify.NewDeadLetterLog(s.c.WebhookDir)
+		if err != nil {
+			return err
+		}
+		defer deadLetters.Close()
+		dispatcher := notify.NewDispatcher(s.log, webhookStore, bus, deadLetters, notify.Config{
+			Attempts: s.c.WebhookAttempts,
+			Backoff:  s.c.WebhookBackoff,
+			Timeout:  s.c.WebhookTimeout,
+			Client:   &http.Client{Transport: otelhttp.NewTransport(webhookTargets.Transport())},
+		})
+		go func() {
+			dispatcher.Run(ctx)
+			close(dispatcherDone)
+		}()
+	} else {
+		close(dispatcherDone)
+	}
+	interceptors := connect.WithInterceptors(chain...)
 
 	mux := http.NewServeMux()
 
 	// Register the services
 	mux.Handle(apiv1connect.NewDomainServiceHandler(domainService, interceptors))
 	mux.Handle(apiv1connect.NewRecordServiceHandler(recordService, interceptors))
+	mux.Handle(apiv1connect.NewChallengeServiceHandler(challengeService, interceptors))
 	mux.Handle(apiv1connect.NewTokenServiceHandler(tokenService, interceptors))
+	mux.Handle(apiv1connect.NewAuthzServiceHandler(authzService, interceptors))
 
-	// Static HealthCheckers
-	checker := grpchealth.NewStaticChecker(
+	services := []string{
 		apiv1connect.DomainServiceName,
 		apiv1connect.RecordServiceName,
+		apiv1connect.ChallengeServiceName,
 		apiv1connect.TokenServiceName,
-	)
+		apiv1connect.AuthzServiceName,
+	}
+	if auditStore != nil {
+		mux.Handle(apiv1connect.NewAuditServiceHandler(service.NewAuditService(s.log, auditStore), interceptors))
+		services = append(services, apiv1connect.AuditServiceName)
+	}
+	if webhookStore != nil {
+		mux.Handle(apiv1connect.NewWebhookServiceHandler(service.NewWebhookService(s.log, webhookStore).WithTargets(webhookTargets), interceptors))
+		services = append(services, apiv1connect.WebhookServiceName)
+	}
+
+	gw, err := gateway.New(s.log, mux)
+	if err != nil {
+		return err
+	}
+	mux.Handle(gateway.Prefix, gw)
+
+	// domains, records and challenges are served by the backend, the other services have none
+	checker := health.NewChecker(s.log, s.c.HealthCheckInterval, s.c.HealthCheckTimeout)
+	checker.AddProbe(b.Name, domainService.Probe)
+	for _, name := range services {
+		switch name {
+		case apiv1connect.DomainServiceName, apiv1connect.RecordServiceName, apiv1connect.ChallengeServiceName:
+			checker.AddService(name, b.Name)
+		default:
+			checker.AddService(name)
+		}
+	}
+	go checker.Run(ctx)
 	mux.Handle(grpchealth.NewHandler(checker))
+	mux.Handle("/healthz", checker.LivenessHandler())
+	mux.Handle("/readyz", checker.ReadinessHandler())
 
 	// enable remote service listing by enabling reflection
 	reflector := grpcreflect.NewStaticReflector()
 	mux.Handle(grpcreflect.NewHandlerV1(reflector))
 	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector))
 
+	var dnsUpdate *dnsupdate.Server
+	if s.c.DNSUpdateEndpoint != "" {
+		keys, err := dnsupdate.LoadKeys(s.c.DNSUpdateKeys)
+		if err != nil {
+			return err
+		}
+		// updates are applied with the RecordService of the mux and pass all interceptors
+		dnsUpdate = dnsupdate.New(s.log, mux, dnsupdate.Config{
+			Endpoint: s.c.DNSUpdateEndpoint,
+			Keys:     keys,
+			Secret:   s.c.Secret,
+		})
+		err = dnsUpdate.Start()
+		if err != nil {
+			return err
+		}
+	}
+
+	var metricsServer *http.Server
+	if s.c.MetricsServerEndpoint == "" {
+		mux.Handle("/metrics", promhttp.Handler())
+	} else {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("/metrics", promhttp.Handler())
+		metricsServer = &http.Server{
+			Addr:              s.c.MetricsServerEndpoint,
+			Handler:           metricsMux,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+	}
+
 	apiServer := &http.Server{
 		Addr:              s.c.HttpServerEndpoint,
-		Handler:           h2c.NewHandler(newCORS().Handler(mux), &http2.Server{}),
+		Handler:           h2c.NewHandler(s.c.withCORS(s.withWatch(mux)), &http2.Server{}),
 		ReadHeaderTimeout: 10 * time.Second,
 		ReadTimeout:       5 * time.Minute,
 		WriteTimeout:      5 * time.Minute,
 		MaxHeaderBytes:    8 * 1024, // 8KiB
 	}
-	s.log.Infof("serving http on %s", apiServer.Addr)
+	if s.c.TLSCert != "" {
+		certs, err := newCertReloader(s.log, s.c.TLSCert, s.c.TLSKey, s.c.TLSClientCA)
+		if err != nil {
+			return err
+		}
+		err = certs.Watch(ctx)
+		if err != nil {
+			return err
+		}
+		// http2 is negotiated with alpn, h2c is not required
+		apiServer.Handler = auth.ClientCertificateHandler(s.c.withCORS(s.withWatch(mux)))
+		apiServer.TLSConfig = certs.TLSConfig()
+	}
+	s.log.Infow("serving http", "address", apiServer.Addr, "tls", apiServer.TLSConfig != nil, "client-ca", s.c.TLSClientCA)
 
 	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		if err := apiServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if apiServer.TLSConfig != nil {
+			err = apiServer.ListenAndServeTLS("", "")
+		} else {
+			err = apiServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.log.Fatalf("HTTP listen and serve %v", err)
 		}
 	}()
+	if metricsServer != nil {
+		s.log.Infof("serving metrics on %s", metricsServer.Addr)
+		go func() {
+			if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				s.log.Fatalf("metrics listen and serve %v", err)
+			}
+		}()
+	}
 
 	<-signals
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-	defer cancel()
-	return apiServer.Shutdown(ctx)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Second)
+	defer shutdownCancel()
+	if metricsServer != nil {
+		if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+			s.log.Errorw("unable to shutdown metrics server", "error", err)
+		}
+	}
+	if dnsUpdate != nil {
+		if err := dnsUpdate.Shutdown(shutdownCtx); err != nil {
+			s.log.Errorw("unable to shutdown dns update server", "error", err)
+		}
+	}
+	err = apiServer.Shutdown(shutdownCtx)
+	// pending deliveries are written to the dead letter log, pending replications stay in the queue
+	cancel()
+	<-dispatcherDone
+	<-replicatorDone
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		s.log.Errorw("unable to flush traces", "error", err)
+	}
+	return err
 
 }
 
-func newCORS() *cors.Cors {
-	// To let web developers play with the demo service from browsers, we need a
-	// very permissive CORS setup.
-	return cors.New(cors.Options{
-		AllowedMethods: []string{
-			http.MethodHead,
-			http.MethodGet,
-			http.MethodPost,
-			http.MethodPut,
-			http.MethodPatch,
-			http.MethodDelete,
-		},
-		AllowOriginFunc: func(origin string) bool {
-			// Allow all origins, which effectively disables CORS.
-			return true
-		},
-		AllowedHeaders: []string{"*"},
-		ExposedHeaders: []string{
-			// Content-Type is in the default safelist.
-			"Accept",
-			"Accept-Encoding",
-			"Accept-Post",
-			"Connect-Accept-Encoding",
-			"Connect-Content-Encoding",
-			"Connect-Protocol-Version",
-			"Content-Encoding",
-			"Grpc-Accept-Encoding",
-			"Grpc-Encoding",
-			"Grpc-Message",
-			"Grpc-Status",
-			"Grpc-Status-Details-Bin",
-		},
-		// Let browsers cache CORS information for longer, which reduces the number
-		// of preflight requests. Any changes to ExposedHeaders won't take effect
-		// until the cached data expires. FF caps this value at 24h, and modern
-		// Chrome caps it at 2h.
-		MaxAge: int(2 * time.Hour / time.Second),
+// NewBackend returns the configured backend without mirrors, client is used for the http apis of backends
+func (c DialConfig) NewBackend(client *http.Client) (*backend.Backend, error) {
+	switch c.Backend {
+	case BackendRFC2136:
+		return backend.NewRFC2136(backend.RFC2136Config{
+			Nameserver:    c.RFC2136Nameserver,
+			Zones:         c.RFC2136Zones,
+			TSIGKeyName:   c.RFC2136TSIGKeyName,
+			TSIGAlgorithm: c.RFC2136TSIGAlgorithm,
+			TSIGSecret:    c.RFC2136TSIGSecret,
+			Timeout:       c.RFC2136Timeout,
+		})
+	case BackendZoneFile:
+		return backend.NewZoneFile(backend.ZoneFileConfig{
+			Directory:     c.ZoneFileDirectory,
+			ReloadCommand: strings.Fields(c.ZoneFileReloadCommand),
+			ReloadTimeout: c.ZoneFileReloadTimeout,
+		})
+	default:
+		if c.PdnsServers != "" {
+			servers, err := backend.LoadPowerDNSServers(c.PdnsServers)
+			if err != nil {
+				return nil, err
+			}
+			return backend.NewPowerDNSRouter(servers, client)
+		}
+		return backend.NewPowerDNS(c.PdnsApiUrl, c.PdnsApiVHost, c.PdnsApiPassword, client), nil
+	}
+}
+
+// withWatch lifts the read and write timeouts of the server for watches, which stream as long as the client is connected
+func (s *Server) withWatch(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apiv1connect.DomainServiceWatchProcedure || r.URL.Path == apiv1connect.RecordServiceWatchProcedure {
+			rc := http.NewResponseController(w)
+			if err := errors.Join(rc.SetReadDeadline(time.Time{}), rc.SetWriteDeadline(time.Time{})); err != nil {
+				s.log.Debugw("unable to lift the timeouts of a watch", "error", err)
+			}
+		}
+		next.ServeHTTP(w, r)
 	})
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
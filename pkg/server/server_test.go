@@ -13,6 +13,7 @@ import (
 	"github.com/majst01/metal-dns/api/v1/apiv1connect"
 
 	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/backend"
 	"github.com/majst01/metal-dns/pkg/client"
 	"github.com/majst01/metal-dns/pkg/service"
 	"github.com/majst01/metal-dns/test"
@@ -269,8 +270,9 @@ func startGRPCServer(t *testing.T, pdns *test.Pdns) (string, error) {
 	}
 	interceptors := connect.WithInterceptors(authz)
 
-	domainService := service.NewDomainService(log, config.PdnsApiUrl, config.PdnsApiVHost, config.PdnsApiPassword, nil)
-	recordService := service.NewRecordService(log, config.PdnsApiUrl, config.PdnsApiVHost, config.PdnsApiPassword, nil)
+	b := backend.NewPowerDNS(config.PdnsApiUrl, config.PdnsApiVHost, config.PdnsApiPassword, nil)
+	domainService := service.NewDomainService(log, b)
+	recordService := service.NewRecordService(log, b)
 	tokenService := service.NewTokenService(log, "secret")
 
 	mux.Handle(apiv1connect.NewDomainServiceHandler(domainService, interceptors))
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// certReloadDelay is the time to wait for further changes of the certificate files before they are reloaded,
+// certificates, keys and ca are usually replaced one after the other.
+var certReloadDelay = 500 * time.Millisecond
+
+// certReloader serves the certificate and client ca from disk and reloads them whenever the files change
+type certReloader struct {
+	log      *zap.SugaredLogger
+	certFile string
+	keyFile  string
+	caFile   string
+
+	config atomic.Pointer[tls.Config]
+}
+
+// newCertReloader loads the certificate and key, with a non empty caFile clients are asked
+// for a certificate which is verified against this ca.
+func newCertReloader(log *zap.SugaredLogger, certFile, keyFile, caFile string) (*certReloader, error) {
+	c := &certReloader{
+		log:      log.Named("tls"),
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// TLSConfig returns the tls configuration for the server, every handshake uses the latest certificates
+func (c *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return c.config.Load(), nil
+		},
+	}
+}
+
+func (c *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load server certificate %w", err)
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2", "http/1.1"},
+		Certificates: []tls.Certificate{cert},
+	}
+	if c.caFile != "" {
+		ca, err := os.ReadFile(c.caFile)
+		if err != nil {
+			return fmt.Errorf("unable to read client ca %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return fmt.Errorf("no certificates found in client ca %s", c.caFile)
+		}
+		config.ClientCAs = pool
+		// clients without certificate are still able to authenticate with a token
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	c.config.Store(config)
+	return nil
+}
+
+// Watch reloads the certificates whenever one of the files changes, until ctx is done.
+// If the new certificates are invalid, the previous ones are served further.
+func (c *certReloader) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create certificate watcher %w", err)
+	}
+	// watch the parent directories, certificates mounted from a kubernetes secret are replaced by swapping a symlink
+	files := map[string]bool{}
+	for _, f := range []string{c.certFile, c.keyFile, c.caFile} {
+		if f == "" {
+			continue
+		}
+		files[filepath.Clean(f)] = true
+		if err := watcher.Add(filepath.Dir(f)); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("unable to watch %s %w", f, err)
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		reload := time.NewTimer(certReloadDelay)
+		reload.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				reload.Stop()
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !files[filepath.Clean(event.Name)] && filepath.Base(event.Name) != "..data" {
+					continue
+				}
+				c.log.Debugw("certificate change detected", "event", event)
+				reload.Reset(certReloadDelay)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				c.log.Errorw("error watching certificates", "error", err)
+			case <-reload.C:
+				if err := c.reload(); err != nil {
+					c.log.Errorw("unable to reload certificates, keep serving the previous ones", "error", err)
+					continue
+				}
+				c.log.Infow("certificates reloaded", "cert", c.certFile)
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/majst01/metal-dns/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCertReloader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	certReloadDelay = 10 * time.Millisecond
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+
+	issue := func() string {
+		ca, err := test.NewCA()
+		require.NoError(t, err)
+		cert, key, err := ca.Issue("metal-dns")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(certFile, cert, 0600))
+		require.NoError(t, os.WriteFile(keyFile, key, 0600))
+		caFile := filepath.Join(t.TempDir(), "ca.crt")
+		require.NoError(t, os.WriteFile(caFile, ca.PEM, 0600))
+		return caFile
+	}
+
+	firstCA := issue()
+	certs, err := newCertReloader(zaptest.NewLogger(t).Sugar(), certFile, keyFile, "")
+	require.NoError(t, err)
+	require.NoError(t, certs.Watch(ctx))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = certs.TLSConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	get := func(ca string) error {
+		c := (&client.DialConfig{CA: ca}).HttpClient()
+		resp, err := c.Get(server.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	require.NoError(t, get(firstCA))
+
+	secondCA := issue()
+	require.Eventually(t, func() bool {
+		return get(secondCA) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Error(t, get(firstCA))
+
+	// invalid certificates are not loaded
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0600))
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, get(secondCA))
+}
+
+func TestNewCertReloaderInvalid(t *testing.T) {
+	dir := t.TempDir()
+	_, err := newCertReloader(zaptest.NewLogger(t).Sugar(), filepath.Join(dir, "missing.crt"), filepath.Join(dir, "missing.key"), "")
+	require.Error(t, err)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	connect "github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/audit"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type AuditService struct {
+	store audit.Store
+	log   *zap.SugaredLogger
+}
+
+func NewAuditService(l *zap.SugaredLogger, store audit.Store) *AuditService {
+	return &AuditService{
+		store: store,
+		log:   l.Named("audit"),
+	}
+}
+
+// List returns the entries of the audit log, only entries which affected domains of the caller are returned
+func (a *AuditService) List(ctx context.Context, rq *connect.Request[v1.AuditServiceListRequest]) (*connect.Response[v1.AuditServiceListResponse], error) {
+	a.log.Debugw("list", "req", rq)
+	req := rq.Msg
+	claims := token.ClaimsFromContext(ctx)
+
+	filter := audit.Filter{}
+	if req.Domain != nil {
+		filter.Domain = *req.Domain
+	}
+	if req.Subject != nil {
+		filter.Subject = *req.Subject
+	}
+	if req.From != nil {
+		filter.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		filter.To = req.To.AsTime()
+	}
+
+	entries, err := a.store.List(ctx, filter)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	result := []*v1.AuditEntry{}
+	for _, e := range entries {
+		if !domainsAllowed(e.Domains, claims.Domains) {
+			continue
+		}
+		result = append(result, &v1.AuditEntry{
+			Time:      timestamppb.New(e.Time),
+			Procedure: e.Procedure,
+			Subject:   e.Subject,
+			Issuer:    e.Issuer,
+			Jti:       e.JTI,
+			Domains:   e.Domains,
+			Request:   e.Request,
+			Before:    e.Before,
+			After:     e.After,
+			Result:    e.Result,
+			Error:     e.Error,
+		})
+	}
+	return connect.NewResponse(&v1.AuditServiceListResponse{Entries: result}), nil
+}
+
+// domainsAllowed returns true if all domains are one of the allowed domains or a subdomain of them, compared by labels
+func domainsAllowed(domains, allowed []string) bool {
+	if len(domains) == 0 {
+		return false
+	}
+	for _, d := range domains {
+		found := false
+		for _, a := range allowed {
+			if dns.IsSubDomain(dns.CanonicalName(a), dns.CanonicalName(d)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Snapshot returns the records of the rrsets which are modified by req,
+// for domain requests all records of the zone are returned.
+// A zone which can not be read is treated as empty, e.g. before it is created.
+func (r *RecordService) Snapshot(ctx context.Context, req any) (*audit.Snapshot, error) {
+	var (
+		domain string
+		name   *string
+		rrtype *v1.RecordType
+		err    error
+	)
+	switch req := req.(type) {
+	case *v1.DomainServiceCreateRequest:
+		domain = req.Name
+	case *v1.DomainServiceUpdateRequest:
+		domain = req.Name
+	case *v1.DomainServiceDeleteRequest:
+		domain = req.Name
+	case *v1.DomainServiceRollbackRequest:
+		domain = req.Name
+	case *v1.RecordServiceCreateRequest:
+		domain, err = zoneOf(ctx, r.backend, req.Name)
+		name, rrtype = &req.Name, &req.Type
+	case *v1.RecordServiceUpdateRequest:
+		domain, err = zoneOf(ctx, r.backend, req.Name)
+		name, rrtype = &req.Name, &req.Type
+	case *v1.RecordServiceDeleteRequest:
+		domain, err = zoneOf(ctx, r.backend, req.Name)
+		name, rrtype = &req.Name, &req.Type
+	case *v1.ChallengeServicePresentRequest:
+		domain, err = zoneOf(ctx, r.backend, req.Name)
+		name, rrtype = &req.Name, v1.RecordType_TXT.Enum()
+	case *v1.ChallengeServiceCleanUpRequest:
+		domain, err = zoneOf(ctx, r.backend, req.Name)
+		name, rrtype = &req.Name, v1.RecordType_TXT.Enum()
+	default:
+		return nil, fmt.Errorf("unable to snapshot %T", req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := &audit.Snapshot{Domains: []string{domain}}
+	if name != nil {
+		s.Names = []string{*name}
+	}
+	zone, err := r.backend.Zones.Get(ctx, domain)
+	if err != nil {
+		r.log.Debugw("zone not readable, snapshot is empty", "domain", domain, "error", err)
+		return s, nil
+	}
+	for _, rset := range zone.RRsets {
+		if name != nil && *name != *rset.Name {
+			continue
+		}
+		if rrtype != nil && rrtype.String() != string(*rset.Type) {
+			continue
+		}
+		for _, record := range rset.Records {
+			s.Records = append(s.Records, toV1Record(record, rset))
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainsAllowed(t *testing.T) {
+	allowed := []string{"example.com."}
+	tests := []struct {
+		name    string
+		domains []string
+		want    bool
+	}{
+		{name: "domain", domains: []string{"example.com."}, want: true},
+		{name: "subdomain", domains: []string{"a.example.com.", "example.com."}, want: true},
+		{name: "case and trailing dot", domains: []string{"A.Example.com"}, want: true},
+		{name: "neighbour suffix", domains: []string{"badexample.com."}},
+		{name: "one of them not allowed", domains: []string{"a.example.com.", "example.org."}},
+		{name: "none", domains: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, domainsAllowed(tt.domains, allowed))
+		})
+	}
+}
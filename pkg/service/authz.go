@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	connect "github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/token"
+	"go.uber.org/zap"
+)
+
+type AuthzService struct {
+	authz *auth.OpaAuther
+	log   *zap.SugaredLogger
+}
+
+func NewAuthzService(l *zap.SugaredLogger, authz *auth.OpaAuther) *AuthzService {
+	return &AuthzService{
+		authz: authz,
+		log:   l.Named("authz"),
+	}
+}
+
+// Explain evaluates the given method and request with the token of the caller
+func (a *AuthzService) Explain(ctx context.Context, rq *connect.Request[v1.AuthzServiceExplainRequest]) (*connect.Response[v1.AuthzServiceExplainResponse], error) {
+	a.log.Debugw("explain", "req", rq)
+	req := rq.Msg
+	// the authorizer stores the token of the caller, the bearer token or the token of the client certificate
+	jwtToken, ok := token.TokenFromContext(ctx)
+	if !ok {
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("no token found"))
+	}
+	var request map[string]any
+	if req.Request != nil {
+		request = req.Request.AsMap()
+	}
+	explanation, err := a.authz.Explain(ctx, req.Method, request, jwtToken)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1.AuthzServiceExplainResponse{
+		Allow:   explanation.Allow,
+		IsAdmin: explanation.IsAdmin,
+		Reason:  explanation.Reason,
+		Rules:   explanation.Rules,
+		Prints:  explanation.Prints,
+	}), nil
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestExplainClientCertificate(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	authz, err := auth.NewOpaAuther(log, "secret", auth.WithClientSubjects([]auth.ClientSubject{
+		{Subject: "external-dns", Domains: []string{"a.example.com."}, Permissions: []string{apiv1connect.AuthzServiceExplainProcedure, apiv1connect.RecordServiceCreateProcedure}},
+	}))
+	require.NoError(t, err)
+
+	ca, err := test.NewCA()
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(ca.PEM))
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewAuthzServiceHandler(NewAuthzService(log, authz), connect.WithInterceptors(authz)))
+	server := httptest.NewUnstartedServer(auth.ClientCertificateHandler(mux))
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{ClientCAs: pool, ClientAuth: tls.VerifyClientCertIfGiven, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	certPEM, keyPEM, err := ca.Issue("external-dns")
+	require.NoError(t, err)
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	transport := server.Client().Transport.(*http.Transport).Clone()
+	transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	c := apiv1connect.NewAuthzServiceClient(&http.Client{Transport: transport}, server.URL)
+
+	explain := func(name string) bool {
+		req, err := structpb.NewStruct(map[string]any{"name": name})
+		require.NoError(t, err)
+		resp, err := c.Explain(ctx, connect.NewRequest(&v1.AuthzServiceExplainRequest{Method: apiv1connect.RecordServiceCreateProcedure, Request: req}))
+		require.NoError(t, err)
+		return resp.Msg.Allow
+	}
+	require.True(t, explain("www.a.example.com."))
+	require.False(t, explain("www.b.example.com."))
+}
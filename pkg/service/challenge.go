@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/joeig/go-powerdns/v3"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const (
+	// ChallengePrefix is the first label of the TXT records of ACME DNS-01 challenges
+	ChallengePrefix = "_acme-challenge."
+
+	defaultChallengeTTL = 60
+)
+
+// ChallengeService manages the TXT records of ACME DNS-01 challenges. Challenges of several certificates,
+// e.g. for example.com and *.example.com, share the same rrset, values are therefore added and removed
+// without touching the others.
+type ChallengeService struct {
+	backend *backend.Backend
+	log     *zap.SugaredLogger
+	// lock serializes the read-modify-write of the rrsets, it does not protect against other metal-dns instances
+	lock   sync.Mutex
+	events *events.Bus
+}
+
+func NewChallengeService(l *zap.SugaredLogger, b *backend.Backend) *ChallengeService {
+	return &ChallengeService{
+		backend: b,
+		log:     l.Named("challenge"),
+	}
+}
+
+func (c *ChallengeService) Present(ctx context.Context, rq *connect.Request[v1.ChallengeServicePresentRequest]) (*connect.Response[v1.ChallengeServicePresentResponse], error) {
+	c.log.Debugw("present", "req", rq)
+	req := rq.Msg
+	if err := validateChallenge(req.Name, req.Value); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	ttl := req.Ttl
+	if ttl == 0 {
+		ttl = defaultChallengeTTL
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	zone, values, _, err := c.challengeRRset(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	value := strconv.Quote(req.Value)
+	if !contains(values, value) {
+		values = append(values, value)
+		c.log.Infow("present challenge", "zone", zone, "name", req.Name, "values", len(values))
+		err = c.backend.Records.Change(ctx, zone, req.Name, powerdns.RRTypeTXT, ttl, values)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		c.events.Publish(recordEvent(v1.EventType_EVENT_TYPE_CREATED, zone, &v1.Record{Name: req.Name, Type: v1.RecordType_TXT, Data: value, Ttl: ttl}))
+	}
+	return connect.NewResponse(&v1.ChallengeServicePresentResponse{Values: unquote(values)}), nil
+}
+
+func (c *ChallengeService) CleanUp(ctx context.Context, rq *connect.Request[v1.ChallengeServiceCleanUpRequest]) (*connect.Response[v1.ChallengeServiceCleanUpResponse], error) {
+	c.log.Debugw("cleanup", "req", rq)
+	req := rq.Msg
+	if err := validateChallenge(req.Name, req.Value); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	zone, values, ttl, err := c.challengeRRset(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	value := strconv.Quote(req.Value)
+	if !contains(values, value) {
+		return connect.NewResponse(&v1.ChallengeServiceCleanUpResponse{Values: unquote(values)}), nil
+	}
+
+	var remaining []string
+	for _, v := range values {
+		if v != value {
+			remaining = append(remaining, v)
+		}
+	}
+	c.log.Infow("clean up challenge", "zone", zone, "name", req.Name, "values", len(remaining))
+	if len(remaining) == 0 {
+		err = c.backend.Records.Delete(ctx, zone, req.Name, powerdns.RRTypeTXT)
+	} else {
+		err = c.backend.Records.Change(ctx, zone, req.Name, powerdns.RRTypeTXT, ttl, remaining)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	c.events.Publish(recordEvent(v1.EventType_EVENT_TYPE_DELETED, zone, &v1.Record{Name: req.Name, Type: v1.RecordType_TXT, Data: value, Ttl: ttl}))
+	return connect.NewResponse(&v1.ChallengeServiceCleanUpResponse{Values: unquote(remaining)}), nil
+}
+
+// challengeRRset returns the zone of name and the values and ttl of its TXT rrset
+func (c *ChallengeService) challengeRRset(ctx context.Context, name string) (string, []string, uint32, error) {
+	zone, err := findZone(ctx, c.backend, name)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	for _, rset := range zone.RRsets {
+		if powerdns.StringValue(rset.Name) != name || rset.Type == nil || *rset.Type != powerdns.RRTypeTXT {
+			continue
+		}
+		var values []string
+		for _, r := range rset.Records {
+			values = append(values, powerdns.StringValue(r.Content))
+		}
+		return *zone.Name, values, powerdns.Uint32Value(rset.TTL), nil
+	}
+	return *zone.Name, nil, 0, nil
+}
+
+func validateChallenge(name, value string) error {
+	if _, ok := dns.IsDomainName(name); !ok || !dns.IsFqdn(name) {
+		return fmt.Errorf("%q is not a fully qualified domain name", name)
+	}
+	if !strings.HasPrefix(name, ChallengePrefix) {
+		return fmt.Errorf("name must start with %s", ChallengePrefix)
+	}
+	if value == "" {
+		return errors.New("value must not be empty")
+	}
+	return nil
+}
+
+func unquote(values []string) []string {
+	result := []string{}
+	for _, v := range values {
+		if u, err := strconv.Unquote(v); err == nil {
+			v = u
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestChallenge(t *testing.T) {
+	ctx := context.Background()
+	pdns, err := test.StartPowerDNS()
+	require.NoError(t, err)
+
+	log := zaptest.NewLogger(t).Sugar()
+	b := backend.NewPowerDNS(pdns.BaseURL, pdns.VHost, pdns.APIKey, nil)
+	ds := NewDomainService(log, b)
+	cs := NewChallengeService(log, b)
+
+	_, err = ds.Create(ctx, connect.NewRequest(&v1.DomainServiceCreateRequest{Name: "challenge.com.", Nameservers: []string{"ns1.challenge.com."}}))
+	require.NoError(t, err)
+
+	const name = "_acme-challenge.www.challenge.com."
+
+	// challenges of www.challenge.com and *.www.challenge.com share the rrset
+	p1, err := cs.Present(ctx, connect.NewRequest(&v1.ChallengeServicePresentRequest{Name: name, Value: "first"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"first"}, p1.Msg.Values)
+
+	p2, err := cs.Present(ctx, connect.NewRequest(&v1.ChallengeServicePresentRequest{Name: name, Value: "second"}))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"first", "second"}, p2.Msg.Values)
+
+	// presenting a value again does not duplicate it
+	p3, err := cs.Present(ctx, connect.NewRequest(&v1.ChallengeServicePresentRequest{Name: name, Value: "second"}))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"first", "second"}, p3.Msg.Values)
+
+	txt, err := pdns.Resolver.LookupTXT(ctx, name)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"first", "second"}, txt)
+
+	c1, err := cs.CleanUp(ctx, connect.NewRequest(&v1.ChallengeServiceCleanUpRequest{Name: name, Value: "first"}))
+	require.NoError(t, err)
+	require.Equal(t, []string{"second"}, c1.Msg.Values)
+
+	c2, err := cs.CleanUp(ctx, connect.NewRequest(&v1.ChallengeServiceCleanUpRequest{Name: name, Value: "second"}))
+	require.NoError(t, err)
+	require.Empty(t, c2.Msg.Values)
+
+	// cleaning up an unknown value is not an error
+	_, err = cs.CleanUp(ctx, connect.NewRequest(&v1.ChallengeServiceCleanUpRequest{Name: name, Value: "second"}))
+	require.NoError(t, err)
+
+	_, err = cs.Present(ctx, connect.NewRequest(&v1.ChallengeServicePresentRequest{Name: "www.challenge.com.", Value: "first"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = cs.Present(ctx, connect.NewRequest(&v1.ChallengeServicePresentRequest{Name: "_acme-challenge.www.unknown.org.", Value: "first"}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestValidateChallenge(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "_acme-challenge.example.com.", value: "abc"},
+		{name: "_acme-challenge.example.com", value: "abc", wantErr: `"_acme-challenge.example.com" is not a fully qualified domain name`},
+		{name: "www.example.com.", value: "abc", wantErr: "name must start with _acme-challenge."},
+		{name: "_acme-challenge.example.com.", wantErr: "value must not be empty"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateChallenge(tt.name, tt.value)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
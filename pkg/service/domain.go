@@ -2,28 +2,30 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
 
 	connect "github.com/bufbuild/connect-go"
 	"github.com/joeig/go-powerdns/v3"
 	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/history"
 	"github.com/majst01/metal-dns/pkg/token"
 	"go.uber.org/zap"
 )
 
 type DomainService struct {
-	pdns  *powerdns.Client
-	log   *zap.SugaredLogger
-	vhost string
+	backend *backend.Backend
+	log     *zap.SugaredLogger
+	history history.Store
+	events  *events.Bus
 }
 
-func NewDomainService(l *zap.SugaredLogger, baseURL string, vHost string, apikey string, httpClient *http.Client) *DomainService {
-	pdns := powerdns.NewClient(baseURL, vHost, map[string]string{"X-API-Key": apikey}, httpClient)
+func NewDomainService(l *zap.SugaredLogger, b *backend.Backend) *DomainService {
 	return &DomainService{
-		pdns:  pdns,
-		log:   l.Named("domain"),
-		vhost: vHost,
+		backend: b,
+		log:     l.Named("domain"),
 	}
 }
 
@@ -38,7 +40,7 @@ func (d *DomainService) List(ctx context.Context, rq *connect.Request[v1.DomainS
 		return nil, connect.NewError(connect.CodeUnauthenticated, err)
 	}
 
-	zones, err := d.pdns.Zones.List(ctx)
+	zones, err := d.backend.Zones.List(ctx)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -58,7 +60,7 @@ func (d *DomainService) List(ctx context.Context, rq *connect.Request[v1.DomainS
 func (d *DomainService) Get(ctx context.Context, rq *connect.Request[v1.DomainServiceGetRequest]) (*connect.Response[v1.DomainServiceGetResponse], error) {
 	d.log.Debugw("get", "req", rq)
 	req := rq.Msg
-	zone, err := d.pdns.Zones.Get(ctx, req.Name)
+	zone, err := d.backend.Zones.Get(ctx, req.Name)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
@@ -84,18 +86,19 @@ func (d *DomainService) Create(ctx context.Context, rq *connect.Request[v1.Domai
 	if req.Url != nil {
 		zone.URL = req.Url
 	}
-	zone, err := d.pdns.Zones.Add(ctx, zone)
+	zone, err := d.backend.Zones.Add(ctx, zone)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, backendError(err)
 	}
 	domain := toV1Domain(zone)
+	d.events.Publish(domainEvent(v1.EventType_EVENT_TYPE_CREATED, domain))
 	return connect.NewResponse(&v1.DomainServiceCreateResponse{Domain: domain}), nil
 }
 
 func (d *DomainService) Update(ctx context.Context, rq *connect.Request[v1.DomainServiceUpdateRequest]) (*connect.Response[v1.DomainServiceUpdateResponse], error) {
 	d.log.Debugw("update", "req", rq)
 	req := rq.Msg
-	existingZone, err := d.pdns.Zones.Get(ctx, req.Name)
+	existingZone, err := d.backend.Zones.Get(ctx, req.Name)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeNotFound, err)
 	}
@@ -105,28 +108,38 @@ func (d *DomainService) Update(ctx context.Context, rq *connect.Request[v1.Domai
 		existingZone.URL = req.Url
 	}
 
-	err = d.pdns.Zones.Change(ctx, *existingZone.Name, existingZone)
+	err = d.backend.Zones.Change(ctx, *existingZone.Name, existingZone)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, backendError(err)
 	}
 
 	domain := toV1Domain(existingZone)
+	d.events.Publish(domainEvent(v1.EventType_EVENT_TYPE_UPDATED, domain))
 	return connect.NewResponse(&v1.DomainServiceUpdateResponse{Domain: domain}), nil
 }
 
 func (d *DomainService) Delete(ctx context.Context, rq *connect.Request[v1.DomainServiceDeleteRequest]) (*connect.Response[v1.DomainServiceDeleteResponse], error) {
 	d.log.Debugw("delete", "req", rq)
 	req := rq.Msg
-	err := d.pdns.Zones.Delete(ctx, req.Name)
+	err := d.backend.Zones.Delete(ctx, req.Name)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, backendError(err)
 	}
 	domain := &v1.Domain{
 		Name: req.Name,
 	}
+	d.events.Publish(domainEvent(v1.EventType_EVENT_TYPE_DELETED, domain))
 	return connect.NewResponse(&v1.DomainServiceDeleteResponse{Domain: domain}), nil
 }
 
+// backendError returns Unimplemented for operations the backend does not support and Internal otherwise
+func backendError(err error) error {
+	if errors.Is(err, backend.ErrUnsupported) {
+		return connect.NewError(connect.CodeUnimplemented, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
 func toV1Domain(zone *powerdns.Zone) *v1.Domain {
 	return &v1.Domain{
 		Id:          *zone.ID,
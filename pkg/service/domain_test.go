@@ -7,6 +7,7 @@ import (
 
 	"github.com/bufbuild/connect-go"
 	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
 	"github.com/majst01/metal-dns/pkg/token"
 	"github.com/majst01/metal-dns/test"
 	"github.com/stretchr/testify/require"
@@ -21,13 +22,14 @@ func TestDomainListCreate(t *testing.T) {
 
 	log := zaptest.NewLogger(t).Sugar()
 
-	ds := NewDomainService(log, pdns.BaseURL, pdns.VHost, pdns.APIKey, nil)
+	b := backend.NewPowerDNS(pdns.BaseURL, pdns.VHost, pdns.APIKey, nil)
+	ds := NewDomainService(log, b)
 	require.NotNil(t, ds)
 
-	rs := NewRecordService(log, pdns.BaseURL, pdns.VHost, pdns.APIKey, nil)
+	rs := NewRecordService(log, b)
 	require.NotNil(t, ds)
 
-	jwttoken, err := newJWTToken("test", "Tester", []string{"example.com"}, nil, time.Hour, "secret")
+	jwttoken, err := token.NewJWTToken("test", "Tester", []string{"example.com"}, nil, time.Hour, "secret")
 	require.NoError(t, err)
 	require.NotNil(t, jwttoken)
 
@@ -0,0 +1,10 @@
+package service
+
+import (
+	"context"
+)
+
+// Probe checks that the backend is reachable
+func (d *DomainService) Probe(ctx context.Context) error {
+	return d.backend.Probe(ctx)
+}
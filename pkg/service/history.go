@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/joeig/go-powerdns/v3"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/history"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errHistoryDisabled = errors.New("history is not enabled on this server")
+
+// WithHistory enables ListRevisions, DiffRevisions and Rollback with the revisions in store
+func (d *DomainService) WithHistory(store history.Store) *DomainService {
+	d.history = store
+	return d
+}
+
+func (d *DomainService) ListRevisions(ctx context.Context, rq *connect.Request[v1.DomainServiceListRevisionsRequest]) (*connect.Response[v1.DomainServiceListRevisionsResponse], error) {
+	d.log.Debugw("list revisions", "req", rq)
+	if d.history == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errHistoryDisabled)
+	}
+	req := rq.Msg
+	revs, err := d.history.List(ctx, req.Name)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	revisions := []*v1.Revision{}
+	for _, rev := range revs {
+		revisions = append(revisions, &v1.Revision{
+			Id:        rev.ID,
+			Domain:    rev.Zone,
+			Time:      timestamppb.New(rev.Time),
+			Subject:   rev.Subject,
+			Procedure: rev.Procedure,
+		})
+	}
+	return connect.NewResponse(&v1.DomainServiceListRevisionsResponse{Revisions: revisions}), nil
+}
+
+func (d *DomainService) DiffRevisions(ctx context.Context, rq *connect.Request[v1.DomainServiceDiffRevisionsRequest]) (*connect.Response[v1.DomainServiceDiffRevisionsResponse], error) {
+	d.log.Debugw("diff revisions", "req", rq)
+	if d.history == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errHistoryDisabled)
+	}
+	req := rq.Msg
+	from, err := d.revision(ctx, req.Name, req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	var to []history.RRset
+	if req.To != nil {
+		rev, err := d.revision(ctx, req.Name, *req.To)
+		if err != nil {
+			return nil, err
+		}
+		to = rev.RRsets
+	} else {
+		to, _, err = d.currentRRsets(ctx, req.Name)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	changes := toV1Changes(history.Diff(from.RRsets, to))
+	return connect.NewResponse(&v1.DomainServiceDiffRevisionsResponse{Changes: changes}), nil
+}
+
+// Rollback restores the rrsets of a zone to the state of a revision with a single patch,
+// a deleted zone is created again.
+func (d *DomainService) Rollback(ctx context.Context, rq *connect.Request[v1.DomainServiceRollbackRequest]) (*connect.Response[v1.DomainServiceRollbackResponse], error) {
+	d.log.Debugw("rollback", "req", rq)
+	if d.history == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errHistoryDisabled)
+	}
+	req := rq.Msg
+	rev, err := d.revision(ctx, req.Name, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	current, exists, err := d.currentRRsets(ctx, req.Name)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	changes := history.Diff(current, rev.RRsets)
+	d.log.Infow("rollback", "domain", req.Name, "revision", rev.ID, "changes", len(changes))
+
+	if !exists {
+		zone := &powerdns.Zone{
+			Name:       &req.Name,
+			Kind:       powerdns.ZoneKindPtr(powerdns.MasterZoneKind),
+			DNSsec:     powerdns.Bool(false),
+			APIRectify: powerdns.Bool(false),
+		}
+		for _, r := range rev.RRsets {
+			zone.RRsets = append(zone.RRsets, toPdnsRRset(r))
+		}
+		_, err = d.backend.Zones.Add(ctx, zone)
+		if err != nil {
+			return nil, backendError(err)
+		}
+	} else if len(changes) > 0 {
+		sets := &powerdns.RRsets{}
+		for _, c := range changes {
+			if c.After == nil {
+				sets.Sets = append(sets.Sets, powerdns.RRset{
+					Name:       powerdns.String(c.Name),
+					Type:       powerdns.RRTypePtr(powerdns.RRType(c.Type)),
+					ChangeType: powerdns.ChangeTypePtr(powerdns.ChangeTypeDelete),
+					Records:    []powerdns.Record{},
+				})
+				continue
+			}
+			set := toPdnsRRset(*c.After)
+			set.ChangeType = powerdns.ChangeTypePtr(powerdns.ChangeTypeReplace)
+			sets.Sets = append(sets.Sets, set)
+		}
+		err = d.backend.Records.Patch(ctx, req.Name, sets)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	zone, err := d.backend.Zones.Get(ctx, req.Name)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	eventType := v1.EventType_EVENT_TYPE_UPDATED
+	if !exists {
+		eventType = v1.EventType_EVENT_TYPE_CREATED
+	}
+	d.events.Publish(append([]events.Event{domainEvent(eventType, toV1Domain(zone))}, changeEvents(req.Name, changes)...)...)
+	return connect.NewResponse(&v1.DomainServiceRollbackResponse{
+		Domain:  toV1Domain(zone),
+		Changes: toV1Changes(changes),
+	}), nil
+}
+
+// ZoneState returns the zone and its rrsets which are modified by req
+func (d *DomainService) ZoneState(ctx context.Context, req any) (string, []history.RRset, error) {
+	var (
+		zone string
+		err  error
+	)
+	switch req := req.(type) {
+	case *v1.DomainServiceUpdateRequest:
+		zone = req.Name
+	case *v1.DomainServiceDeleteRequest:
+		zone = req.Name
+	case *v1.DomainServiceRollbackRequest:
+		zone = req.Name
+	case *v1.RecordServiceCreateRequest:
+		zone, err = zoneOf(ctx, d.backend, req.Name)
+	case *v1.RecordServiceUpdateRequest:
+		zone, err = zoneOf(ctx, d.backend, req.Name)
+	case *v1.RecordServiceDeleteRequest:
+		zone, err = zoneOf(ctx, d.backend, req.Name)
+	case *v1.ChallengeServicePresentRequest:
+		zone, err = zoneOf(ctx, d.backend, req.Name)
+	case *v1.ChallengeServiceCleanUpRequest:
+		zone, err = zoneOf(ctx, d.backend, req.Name)
+	default:
+		return "", nil, fmt.Errorf("unable to snapshot %T", req)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+	z, err := d.backend.Zones.Get(ctx, zone)
+	if err != nil {
+		return "", nil, err
+	}
+	return zone, toHistoryRRsets(z.RRsets), nil
+}
+
+func (d *DomainService) revision(ctx context.Context, zone string, id uint64) (*history.Revision, error) {
+	rev, err := d.history.Get(ctx, zone, id)
+	if errors.Is(err, history.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("revision %d of %s %w", id, zone, err))
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return rev, nil
+}
+
+// currentRRsets returns the rrsets of zone and whether the zone exists
+func (d *DomainService) currentRRsets(ctx context.Context, zone string) ([]history.RRset, bool, error) {
+	z, err := d.backend.Zones.Get(ctx, zone)
+	if err != nil {
+		if backend.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	return toHistoryRRsets(z.RRsets), true, nil
+}
+
+// toHistoryRRsets converts the rrsets of a zone, the SOA is managed by powerdns and never restored
+func toHistoryRRsets(rrsets []powerdns.RRset) []history.RRset {
+	result := []history.RRset{}
+	for _, rset := range rrsets {
+		if rset.Type == nil || *rset.Type == powerdns.RRTypeSOA {
+			continue
+		}
+		r := history.RRset{
+			Name: powerdns.StringValue(rset.Name),
+			Type: string(*rset.Type),
+			TTL:  powerdns.Uint32Value(rset.TTL),
+		}
+		for _, record := range rset.Records {
+			r.Records = append(r.Records, history.Record{
+				Content:  powerdns.StringValue(record.Content),
+				Disabled: powerdns.BoolValue(record.Disabled),
+			})
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+func toPdnsRRset(r history.RRset) powerdns.RRset {
+	set := powerdns.RRset{
+		Name:    powerdns.String(r.Name),
+		Type:    powerdns.RRTypePtr(powerdns.RRType(r.Type)),
+		TTL:     powerdns.Uint32(r.TTL),
+		Records: []powerdns.Record{},
+	}
+	for _, record := range r.Records {
+		set.Records = append(set.Records, powerdns.Record{
+			Content:  powerdns.String(record.Content),
+			Disabled: powerdns.Bool(record.Disabled),
+		})
+	}
+	return set
+}
+
+func toV1Changes(changes []history.Change) []*v1.RecordSetChange {
+	result := []*v1.RecordSetChange{}
+	for _, c := range changes {
+		rrtype := powerdns.RRType(c.Type)
+		result = append(result, &v1.RecordSetChange{
+			Name:   c.Name,
+			Type:   toV1RecordType(&rrtype),
+			Before: toV1Records(c.Before),
+			After:  toV1Records(c.After),
+		})
+	}
+	return result
+}
+
+func toV1Records(r *history.RRset) []*v1.Record {
+	if r == nil {
+		return nil
+	}
+	rrtype := powerdns.RRType(r.Type)
+	records := []*v1.Record{}
+	for _, record := range r.Records {
+		records = append(records, &v1.Record{
+			Name: r.Name,
+			Type: toV1RecordType(&rrtype),
+			Data: record.Content,
+			Ttl:  r.TTL,
+		})
+	}
+	return records
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/history"
+	"github.com/majst01/metal-dns/test"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	pdns, err := test.StartPowerDNS()
+	require.NoError(t, err)
+	require.NotNil(t, pdns)
+
+	log := zaptest.NewLogger(t).Sugar()
+
+	store, err := history.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	b := backend.NewPowerDNS(pdns.BaseURL, pdns.VHost, pdns.APIKey, nil)
+	ds := NewDomainService(log, b).WithHistory(store)
+	rs := NewRecordService(log, b)
+
+	// snapshot behaves like the history interceptor
+	snapshot := func(req any) {
+		zone, rrsets, err := ds.ZoneState(ctx, req)
+		require.NoError(t, err)
+		require.NoError(t, store.Append(ctx, &history.Revision{Zone: zone, RRsets: rrsets}))
+	}
+
+	_, err = ds.Create(ctx, connect.NewRequest(&v1.DomainServiceCreateRequest{Name: "history.com.", Nameservers: []string{"ns1.history.com."}}))
+	require.NoError(t, err)
+	defer func() {
+		_, _ = ds.Delete(ctx, connect.NewRequest(&v1.DomainServiceDeleteRequest{Name: "history.com."}))
+	}()
+
+	create := &v1.RecordServiceCreateRequest{Type: v1.RecordType_A, Name: "www.history.com.", Data: "1.2.3.4", Ttl: uint32(600)}
+	snapshot(create)
+	_, err = rs.Create(ctx, connect.NewRequest(create))
+	require.NoError(t, err)
+
+	update := &v1.RecordServiceUpdateRequest{Type: v1.RecordType_A, Name: "www.history.com.", Data: "2.3.4.5", Ttl: uint32(300)}
+	snapshot(update)
+	_, err = rs.Update(ctx, connect.NewRequest(update))
+	require.NoError(t, err)
+
+	revs, err := ds.ListRevisions(ctx, connect.NewRequest(&v1.DomainServiceListRevisionsRequest{Name: "history.com."}))
+	require.NoError(t, err)
+	require.Len(t, revs.Msg.Revisions, 2)
+
+	diff, err := ds.DiffRevisions(ctx, connect.NewRequest(&v1.DomainServiceDiffRevisionsRequest{Name: "history.com.", From: 2}))
+	require.NoError(t, err)
+	require.Len(t, diff.Msg.Changes, 1)
+	require.Equal(t, "www.history.com.", diff.Msg.Changes[0].Name)
+	require.Equal(t, "1.2.3.4", diff.Msg.Changes[0].Before[0].Data)
+	require.Equal(t, "2.3.4.5", diff.Msg.Changes[0].After[0].Data)
+
+	// back to the state before the record was created
+	resp, err := ds.Rollback(ctx, connect.NewRequest(&v1.DomainServiceRollbackRequest{Name: "history.com.", Revision: 1}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Changes, 1)
+	require.Nil(t, resp.Msg.Changes[0].After)
+
+	rr, err := rs.List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: "history.com.", Type: v1.RecordType_A}))
+	require.NoError(t, err)
+	require.Len(t, rr.Msg.Records, 0)
+
+	// and forward to the first version of the record
+	_, err = ds.Rollback(ctx, connect.NewRequest(&v1.DomainServiceRollbackRequest{Name: "history.com.", Revision: 2}))
+	require.NoError(t, err)
+
+	rr, err = rs.List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: "history.com.", Type: v1.RecordType_A}))
+	require.NoError(t, err)
+	require.Len(t, rr.Msg.Records, 1)
+	require.Equal(t, "1.2.3.4", rr.Msg.Records[0].Data)
+	require.Equal(t, uint32(600), rr.Msg.Records[0].Ttl)
+
+	_, err = ds.Rollback(ctx, connect.NewRequest(&v1.DomainServiceRollbackRequest{Name: "history.com.", Revision: 42}))
+	require.Error(t, err)
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -3,26 +3,29 @@ package service
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	connect "github.com/bufbuild/connect-go"
 	"github.com/joeig/go-powerdns/v3"
 	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/propagation"
 	"github.com/miekg/dns"
 	"go.uber.org/zap"
 )
 
 type RecordService struct {
-	pdns *powerdns.Client
-	log  *zap.SugaredLogger
+	backend     *backend.Backend
+	log         *zap.SugaredLogger
+	propagation *propagation.Verifier
+	events      *events.Bus
 }
 
-func NewRecordService(l *zap.SugaredLogger, baseURL string, vHost string, apikey string, httpClient *http.Client) *RecordService {
-	pdns := powerdns.NewClient(baseURL, vHost, map[string]string{"X-API-Key": apikey}, httpClient)
+func NewRecordService(l *zap.SugaredLogger, b *backend.Backend) *RecordService {
 	return &RecordService{
-		pdns: pdns,
-		log:  l.Named("record"),
+		backend: b,
+		log:     l.Named("record"),
 	}
 }
 
@@ -38,7 +41,7 @@ const (
 func (r *RecordService) List(ctx context.Context, rq *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
 	r.log.Debugw("list", "req", rq)
 	req := rq.Msg
-	zone, err := r.pdns.Zones.Get(ctx, req.Domain)
+	zone, err := r.backend.Zones.Get(ctx, req.Domain)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -86,13 +89,16 @@ func (r *RecordService) List(ctx context.Context, rq *connect.Request[v1.RecordS
 func (r *RecordService) Create(ctx context.Context, rq *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
 	r.log.Debugw("create", "req", rq)
 	req := rq.Msg
-	domain, err := domainFromFQDN(req.Name)
+	if err := r.checkPropagation(req.WaitForPropagation, req.Type); err != nil {
+		return nil, err
+	}
+	domain, err := zoneOf(ctx, r.backend, req.Name)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, err
 	}
 	rrtype := powerdns.RRType(req.Type.String())
 	r.log.Infow("create record", "domain", domain, "name", req.Name, "type", rrtype)
-	err = r.pdns.Records.Add(ctx, domain, req.Name, rrtype, req.Ttl, []string{req.Data})
+	err = r.backend.Records.Add(ctx, domain, req.Name, rrtype, req.Ttl, []string{req.Data})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -102,18 +108,26 @@ func (r *RecordService) Create(ctx context.Context, rq *connect.Request[v1.Recor
 		Type: req.Type,
 		Ttl:  req.Ttl,
 	}
-	return connect.NewResponse(&v1.RecordServiceCreateResponse{Record: record}), nil
+	r.events.Publish(recordEvent(v1.EventType_EVENT_TYPE_CREATED, domain, record))
+	resp := &v1.RecordServiceCreateResponse{Record: record}
+	if req.WaitForPropagation {
+		resp.Propagation = r.waitForPropagation(ctx, domain, req.Name, req.Type, []string{req.Data})
+	}
+	return connect.NewResponse(resp), nil
 }
 
 func (r *RecordService) Update(ctx context.Context, rq *connect.Request[v1.RecordServiceUpdateRequest]) (*connect.Response[v1.RecordServiceUpdateResponse], error) {
 	r.log.Debugw("update", "req", rq)
 	req := rq.Msg
-	domain, err := domainFromFQDN(req.Name)
+	if err := r.checkPropagation(req.WaitForPropagation, req.Type); err != nil {
+		return nil, err
+	}
+	domain, err := zoneOf(ctx, r.backend, req.Name)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, err
 	}
 	rrtype := powerdns.RRType(req.Type.String())
-	err = r.pdns.Records.Change(ctx, domain, req.Name, rrtype, req.Ttl, []string{req.Data})
+	err = r.backend.Records.Change(ctx, domain, req.Name, rrtype, req.Ttl, []string{req.Data})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -123,18 +137,26 @@ func (r *RecordService) Update(ctx context.Context, rq *connect.Request[v1.Recor
 		Type: req.Type,
 		Ttl:  req.Ttl,
 	}
-	return connect.NewResponse(&v1.RecordServiceUpdateResponse{Record: record}), nil
+	r.events.Publish(recordEvent(v1.EventType_EVENT_TYPE_UPDATED, domain, record))
+	resp := &v1.RecordServiceUpdateResponse{Record: record}
+	if req.WaitForPropagation {
+		resp.Propagation = r.waitForPropagation(ctx, domain, req.Name, req.Type, []string{req.Data})
+	}
+	return connect.NewResponse(resp), nil
 }
 
 func (r *RecordService) Delete(ctx context.Context, rq *connect.Request[v1.RecordServiceDeleteRequest]) (*connect.Response[v1.RecordServiceDeleteResponse], error) {
 	r.log.Debugw("delete", "req", rq)
 	req := rq.Msg
-	domain, err := domainFromFQDN(req.Name)
+	if err := r.checkPropagation(req.WaitForPropagation, req.Type); err != nil {
+		return nil, err
+	}
+	domain, err := zoneOf(ctx, r.backend, req.Name)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, err)
+		return nil, err
 	}
 	rrtype := powerdns.RRType(req.Type.String())
-	err = r.pdns.Records.Delete(ctx, domain, req.Name, rrtype)
+	err = r.backend.Records.Delete(ctx, domain, req.Name, rrtype)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
@@ -144,21 +166,42 @@ func (r *RecordService) Delete(ctx context.Context, rq *connect.Request[v1.Recor
 		Data: req.Data,
 		Type: req.Type,
 	}
-	return connect.NewResponse(&v1.RecordServiceDeleteResponse{Record: record}), nil
+	r.events.Publish(recordEvent(v1.EventType_EVENT_TYPE_DELETED, domain, record))
+	resp := &v1.RecordServiceDeleteResponse{Record: record}
+	if req.WaitForPropagation {
+		resp.Propagation = r.waitForPropagation(ctx, domain, req.Name, req.Type, nil)
+	}
+	return connect.NewResponse(resp), nil
 }
 
 // Helper
 
-func domainFromFQDN(fqdn string) (string, error) {
-	_, ok := dns.IsDomainName(fqdn)
-	if !ok {
-		return "", fmt.Errorf("%s is not a domain", fqdn)
+// findZone returns the closest zone which contains name, name itself and then its parent domains are tried one after another
+func findZone(ctx context.Context, b *backend.Backend, name string) (*powerdns.Zone, error) {
+	if _, ok := dns.IsDomainName(name); !ok {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s is not a domain", name))
+	}
+	labels := dns.SplitDomainName(name)
+	for i := range labels {
+		candidate := dns.Fqdn(strings.Join(labels[i:], "."))
+		zone, err := b.Zones.Get(ctx, candidate)
+		if err == nil {
+			return zone, nil
+		}
+		if !backend.IsNotFound(err) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
 	}
-	_, domain, found := strings.Cut(fqdn, ".")
-	if !found {
-		return "", fmt.Errorf("fqdn must contain at least one dot")
+	return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("no zone found for %s", name))
+}
+
+// zoneOf returns the name of the zone of a record or challenge, used by the handlers, the audit log and the history
+func zoneOf(ctx context.Context, b *backend.Backend, name string) (string, error) {
+	zone, err := findZone(ctx, b, name)
+	if err != nil {
+		return "", err
 	}
-	return domain, nil
+	return *zone.Name, nil
 }
 
 func toV1Record(r powerdns.Record, rset powerdns.RRset) *v1.Record {
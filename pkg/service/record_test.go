@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	"github.com/joeig/go-powerdns/v3"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/propagation"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestRecordPropagation(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+	b, err := backend.NewZoneFile(backend.ZoneFileConfig{Directory: t.TempDir()})
+	require.NoError(t, err)
+	_, err = NewDomainService(log, b).Create(ctx, connect.NewRequest(&v1.DomainServiceCreateRequest{Name: "example.com.", Nameservers: []string{"ns1.example.com."}}))
+	require.NoError(t, err)
+	// without nameservers the propagation can not be verified
+	require.NoError(t, b.Records.Delete(ctx, "example.com.", "example.com.", powerdns.RRTypeNS))
+
+	r := NewRecordService(log, b).WithPropagation(propagation.New(log, propagation.Config{}))
+
+	created, err := r.Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{
+		Name: "www.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4", Ttl: 300, WaitForPropagation: true,
+	}))
+	require.NoError(t, err, "the record is written even if its propagation can not be verified")
+	require.Equal(t, "www.example.com.", created.Msg.Record.Name)
+	require.False(t, created.Msg.Propagation.Propagated)
+	require.Equal(t, "no nameservers found for www.example.com.", created.Msg.Propagation.Error)
+
+	list, err := r.List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: "example.com.", Name: &created.Msg.Record.Name, Type: v1.RecordType_A}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Records, 1)
+
+	_, err = r.Verify(ctx, connect.NewRequest(&v1.RecordServiceVerifyRequest{Name: "www.example.org.", Type: v1.RecordType_A}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
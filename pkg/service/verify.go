@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/joeig/go-powerdns/v3"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/propagation"
+	"github.com/miekg/dns"
+)
+
+var errPropagationDisabled = errors.New("propagation verification is disabled")
+
+// WithPropagation enables Verify and wait_for_propagation, the nameservers are queried with verifier
+func (r *RecordService) WithPropagation(verifier *propagation.Verifier) *RecordService {
+	r.propagation = verifier
+	return r
+}
+
+func (r *RecordService) Verify(ctx context.Context, rq *connect.Request[v1.RecordServiceVerifyRequest]) (*connect.Response[v1.RecordServiceVerifyResponse], error) {
+	r.log.Debugw("verify", "req", rq)
+	req := rq.Msg
+	if r.propagation == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, errPropagationDisabled)
+	}
+	rrtype, err := toDNSType(req.Type)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	zone, err := findZone(ctx, r.backend, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := propagation.Normalize(req.Name, rrtype, req.Data); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	expected := req.Data
+	if len(expected) == 0 {
+		expected = rrsetContent(zone, req.Name, powerdns.RRType(req.Type.String()))
+	}
+	result, err := r.propagation.Wait(ctx, rrsetContent(zone, *zone.Name, powerdns.RRTypeNS), req.Name, rrtype, expected, req.Timeout.AsDuration())
+	if err != nil {
+		return nil, propagationError(err)
+	}
+	return connect.NewResponse(&v1.RecordServiceVerifyResponse{Propagation: result}), nil
+}
+
+// checkPropagation fails before a write if its propagation cannot be waited for
+func (r *RecordService) checkPropagation(wait bool, t v1.RecordType) error {
+	if !wait {
+		return nil
+	}
+	if r.propagation == nil {
+		return connect.NewError(connect.CodeUnimplemented, errPropagationDisabled)
+	}
+	if _, err := toDNSType(t); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return nil
+}
+
+// waitForPropagation waits until the nameservers of domain serve the expected content of the rrset.
+// The change is already written, a failed verification is therefore reported in the result instead of failing the call.
+func (r *RecordService) waitForPropagation(ctx context.Context, domain, name string, t v1.RecordType, expected []string) *v1.Propagation {
+	failed := func(err error) *v1.Propagation {
+		r.log.Warnw("unable to verify the propagation", "name", name, "type", t, "error", err)
+		return &v1.Propagation{Error: err.Error()}
+	}
+	rrtype, err := toDNSType(t)
+	if err != nil {
+		return failed(err)
+	}
+	zone, err := r.backend.Zones.Get(ctx, domain)
+	if err != nil {
+		return failed(err)
+	}
+	result, err := r.propagation.Wait(ctx, rrsetContent(zone, *zone.Name, powerdns.RRTypeNS), name, rrtype, expected, r.propagation.Timeout())
+	if err != nil {
+		return failed(err)
+	}
+	if !result.Propagated {
+		r.log.Warnw("record did not propagate", "name", name, "type", t)
+	}
+	return result
+}
+
+// rrsetContent returns the content of the enabled records of a rrset of zone
+func rrsetContent(zone *powerdns.Zone, name string, rrtype powerdns.RRType) []string {
+	var content []string
+	for _, rset := range zone.RRsets {
+		if powerdns.StringValue(rset.Name) != name || rset.Type == nil || *rset.Type != rrtype {
+			continue
+		}
+		for _, r := range rset.Records {
+			if r.Disabled != nil && *r.Disabled {
+				continue
+			}
+			content = append(content, powerdns.StringValue(r.Content))
+		}
+	}
+	return content
+}
+
+func toDNSType(t v1.RecordType) (uint16, error) {
+	rrtype, ok := dns.StringToType[t.String()]
+	if !ok || rrtype == dns.TypeANY {
+		return 0, fmt.Errorf("propagation of %s records cannot be verified", t)
+	}
+	return rrtype, nil
+}
+
+func propagationError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return connect.NewError(connect.CodeDeadlineExceeded, err)
+	}
+	return connect.NewError(connect.CodeFailedPrecondition, err)
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	connect "github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/history"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/miekg/dns"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errWatchDisabled = errors.New("watch is disabled")
+
+// WithEvents publishes the changes of domains to bus and enables Watch
+func (d *DomainService) WithEvents(bus *events.Bus) *DomainService {
+	d.events = bus
+	return d
+}
+
+// WithEvents publishes the changes of records to bus and enables Watch
+func (r *RecordService) WithEvents(bus *events.Bus) *RecordService {
+	r.events = bus
+	return r
+}
+
+// WithEvents publishes the changes of challenge records to bus
+func (c *ChallengeService) WithEvents(bus *events.Bus) *ChallengeService {
+	c.events = bus
+	return c
+}
+
+func (d *DomainService) Watch(ctx context.Context, rq *connect.Request[v1.DomainServiceWatchRequest], stream *connect.ServerStream[v1.DomainServiceWatchResponse]) error {
+	d.log.Debugw("watch", "req", rq)
+	req := rq.Msg
+	if d.events == nil {
+		return connect.NewError(connect.CodeUnimplemented, errWatchDisabled)
+	}
+	claims := token.ClaimsFromContext(ctx)
+	filtered, err := filterDomains(req.Domains, claims.Domains)
+	if err != nil {
+		return connect.NewError(connect.CodeUnauthenticated, err)
+	}
+	return watch(ctx, d.events, req.ResumeToken,
+		func(e events.Event) bool {
+			return e.Domain != nil && filtered[e.Zone]
+		},
+		func(e events.Event, resumeToken string) error {
+			return stream.Send(&v1.DomainServiceWatchResponse{
+				Type:        e.Type,
+				Domain:      e.Domain,
+				ResumeToken: resumeToken,
+				Timestamp:   timestamp(e),
+			})
+		},
+	)
+}
+
+func (r *RecordService) Watch(ctx context.Context, rq *connect.Request[v1.RecordServiceWatchRequest], stream *connect.ServerStream[v1.RecordServiceWatchResponse]) error {
+	r.log.Debugw("watch", "req", rq)
+	req := rq.Msg
+	if r.events == nil {
+		return connect.NewError(connect.CodeUnimplemented, errWatchDisabled)
+	}
+	claims := token.ClaimsFromContext(ctx)
+	return watch(ctx, r.events, req.ResumeToken,
+		func(e events.Event) bool {
+			if e.Record == nil {
+				return false
+			}
+			if req.Domain != "" && dns.CanonicalName(e.Zone) != dns.CanonicalName(req.Domain) {
+				return false
+			}
+			return visible(claims.Domains, e.Zone)
+		},
+		func(e events.Event, resumeToken string) error {
+			return stream.Send(&v1.RecordServiceWatchResponse{
+				Type:        e.Type,
+				Domain:      e.Zone,
+				Record:      e.Record,
+				ResumeToken: resumeToken,
+				Timestamp:   timestamp(e),
+			})
+		},
+	)
+}
+
+// watch sends a bookmark and then every event matched by filter until the client goes away
+func watch(ctx context.Context, bus *events.Bus, resumeToken string, filter func(events.Event) bool, send func(e events.Event, resumeToken string) error) error {
+	sub, err := bus.Subscribe(resumeToken)
+	if err != nil {
+		if errors.Is(err, events.ErrTokenExpired) {
+			return connect.NewError(connect.CodeFailedPrecondition, err)
+		}
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	defer sub.Close()
+
+	err = send(events.Event{Type: v1.EventType_EVENT_TYPE_BOOKMARK}, sub.Token())
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-sub.Events():
+			if !ok {
+				return connect.NewError(connect.CodeUnavailable, sub.Err())
+			}
+			if !filter(e) {
+				continue
+			}
+			err := send(e, bus.Token(e.Seq))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// visible returns true if zone is one of the domains or a subdomain of them, compared by labels like the policies decide for records
+func visible(domains []string, zone string) bool {
+	for _, d := range domains {
+		if dns.IsSubDomain(dns.CanonicalName(d), dns.CanonicalName(zone)) {
+			return true
+		}
+	}
+	return false
+}
+
+func timestamp(e events.Event) *timestamppb.Timestamp {
+	if e.Time.IsZero() {
+		return nil
+	}
+	return timestamppb.New(e.Time)
+}
+
+func recordEvent(t v1.EventType, zone string, record *v1.Record) events.Event {
+	return events.Event{Type: t, Zone: zone, Record: record}
+}
+
+// changeEvents returns an event for every record of the changed rrsets
+func changeEvents(zone string, changes []history.Change) []events.Event {
+	var result []events.Event
+	for _, c := range changes {
+		switch {
+		case c.After == nil:
+			for _, r := range toV1Records(c.Before) {
+				result = append(result, recordEvent(v1.EventType_EVENT_TYPE_DELETED, zone, r))
+			}
+		case c.Before == nil:
+			for _, r := range toV1Records(c.After) {
+				result = append(result, recordEvent(v1.EventType_EVENT_TYPE_CREATED, zone, r))
+			}
+		default:
+			for _, r := range toV1Records(c.After) {
+				result = append(result, recordEvent(v1.EventType_EVENT_TYPE_UPDATED, zone, r))
+			}
+		}
+	}
+	return result
+}
+
+func domainEvent(t v1.EventType, domain *v1.Domain) events.Event {
+	return events.Event{Type: t, Zone: domain.Name, Domain: domain}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/backend"
+	"github.com/majst01/metal-dns/pkg/events"
+	"github.com/majst01/metal-dns/pkg/history"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// withClaims simulates the authorizer for streams
+type withClaims struct {
+	connect.Interceptor
+	claims *token.DNSClaims
+}
+
+func (w withClaims) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return next(token.ContextWithClaims(ctx, w.claims), conn)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	log := zaptest.NewLogger(t).Sugar()
+	bus := events.NewBus(log, 10)
+
+	claims := withClaims{
+		Interceptor: connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc { return next }),
+		claims:      &token.DNSClaims{Domains: []string{"a.example.com.", "b.example.com."}},
+	}
+	mux := http.NewServeMux()
+	b := backend.NewPowerDNS("http://localhost", "localhost", "", nil)
+	mux.Handle(apiv1connect.NewDomainServiceHandler(NewDomainService(log, b).WithEvents(bus), connect.WithInterceptors(claims)))
+	mux.Handle(apiv1connect.NewRecordServiceHandler(NewRecordService(log, b).WithEvents(bus), connect.WithInterceptors(claims)))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	domains := apiv1connect.NewDomainServiceClient(server.Client(), server.URL)
+	records := apiv1connect.NewRecordServiceClient(server.Client(), server.URL)
+
+	// closing a stream drains it, the context must be canceled first
+	watchRecords := func(t *testing.T, req *v1.RecordServiceWatchRequest) *connect.ServerStreamForClient[v1.RecordServiceWatchResponse] {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := records.Watch(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			cancel()
+			_ = stream.Close()
+		})
+		return stream
+	}
+	watchDomains := func(t *testing.T, req *v1.DomainServiceWatchRequest) *connect.ServerStreamForClient[v1.DomainServiceWatchResponse] {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := domains.Watch(ctx, connect.NewRequest(req))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			cancel()
+			_ = stream.Close()
+		})
+		return stream
+	}
+
+	recordStream := watchRecords(t, &v1.RecordServiceWatchRequest{})
+	require.True(t, recordStream.Receive(), recordStream.Err())
+	bookmark := recordStream.Msg()
+	require.Equal(t, v1.EventType_EVENT_TYPE_BOOKMARK, bookmark.Type)
+	require.NotEmpty(t, bookmark.ResumeToken)
+
+	domainStream := watchDomains(t, &v1.DomainServiceWatchRequest{Domains: []string{"a.example.com."}})
+	require.True(t, domainStream.Receive(), domainStream.Err())
+	require.Equal(t, v1.EventType_EVENT_TYPE_BOOKMARK, domainStream.Msg().Type)
+
+	bus.Publish(
+		domainEvent(v1.EventType_EVENT_TYPE_CREATED, &v1.Domain{Name: "b.example.com."}),
+		domainEvent(v1.EventType_EVENT_TYPE_CREATED, &v1.Domain{Name: "a.example.com."}),
+		recordEvent(v1.EventType_EVENT_TYPE_CREATED, "c.example.com.", &v1.Record{Name: "www.c.example.com.", Type: v1.RecordType_A, Data: "1.2.3.3"}),
+		recordEvent(v1.EventType_EVENT_TYPE_CREATED, "xa.example.com.", &v1.Record{Name: "www.xa.example.com.", Type: v1.RecordType_A, Data: "1.2.3.3"}),
+		recordEvent(v1.EventType_EVENT_TYPE_CREATED, "a.example.com.", &v1.Record{Name: "www.a.example.com.", Type: v1.RecordType_A, Data: "1.2.3.4"}),
+		recordEvent(v1.EventType_EVENT_TYPE_DELETED, "sub.b.example.com.", &v1.Record{Name: "www.sub.b.example.com.", Type: v1.RecordType_A, Data: "1.2.3.5"}),
+	)
+
+	// the domain watch only gets the events of a.example.com.
+	require.True(t, domainStream.Receive(), domainStream.Err())
+	require.Equal(t, v1.EventType_EVENT_TYPE_CREATED, domainStream.Msg().Type)
+	require.Equal(t, "a.example.com.", domainStream.Msg().Domain.Name)
+	require.NotNil(t, domainStream.Msg().Timestamp)
+
+	// the record watch gets the events of all domains of the token
+	require.True(t, recordStream.Receive(), recordStream.Err())
+	first := recordStream.Msg()
+	require.Equal(t, v1.EventType_EVENT_TYPE_CREATED, first.Type)
+	require.Equal(t, "a.example.com.", first.Domain)
+	require.Equal(t, "www.a.example.com.", first.Record.Name)
+	require.True(t, recordStream.Receive(), recordStream.Err())
+	require.Equal(t, v1.EventType_EVENT_TYPE_DELETED, recordStream.Msg().Type)
+	require.Equal(t, "www.sub.b.example.com.", recordStream.Msg().Record.Name)
+
+	t.Run("resume", func(t *testing.T) {
+		stream := watchRecords(t, &v1.RecordServiceWatchRequest{Domain: "sub.b.example.com.", ResumeToken: bookmark.ResumeToken})
+		require.True(t, stream.Receive(), stream.Err())
+		require.Equal(t, v1.EventType_EVENT_TYPE_BOOKMARK, stream.Msg().Type)
+		require.Equal(t, bookmark.ResumeToken, stream.Msg().ResumeToken)
+		require.True(t, stream.Receive(), stream.Err())
+		require.Equal(t, "www.sub.b.example.com.", stream.Msg().Record.Name)
+
+		stream = watchRecords(t, &v1.RecordServiceWatchRequest{ResumeToken: first.ResumeToken})
+		require.True(t, stream.Receive(), stream.Err())
+		require.True(t, stream.Receive(), stream.Err())
+		require.Equal(t, "www.sub.b.example.com.", stream.Msg().Record.Name)
+	})
+
+	t.Run("neighbour domain", func(t *testing.T) {
+		// xa.example.com. ends with a.example.com. but is not below it
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		stream, err := records.Watch(ctx, connect.NewRequest(&v1.RecordServiceWatchRequest{Domain: "xa.example.com.", ResumeToken: bookmark.ResumeToken}))
+		require.NoError(t, err)
+		defer stream.Close()
+		require.True(t, stream.Receive(), stream.Err())
+		require.Equal(t, v1.EventType_EVENT_TYPE_BOOKMARK, stream.Msg().Type)
+		require.False(t, stream.Receive(), "events of xa.example.com. must not be sent")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		stream := watchRecords(t, &v1.RecordServiceWatchRequest{ResumeToken: "0.1"})
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(stream.Err()))
+	})
+
+	t.Run("domain not allowed", func(t *testing.T) {
+		stream := watchDomains(t, &v1.DomainServiceWatchRequest{Domains: []string{"c.example.com."}})
+		require.False(t, stream.Receive())
+		require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(stream.Err()))
+	})
+}
+
+func TestChangeEvents(t *testing.T) {
+	rrset := func(content string) *history.RRset {
+		return &history.RRset{Name: "www.a.example.com.", Type: "A", TTL: 300, Records: []history.Record{{Content: content}}}
+	}
+	got := changeEvents("a.example.com.", []history.Change{
+		{Name: "www.a.example.com.", Type: "A", After: rrset("1.2.3.4")},
+		{Name: "www.a.example.com.", Type: "A", Before: rrset("1.2.3.4"), After: rrset("1.2.3.5")},
+		{Name: "www.a.example.com.", Type: "A", Before: rrset("1.2.3.5")},
+	})
+	require.Len(t, got, 3)
+	require.Equal(t, v1.EventType_EVENT_TYPE_CREATED, got[0].Type)
+	require.Equal(t, v1.EventType_EVENT_TYPE_UPDATED, got[1].Type)
+	require.Equal(t, "1.2.3.5", got[1].Record.Data)
+	require.Equal(t, v1.EventType_EVENT_TYPE_DELETED, got[2].Type)
+	require.Equal(t, "a.example.com.", got[2].Zone)
+}
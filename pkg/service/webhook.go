@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	connect "github.com/bufbuild/connect-go"
+	"github.com/google/uuid"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/notify"
+	"github.com/majst01/metal-dns/pkg/token"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type WebhookService struct {
+	store   notify.Store
+	targets *notify.Targets
+	log     *zap.SugaredLogger
+}
+
+func NewWebhookService(l *zap.SugaredLogger, store notify.Store) *WebhookService {
+	return &WebhookService{
+		store:   store,
+		targets: &notify.Targets{},
+		log:     l.Named("webhook"),
+	}
+}
+
+// WithTargets allows the internal networks of targets as webhook urls, all are refused by default
+func (s *WebhookService) WithTargets(targets *notify.Targets) *WebhookService {
+	s.targets = targets
+	return s
+}
+
+// Create stores a new webhook, the request is not logged because it contains the secret
+func (s *WebhookService) Create(ctx context.Context, rq *connect.Request[v1.WebhookServiceCreateRequest]) (*connect.Response[v1.WebhookServiceCreateResponse], error) {
+	req := rq.Msg
+	s.log.Debugw("create", "url", req.Url, "domains", req.Domains)
+	claims := token.ClaimsFromContext(ctx)
+	err := validateWebhook(req.Url, req.Domains, req.EventTypes, claims.Domains)
+	if err != nil {
+		return nil, err
+	}
+	err = s.targets.CheckURL(ctx, req.Url)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	secret := req.Secret
+	if secret == "" {
+		secret, err = notify.GenerateSecret()
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	now := time.Now()
+	w := &notify.Webhook{
+		ID:          uuid.NewString(),
+		URL:         req.Url,
+		Domains:     req.Domains,
+		EventTypes:  req.EventTypes,
+		Description: req.Description,
+		Secret:      secret,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	err = s.store.Put(ctx, w)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1.WebhookServiceCreateResponse{Webhook: toV1Webhook(w, true)}), nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, rq *connect.Request[v1.WebhookServiceGetRequest]) (*connect.Response[v1.WebhookServiceGetResponse], error) {
+	s.log.Debugw("get", "req", rq)
+	w, err := s.get(ctx, rq.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&v1.WebhookServiceGetResponse{Webhook: toV1Webhook(w, false)}), nil
+}
+
+// List returns the webhooks whose domains are all allowed for the caller
+func (s *WebhookService) List(ctx context.Context, rq *connect.Request[v1.WebhookServiceListRequest]) (*connect.Response[v1.WebhookServiceListResponse], error) {
+	s.log.Debugw("list", "req", rq)
+	claims := token.ClaimsFromContext(ctx)
+	webhooks, err := s.store.List(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	result := []*v1.Webhook{}
+	for _, w := range webhooks {
+		if webhookDomainsAllowed(w.Domains, claims.Domains) {
+			result = append(result, toV1Webhook(w, false))
+		}
+	}
+	return connect.NewResponse(&v1.WebhookServiceListResponse{Webhooks: result}), nil
+}
+
+func (s *WebhookService) Update(ctx context.Context, rq *connect.Request[v1.WebhookServiceUpdateRequest]) (*connect.Response[v1.WebhookServiceUpdateResponse], error) {
+	req := rq.Msg
+	// the request is not logged because it may contain the secret
+	s.log.Debugw("update", "id", req.Id, "url", req.Url, "domains", req.Domains)
+	claims := token.ClaimsFromContext(ctx)
+	w, err := s.get(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = validateWebhook(req.Url, req.Domains, req.EventTypes, claims.Domains)
+	if err != nil {
+		return nil, err
+	}
+	err = s.targets.CheckURL(ctx, req.Url)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	w.URL = req.Url
+	w.Domains = req.Domains
+	w.EventTypes = req.EventTypes
+	w.Description = req.Description
+	w.UpdatedAt = time.Now()
+	if req.Secret != nil {
+		w.Secret = *req.Secret
+		if w.Secret == "" {
+			w.Secret, err = notify.GenerateSecret()
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+	}
+	err = s.store.Put(ctx, w)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1.WebhookServiceUpdateResponse{Webhook: toV1Webhook(w, req.Secret != nil)}), nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, rq *connect.Request[v1.WebhookServiceDeleteRequest]) (*connect.Response[v1.WebhookServiceDeleteResponse], error) {
+	s.log.Debugw("delete", "req", rq)
+	w, err := s.get(ctx, rq.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+	err = s.store.Delete(ctx, w.ID)
+	if err != nil {
+		if errors.Is(err, notify.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&v1.WebhookServiceDeleteResponse{Webhook: toV1Webhook(w, false)}), nil
+}
+
+// get returns the webhook with id, webhooks of other domains than the ones of the caller are not found
+func (s *WebhookService) get(ctx context.Context, id string) (*notify.Webhook, error) {
+	claims := token.ClaimsFromContext(ctx)
+	w, err := s.store.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, notify.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if !webhookDomainsAllowed(w.Domains, claims.Domains) {
+		return nil, connect.NewError(connect.CodeNotFound, notify.ErrNotFound)
+	}
+	return w, nil
+}
+
+func validateWebhook(rawURL string, domains []string, eventTypes []v1.EventType, allowed []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("url %q must be an absolute http or https url", rawURL))
+	}
+	if len(domains) == 0 {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("at least one domain is required"))
+	}
+	for _, d := range domains {
+		if err := notify.ValidateDomain(d); err != nil {
+			return connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	for _, t := range eventTypes {
+		switch t {
+		case v1.EventType_EVENT_TYPE_CREATED, v1.EventType_EVENT_TYPE_UPDATED, v1.EventType_EVENT_TYPE_DELETED:
+		default:
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("event type %s cannot be posted", t))
+		}
+	}
+	if !webhookDomainsAllowed(domains, allowed) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("domains %v are not allowed", domains))
+	}
+	return nil
+}
+
+// webhookDomainsAllowed returns true if every pattern only matches the allowed domains or their subdomains.
+// The part after the last wildcard must end with a complete allowed domain, *example.com. would match evilexample.com.
+func webhookDomainsAllowed(patterns, allowed []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	for _, p := range patterns {
+		rest := "." + p
+		if i := strings.LastIndexAny(p, `*?[]\`); i >= 0 {
+			rest = p[i+1:]
+		}
+		found := false
+		for _, a := range allowed {
+			if strings.HasSuffix(rest, "."+a) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func toV1Webhook(w *notify.Webhook, withSecret bool) *v1.Webhook {
+	result := &v1.Webhook{
+		Id:          w.ID,
+		Url:         w.URL,
+		Domains:     w.Domains,
+		EventTypes:  w.EventTypes,
+		Description: w.Description,
+		CreatedAt:   timestamppb.New(w.CreatedAt),
+		UpdatedAt:   timestamppb.New(w.UpdatedAt),
+	}
+	if withSecret {
+		result.Secret = w.Secret
+	}
+	return result
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/notify"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWebhookService(t *testing.T) {
+	store, err := notify.NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	s := NewWebhookService(zaptest.NewLogger(t).Sugar(), store)
+	ctx := token.ContextWithClaims(context.Background(), &token.DNSClaims{Domains: []string{"a.example.com."}})
+	other := token.ContextWithClaims(context.Background(), &token.DNSClaims{Domains: []string{"b.example.com."}})
+
+	created, err := s.Create(ctx, connect.NewRequest(&v1.WebhookServiceCreateRequest{
+		Url:        "https://hooks.example.com/dns",
+		Domains:    []string{"a.example.com.", "*.a.example.com."},
+		EventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_DELETED},
+	}))
+	require.NoError(t, err)
+	w := created.Msg.Webhook
+	require.NotEmpty(t, w.Id)
+	require.Len(t, w.Secret, 64)
+
+	got, err := s.Get(ctx, connect.NewRequest(&v1.WebhookServiceGetRequest{Id: w.Id}))
+	require.NoError(t, err)
+	require.Equal(t, "https://hooks.example.com/dns", got.Msg.Webhook.Url)
+	require.Empty(t, got.Msg.Webhook.Secret)
+
+	// webhooks of other domains are not visible
+	_, err = s.Get(other, connect.NewRequest(&v1.WebhookServiceGetRequest{Id: w.Id}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	list, err := s.List(other, connect.NewRequest(&v1.WebhookServiceListRequest{}))
+	require.NoError(t, err)
+	require.Empty(t, list.Msg.Webhooks)
+	list, err = s.List(ctx, connect.NewRequest(&v1.WebhookServiceListRequest{}))
+	require.NoError(t, err)
+	require.Len(t, list.Msg.Webhooks, 1)
+
+	secret := "my-secret"
+	updated, err := s.Update(ctx, connect.NewRequest(&v1.WebhookServiceUpdateRequest{
+		Id:      w.Id,
+		Url:     "https://hooks.example.com/other",
+		Domains: []string{"a.example.com."},
+		Secret:  &secret,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, "my-secret", updated.Msg.Webhook.Secret)
+	require.Empty(t, updated.Msg.Webhook.EventTypes)
+	stored, err := store.Get(ctx, w.Id)
+	require.NoError(t, err)
+	require.Equal(t, "my-secret", stored.Secret)
+
+	// without secret the secret is kept
+	updated, err = s.Update(ctx, connect.NewRequest(&v1.WebhookServiceUpdateRequest{Id: w.Id, Url: "https://hooks.example.com/other", Domains: []string{"a.example.com."}}))
+	require.NoError(t, err)
+	require.Empty(t, updated.Msg.Webhook.Secret)
+	stored, err = store.Get(ctx, w.Id)
+	require.NoError(t, err)
+	require.Equal(t, "my-secret", stored.Secret)
+
+	// internal addresses are refused unless their network is allowed
+	_, err = s.Update(ctx, connect.NewRequest(&v1.WebhookServiceUpdateRequest{Id: w.Id, Url: "http://169.254.169.254/latest", Domains: []string{"a.example.com."}}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = s.Create(ctx, connect.NewRequest(&v1.WebhookServiceCreateRequest{Url: "http://10.0.0.1/dns", Domains: []string{"a.example.com."}}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.ErrorIs(t, err, notify.ErrForbiddenTarget)
+	targets, err := notify.ParseTargets([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+	internal, err := NewWebhookService(zaptest.NewLogger(t).Sugar(), store).WithTargets(targets).Create(ctx, connect.NewRequest(&v1.WebhookServiceCreateRequest{Url: "http://10.0.0.1/dns", Domains: []string{"a.example.com."}}))
+	require.NoError(t, err)
+	_, err = s.Delete(ctx, connect.NewRequest(&v1.WebhookServiceDeleteRequest{Id: internal.Msg.Webhook.Id}))
+	require.NoError(t, err)
+
+	_, err = s.Delete(other, connect.NewRequest(&v1.WebhookServiceDeleteRequest{Id: w.Id}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = s.Delete(ctx, connect.NewRequest(&v1.WebhookServiceDeleteRequest{Id: w.Id}))
+	require.NoError(t, err)
+	_, err = s.Get(ctx, connect.NewRequest(&v1.WebhookServiceGetRequest{Id: w.Id}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestValidateWebhook(t *testing.T) {
+	allowed := []string{"a.example.com."}
+	tests := []struct {
+		name       string
+		url        string
+		domains    []string
+		eventTypes []v1.EventType
+		want       connect.Code
+	}{
+		{name: "valid", url: "http://hooks.example.com", domains: []string{"a.example.com.", "*.a.example.com.", "www.a.example.com."}},
+		{name: "relative url", url: "/dns", domains: allowed, want: connect.CodeInvalidArgument},
+		{name: "other scheme", url: "ftp://hooks.example.com", domains: allowed, want: connect.CodeInvalidArgument},
+		{name: "no domains", url: "https://hooks.example.com", want: connect.CodeInvalidArgument},
+		{name: "not fully qualified", url: "https://hooks.example.com", domains: []string{"a.example.com"}, want: connect.CodeInvalidArgument},
+		{name: "bookmark", url: "https://hooks.example.com", domains: allowed, eventTypes: []v1.EventType{v1.EventType_EVENT_TYPE_BOOKMARK}, want: connect.CodeInvalidArgument},
+		{name: "other domain", url: "https://hooks.example.com", domains: []string{"b.example.com."}, want: connect.CodePermissionDenied},
+		{name: "parent domain", url: "https://hooks.example.com", domains: []string{"*.example.com."}, want: connect.CodePermissionDenied},
+		{name: "wildcard within a label", url: "https://hooks.example.com", domains: []string{"*a.example.com."}, want: connect.CodePermissionDenied},
+		{name: "wildcard only", url: "https://hooks.example.com", domains: []string{"*"}, want: connect.CodeInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebhook(tt.url, tt.domains, tt.eventTypes, allowed)
+			if tt.want == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.Equal(t, tt.want, connect.CodeOf(err), err)
+		})
+	}
+}
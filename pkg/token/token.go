@@ -3,8 +3,10 @@ package token
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 )
 
 type DNSClaims struct {
@@ -34,3 +36,49 @@ func ClaimsFromContext(ctx context.Context) *DNSClaims {
 func ContextWithClaims(ctx context.Context, claims *DNSClaims) context.Context {
 	return context.WithValue(ctx, DNSClaimsKey{}, claims)
 }
+
+type tokenKey struct{}
+
+// TokenFromContext returns the token the call was authorized with, which is either the bearer token
+// or the token created for the client certificate
+func TokenFromContext(ctx context.Context) (string, bool) {
+	t, ok := ctx.Value(tokenKey{}).(string)
+	return t, ok && t != ""
+}
+
+// ContextWithToken stores the token the call was authorized with in ctx
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey{}, token)
+}
+
+// NewJWTToken creates a token signed with secret, which expires after expires
+func NewJWTToken(subject, issuer string, domains, permissions []string, expires time.Duration, secret string) (string, error) {
+	now := time.Now().UTC()
+	claims := &DNSClaims{
+		// see overview of "registered" JWT claims as used by jwt-go here:
+		//   https://pkg.go.dev/github.com/golang-jwt/jwt/v4?utm_source=godoc#RegisteredClaims
+		// see the semantics of the registered claims here:
+		//   https://en.wikipedia.org/wiki/JSON_Web_Token#Standard_fields
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(expires)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+
+			// ID is for your traceability, doesn't have to be UUID:
+			ID: uuid.New().String(),
+
+			// put name/title/ID of whoever will be using this JWT here:
+			Subject: subject,
+			Issuer:  issuer,
+		},
+		Domains:     domains,
+		Permissions: permissions,
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	res, err := token.SignedString([]byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("unable to sign RS256 JWT: %w", err)
+	}
+	return res, nil
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/metal-stack/v"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.uber.org/zap"
+)
+
+// Config configures the export of traces to a OTLP collector
+type Config struct {
+	// Endpoint of the OTLP/HTTP collector, e.g. localhost:4318, tracing is disabled if empty
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector
+	Insecure bool
+	// SampleRatio is the fraction of traces which are sampled if the caller did not decide already
+	SampleRatio float64
+}
+
+// New sets the global tracer provider and propagator to export traces to the collector configured in c.
+// The returned function flushes all pending spans and must be called before the process exits.
+func New(ctx context.Context, log *zap.SugaredLogger, serviceName string, c Config) (func(context.Context) error, error) {
+	// always propagate trace context, even if traces are not exported, to not break traces of our callers
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if c.Endpoint == "" {
+		log.Infow("tracing disabled, no otlp endpoint configured")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(c.Endpoint)}
+	if c.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create otlp exporter %w", err)
+	}
+
+	// the semconv version must match the one of the sdk, merging resources of different schemas fails
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(v.Version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create tracing resource %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(c.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Errorw("tracing", "error", err)
+	}))
+
+	log.Infow("tracing enabled", "endpoint", c.Endpoint, "sample-ratio", c.SampleRatio)
+	return tp.Shutdown, nil
+}
@@ -0,0 +1,26 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background()
+	log := zaptest.NewLogger(t).Sugar()
+
+	shutdown, err := New(ctx, log, "metal-dns", Config{})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(ctx))
+
+	// the exporter connects lazily, nothing has to listen on the endpoint
+	shutdown, err = New(ctx, log, "metal-dns", Config{Endpoint: "localhost:4318", Insecure: true, SampleRatio: 1})
+	require.NoError(t, err)
+	require.IsType(t, &sdktrace.TracerProvider{}, otel.GetTracerProvider())
+	require.NoError(t, shutdown(ctx))
+}
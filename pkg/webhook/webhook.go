@@ -0,0 +1,359 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/miekg/dns"
+	"go.uber.org/zap"
+)
+
+const (
+	// MediaType is the content type of the external-dns webhook protocol
+	MediaType = "application/external.dns.webhook+json;version=1"
+
+	defaultTTL = 300
+)
+
+// supportedTypes are the record types which are exchanged with external-dns,
+// TXT records carry the ownership labels of the external-dns TXT registry
+var supportedTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"TXT":   true,
+	"MX":    true,
+	"NS":    true,
+	"PTR":   true,
+	"SRV":   true,
+	"CAA":   true,
+}
+
+// hostTypes are the record types whose data ends with a host name, which must be fully qualified in powerdns
+var hostTypes = map[string]bool{
+	"CNAME": true,
+	"MX":    true,
+	"NS":    true,
+	"PTR":   true,
+	"SRV":   true,
+}
+
+// Endpoint is a record of external-dns, names and host names are not fully qualified
+type Endpoint struct {
+	DNSName          string             `json:"dnsName,omitempty"`
+	Targets          []string           `json:"targets,omitempty"`
+	RecordType       string             `json:"recordType,omitempty"`
+	SetIdentifier    string             `json:"setIdentifier,omitempty"`
+	RecordTTL        int64              `json:"recordTTL,omitempty"`
+	Labels           map[string]string  `json:"labels,omitempty"`
+	ProviderSpecific []ProviderSpecific `json:"providerSpecific,omitempty"`
+}
+
+// ProviderSpecific is a provider specific property of an endpoint, they are ignored
+type ProviderSpecific struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Changes are the changes external-dns wants to apply
+type Changes struct {
+	Create    []*Endpoint `json:"Create"`
+	UpdateOld []*Endpoint `json:"UpdateOld"`
+	UpdateNew []*Endpoint `json:"UpdateNew"`
+	Delete    []*Endpoint `json:"Delete"`
+}
+
+// DomainFilter tells external-dns which domains are managed by the webhook
+type DomainFilter struct {
+	Include []string `json:"include,omitempty"`
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// Webhook is an external-dns webhook provider, which translates endpoints into calls to the RecordService.
+// The TXT records of the external-dns registry are stored like any other record, they require a --txt-prefix
+// of external-dns because a CNAME can not share its name with the TXT record.
+type Webhook struct {
+	log          *zap.SugaredLogger
+	client       client.Client
+	domainFilter []string
+}
+
+// New creates a webhook, the domains are limited to domainFilter, if given, and to the domains the token is allowed for
+func New(log *zap.SugaredLogger, c client.Client, domainFilter []string) *Webhook {
+	var filter []string
+	for _, d := range domainFilter {
+		filter = append(filter, dns.Fqdn(d))
+	}
+	return &Webhook{
+		log:          log.Named("webhook"),
+		client:       c,
+		domainFilter: filter,
+	}
+}
+
+// Handler serves the webhook protocol of external-dns
+func (w *Webhook) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", w.negotiate)
+	mux.HandleFunc("/records", w.records)
+	mux.HandleFunc("/adjustendpoints", w.adjustEndpoints)
+	mux.HandleFunc("/healthz", func(rw http.ResponseWriter, r *http.Request) {
+		_, _ = rw.Write([]byte("ok"))
+	})
+	return mux
+}
+
+func (w *Webhook) negotiate(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(rw, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		methodNotAllowed(rw, http.MethodGet)
+		return
+	}
+	domains, err := w.domains(r.Context())
+	if err != nil {
+		w.error(rw, "unable to list domains", err)
+		return
+	}
+	filter := DomainFilter{}
+	for _, d := range domains {
+		filter.Include = append(filter.Include, strings.TrimSuffix(d, "."))
+	}
+	w.write(rw, http.StatusOK, filter)
+}
+
+func (w *Webhook) records(rw http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		endpoints, err := w.Records(r.Context())
+		if err != nil {
+			w.error(rw, "unable to list records", err)
+			return
+		}
+		w.write(rw, http.StatusOK, endpoints)
+	case http.MethodPost:
+		changes := &Changes{}
+		if err := json.NewDecoder(r.Body).Decode(changes); err != nil {
+			http.Error(rw, fmt.Sprintf("unable to decode changes %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := w.ApplyChanges(r.Context(), changes); err != nil {
+			w.error(rw, "unable to apply changes", err)
+			return
+		}
+		rw.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(rw, http.MethodGet, http.MethodPost)
+	}
+}
+
+func (w *Webhook) adjustEndpoints(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(rw, http.MethodPost)
+		return
+	}
+	var endpoints []*Endpoint
+	if err := json.NewDecoder(r.Body).Decode(&endpoints); err != nil {
+		http.Error(rw, fmt.Sprintf("unable to decode endpoints %v", err), http.StatusBadRequest)
+		return
+	}
+	w.write(rw, http.StatusOK, w.AdjustEndpoints(endpoints))
+}
+
+// domains returns the domains of the token, limited to the domain filter
+func (w *Webhook) domains(ctx context.Context) ([]string, error) {
+	resp, err := w.client.Domain().List(ctx, connect.NewRequest(&v1.DomainServiceListRequest{Domains: w.domainFilter}))
+	if err != nil {
+		return nil, err
+	}
+	var domains []string
+	for _, d := range resp.Msg.Domains {
+		domains = append(domains, d.Name)
+	}
+	sort.Strings(domains)
+	return domains, nil
+}
+
+// Records returns the records of all domains as endpoints, the records of a rrset are the targets of one endpoint
+func (w *Webhook) Records(ctx context.Context) ([]*Endpoint, error) {
+	domains, err := w.domains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	endpoints := []*Endpoint{}
+	for _, domain := range domains {
+		resp, err := w.client.Record().List(ctx, connect.NewRequest(&v1.RecordServiceListRequest{Domain: domain, Type: v1.RecordType_ANY}))
+		if err != nil {
+			return nil, fmt.Errorf("unable to list records of %s %w", domain, err)
+		}
+		rrsets := map[string]*Endpoint{}
+		for _, r := range resp.Msg.Records {
+			t := r.Type.String()
+			if !supportedTypes[t] {
+				continue
+			}
+			key := r.Name + " " + t
+			ep, ok := rrsets[key]
+			if !ok {
+				ep = &Endpoint{
+					DNSName:    strings.TrimSuffix(r.Name, "."),
+					RecordType: t,
+					RecordTTL:  int64(r.Ttl),
+				}
+				rrsets[key] = ep
+				endpoints = append(endpoints, ep)
+			}
+			ep.Targets = append(ep.Targets, fromData(t, r.Data))
+		}
+	}
+	return endpoints, nil
+}
+
+// AdjustEndpoints reduces the targets of an endpoint to one, because the RecordService stores a single record per rrset.
+// Without this external-dns would try to update such endpoints forever.
+func (w *Webhook) AdjustEndpoints(endpoints []*Endpoint) []*Endpoint {
+	adjusted := []*Endpoint{}
+	for _, ep := range endpoints {
+		if len(ep.Targets) > 1 {
+			targets := append([]string{}, ep.Targets...)
+			sort.Strings(targets)
+			w.log.Warnw("only a single target per endpoint is supported, dropping the others", "name", ep.DNSName, "type", ep.RecordType, "targets", targets)
+			ep.Targets = targets[:1]
+		}
+		if ep.RecordType == "TXT" && len(ep.Targets) == 1 {
+			ep.Targets[0] = quoteTXT(ep.Targets[0])
+		}
+		adjusted = append(adjusted, ep)
+	}
+	return adjusted
+}
+
+// ApplyChanges deletes, creates and updates the records of the changes.
+// Endpoints outside of the managed domains are skipped, like the other providers of external-dns do.
+func (w *Webhook) ApplyChanges(ctx context.Context, changes *Changes) error {
+	domains, err := w.domains(ctx)
+	if err != nil {
+		return err
+	}
+	managed := func(ep *Endpoint) bool {
+		name := dns.Fqdn(ep.DNSName)
+		for _, d := range domains {
+			if dns.IsSubDomain(d, name) {
+				return true
+			}
+		}
+		w.log.Warnw("skipping endpoint outside of the managed domains", "name", ep.DNSName, "type", ep.RecordType)
+		return false
+	}
+
+	for _, ep := range changes.Delete {
+		if !managed(ep) {
+			continue
+		}
+		r := toRecord(ep)
+		w.log.Infow("delete record", "name", r.Name, "type", r.Type)
+		_, err := w.client.Record().Delete(ctx, connect.NewRequest(&v1.RecordServiceDeleteRequest{Type: r.Type, Name: r.Name, Data: r.Data}))
+		if err != nil {
+			return fmt.Errorf("unable to delete %s %s %w", r.Name, r.Type, err)
+		}
+	}
+	for _, ep := range changes.Create {
+		if !managed(ep) {
+			continue
+		}
+		r := toRecord(ep)
+		w.log.Infow("create record", "name", r.Name, "type", r.Type, "data", r.Data)
+		_, err := w.client.Record().Create(ctx, connect.NewRequest(&v1.RecordServiceCreateRequest{Type: r.Type, Name: r.Name, Data: r.Data, Ttl: r.Ttl}))
+		if err != nil {
+			return fmt.Errorf("unable to create %s %s %w", r.Name, r.Type, err)
+		}
+	}
+	// an update replaces the whole rrset, the old endpoints are not needed
+	for _, ep := range changes.UpdateNew {
+		if !managed(ep) {
+			continue
+		}
+		r := toRecord(ep)
+		w.log.Infow("update record", "name", r.Name, "type", r.Type, "data", r.Data)
+		_, err := w.client.Record().Update(ctx, connect.NewRequest(&v1.RecordServiceUpdateRequest{Type: r.Type, Name: r.Name, Data: r.Data, Ttl: r.Ttl}))
+		if err != nil {
+			return fmt.Errorf("unable to update %s %s %w", r.Name, r.Type, err)
+		}
+	}
+	return nil
+}
+
+// toRecord converts an endpoint to a record of the first target, see AdjustEndpoints
+func toRecord(ep *Endpoint) *v1.Record {
+	r := &v1.Record{
+		Name: dns.Fqdn(ep.DNSName),
+		Type: client.ToV1RecordType(ep.RecordType),
+		Ttl:  defaultTTL,
+	}
+	if ep.RecordTTL > 0 {
+		r.Ttl = uint32(ep.RecordTTL)
+	}
+	if len(ep.Targets) > 0 {
+		r.Data = toData(ep.RecordType, ep.Targets[0])
+	}
+	return r
+}
+
+// toData converts a target to the content of a powerdns record
+func toData(t, target string) string {
+	switch {
+	case t == "TXT":
+		return quoteTXT(target)
+	case hostTypes[t]:
+		fields := strings.Fields(target)
+		if len(fields) == 0 {
+			return target
+		}
+		fields[len(fields)-1] = dns.Fqdn(fields[len(fields)-1])
+		return strings.Join(fields, " ")
+	}
+	return target
+}
+
+// fromData converts the content of a powerdns record to a target, TXT records stay quoted
+func fromData(t, data string) string {
+	if hostTypes[t] {
+		return strings.TrimSuffix(data, ".")
+	}
+	return data
+}
+
+func quoteTXT(target string) string {
+	if strings.HasPrefix(target, `"`) && strings.HasSuffix(target, `"`) && len(target) > 1 {
+		return target
+	}
+	return `"` + strings.ReplaceAll(target, `"`, `\"`) + `"`
+}
+
+func (w *Webhook) write(rw http.ResponseWriter, code int, v any) {
+	rw.Header().Set("Content-Type", MediaType)
+	rw.Header().Set("Vary", "Content-Type")
+	rw.WriteHeader(code)
+	if err := json.NewEncoder(rw).Encode(v); err != nil {
+		w.log.Errorw("unable to write response", "error", err)
+	}
+}
+
+func (w *Webhook) error(rw http.ResponseWriter, msg string, err error) {
+	w.log.Errorw(msg, "error", err)
+	http.Error(rw, fmt.Sprintf("%s %v", msg, err), http.StatusInternalServerError)
+}
+
+func methodNotAllowed(rw http.ResponseWriter, methods ...string) {
+	rw.Header().Set("Allow", strings.Join(methods, ", "))
+	http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+}
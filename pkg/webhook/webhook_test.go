@@ -0,0 +1,293 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/api/v1"
+	"github.com/majst01/metal-dns/api/v1/apiv1connect"
+	"github.com/majst01/metal-dns/pkg/auth"
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/majst01/metal-dns/pkg/token"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWebhook(t *testing.T) {
+	zones := &fakeZones{
+		domains: []string{"example.com.", "example.org."},
+		records: map[string]*v1.Record{},
+	}
+	zones.set(&v1.Record{Name: "example.com.", Type: v1.RecordType_SOA, Ttl: 3600, Data: "ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600"})
+	zones.set(&v1.Record{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 300, Data: "1.2.3.4"})
+	zones.set(&v1.Record{Name: "a-www.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: `"heritage=external-dns,external-dns/owner=default"`})
+	zones.set(&v1.Record{Name: "ftp.example.com.", Type: v1.RecordType_CNAME, Ttl: 300, Data: "www.example.com."})
+	zones.set(&v1.Record{Name: "www.example.org.", Type: v1.RecordType_A, Ttl: 300, Data: "1.2.3.5"})
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewDomainServiceHandler(fakeDomainService{fakeZones: zones}))
+	mux.Handle(apiv1connect.NewRecordServiceHandler(fakeRecordService{fakeZones: zones}))
+	api := httptest.NewServer(mux)
+	defer api.Close()
+
+	c := client.New(context.Background(), client.DialConfig{BaseURL: api.URL})
+	server := httptest.NewServer(New(zaptest.NewLogger(t).Sugar(), c, []string{"example.com"}).Handler())
+	defer server.Close()
+
+	do := func(method, path string, body any) *http.Response {
+		var reader *bytes.Reader
+		if body != nil {
+			data, err := json.Marshal(body)
+			require.NoError(t, err)
+			reader = bytes.NewReader(data)
+		} else {
+			reader = bytes.NewReader(nil)
+		}
+		req, err := http.NewRequest(method, server.URL+path, reader)
+		require.NoError(t, err)
+		req.Header.Set("Accept", MediaType)
+		req.Header.Set("Content-Type", MediaType)
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("negotiate", func(t *testing.T) {
+		resp := do(http.MethodGet, "/", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, MediaType, resp.Header.Get("Content-Type"))
+		filter := DomainFilter{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&filter))
+		require.Equal(t, DomainFilter{Include: []string{"example.com"}}, filter)
+	})
+
+	t.Run("records", func(t *testing.T) {
+		resp := do(http.MethodGet, "/records", nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var endpoints []*Endpoint
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&endpoints))
+		sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].DNSName < endpoints[j].DNSName })
+		require.Equal(t, []*Endpoint{
+			{DNSName: "a-www.example.com", RecordType: "TXT", RecordTTL: 300, Targets: []string{`"heritage=external-dns,external-dns/owner=default"`}},
+			{DNSName: "ftp.example.com", RecordType: "CNAME", RecordTTL: 300, Targets: []string{"www.example.com"}},
+			{DNSName: "www.example.com", RecordType: "A", RecordTTL: 300, Targets: []string{"1.2.3.4"}},
+		}, endpoints)
+	})
+
+	t.Run("adjust endpoints", func(t *testing.T) {
+		resp := do(http.MethodPost, "/adjustendpoints", []*Endpoint{
+			{DNSName: "www.example.com", RecordType: "A", Targets: []string{"1.2.3.5", "1.2.3.4"}},
+			{DNSName: "a-www.example.com", RecordType: "TXT", Targets: []string{"heritage=external-dns"}},
+		})
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var endpoints []*Endpoint
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&endpoints))
+		require.Equal(t, []*Endpoint{
+			{DNSName: "www.example.com", RecordType: "A", Targets: []string{"1.2.3.4"}},
+			{DNSName: "a-www.example.com", RecordType: "TXT", Targets: []string{`"heritage=external-dns"`}},
+		}, endpoints)
+	})
+
+	t.Run("apply changes", func(t *testing.T) {
+		resp := do(http.MethodPost, "/records", &Changes{
+			Create: []*Endpoint{
+				{DNSName: "app.example.com", RecordType: "CNAME", Targets: []string{"www.example.com"}},
+				{DNSName: "a-app.example.com", RecordType: "TXT", RecordTTL: 60, Targets: []string{"heritage=external-dns,external-dns/owner=default"}},
+				{DNSName: "app.example.net", RecordType: "A", Targets: []string{"1.2.3.6"}},
+			},
+			UpdateOld: []*Endpoint{
+				{DNSName: "www.example.com", RecordType: "A", RecordTTL: 300, Targets: []string{"1.2.3.4"}},
+			},
+			UpdateNew: []*Endpoint{
+				{DNSName: "www.example.com", RecordType: "A", RecordTTL: 600, Targets: []string{"1.2.3.7"}},
+			},
+			Delete: []*Endpoint{
+				{DNSName: "ftp.example.com", RecordType: "CNAME", Targets: []string{"www.example.com"}},
+			},
+		})
+		require.Equal(t, http.StatusNoContent, resp.StatusCode)
+		require.Equal(t, []string{
+			`a-app.example.com. TXT 60 "heritage=external-dns,external-dns/owner=default"`,
+			`a-www.example.com. TXT 300 "heritage=external-dns,external-dns/owner=default"`,
+			"app.example.com. CNAME 300 www.example.com.",
+			"example.com. SOA 3600 ns1.example.com. hostmaster.example.com. 1 10800 3600 604800 3600",
+			"www.example.com. A 600 1.2.3.7",
+			"www.example.org. A 300 1.2.3.5",
+		}, zones.list())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		resp := do(http.MethodDelete, "/records", nil)
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		require.Equal(t, "GET, POST", resp.Header.Get("Allow"))
+	})
+}
+
+func TestWebhookAuthorized(t *testing.T) {
+	zones := &fakeZones{
+		domains: []string{"example.com."},
+		records: map[string]*v1.Record{},
+	}
+	zones.set(&v1.Record{Name: "www.example.com.", Type: v1.RecordType_A, Ttl: 300, Data: "1.2.3.4"})
+	zones.set(&v1.Record{Name: "ftp.example.com.", Type: v1.RecordType_CNAME, Ttl: 300, Data: "www.example.com."})
+	zones.set(&v1.Record{Name: "extdns-cname-ftp.example.com.", Type: v1.RecordType_TXT, Ttl: 300, Data: `"heritage=external-dns,external-dns/owner=default"`})
+
+	log := zaptest.NewLogger(t).Sugar()
+	authz, err := auth.NewOpaAuther(log, "secret")
+	require.NoError(t, err)
+	interceptors := connect.WithInterceptors(authz)
+
+	mux := http.NewServeMux()
+	mux.Handle(apiv1connect.NewDomainServiceHandler(fakeDomainService{fakeZones: zones}, interceptors))
+	mux.Handle(apiv1connect.NewRecordServiceHandler(fakeRecordService{fakeZones: zones}, interceptors))
+	api := httptest.NewServer(mux)
+	defer api.Close()
+
+	jwtToken, err := token.NewJWTToken("external-dns", "Tester", []string{"example.com."}, []string{
+		apiv1connect.DomainServiceListProcedure,
+		apiv1connect.RecordServiceListProcedure,
+		apiv1connect.RecordServiceCreateProcedure,
+		apiv1connect.RecordServiceUpdateProcedure,
+		apiv1connect.RecordServiceDeleteProcedure,
+	}, time.Hour, "secret")
+	require.NoError(t, err)
+
+	c := client.New(context.Background(), client.DialConfig{BaseURL: api.URL, Token: jwtToken})
+	w := New(log, c, nil)
+
+	err = w.ApplyChanges(context.Background(), &Changes{
+		Create: []*Endpoint{
+			{DNSName: "app.example.com", RecordType: "A", Targets: []string{"1.2.3.6"}},
+			{DNSName: "extdns-a-app.example.com", RecordType: "TXT", Targets: []string{"heritage=external-dns,external-dns/owner=default"}},
+		},
+		UpdateNew: []*Endpoint{
+			{DNSName: "www.example.com", RecordType: "A", Targets: []string{"1.2.3.7"}},
+		},
+		Delete: []*Endpoint{
+			{DNSName: "ftp.example.com", RecordType: "CNAME", Targets: []string{"www.example.com"}},
+			{DNSName: "extdns-cname-ftp.example.com", RecordType: "TXT", Targets: []string{`"heritage=external-dns,external-dns/owner=default"`}},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"app.example.com. A 300 1.2.3.6",
+		`extdns-a-app.example.com. TXT 300 "heritage=external-dns,external-dns/owner=default"`,
+		"www.example.com. A 300 1.2.3.7",
+	}, zones.list())
+}
+
+func TestToData(t *testing.T) {
+	tests := []struct {
+		typ    string
+		target string
+		want   string
+	}{
+		{typ: "A", target: "1.2.3.4", want: "1.2.3.4"},
+		{typ: "CNAME", target: "www.example.com", want: "www.example.com."},
+		{typ: "MX", target: "10 mx.example.com", want: "10 mx.example.com."},
+		{typ: "SRV", target: "0 5 5060 sip.example.com.", want: "0 5 5060 sip.example.com."},
+		{typ: "TXT", target: `v="1"`, want: `"v=\"1\""`},
+		{typ: "TXT", target: `"quoted"`, want: `"quoted"`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.typ+" "+tt.target, func(t *testing.T) {
+			require.Equal(t, tt.want, toData(tt.typ, tt.target))
+		})
+	}
+}
+
+type fakeZones struct {
+	lock    sync.Mutex
+	domains []string
+	records map[string]*v1.Record
+}
+
+func (f *fakeZones) set(r *v1.Record) {
+	f.records[r.Name+" "+r.Type.String()] = r
+}
+
+func (f *fakeZones) list() []string {
+	var records []string
+	for _, r := range f.records {
+		records = append(records, fmt.Sprintf("%s %s %d %s", r.Name, r.Type, r.Ttl, r.Data))
+	}
+	sort.Strings(records)
+	return records
+}
+
+type fakeDomainService struct {
+	apiv1connect.UnimplementedDomainServiceHandler
+	*fakeZones
+}
+
+func (f fakeDomainService) List(ctx context.Context, req *connect.Request[v1.DomainServiceListRequest]) (*connect.Response[v1.DomainServiceListResponse], error) {
+	var domains []*v1.Domain
+	for _, d := range f.domains {
+		if len(req.Msg.Domains) > 0 && !contains(req.Msg.Domains, d) {
+			continue
+		}
+		domains = append(domains, &v1.Domain{Name: d})
+	}
+	return connect.NewResponse(&v1.DomainServiceListResponse{Domains: domains}), nil
+}
+
+func contains(s []string, e string) bool {
+	for _, a := range s {
+		if a == e {
+			return true
+		}
+	}
+	return false
+}
+
+type fakeRecordService struct {
+	apiv1connect.UnimplementedRecordServiceHandler
+	*fakeZones
+}
+
+func (f fakeRecordService) List(ctx context.Context, req *connect.Request[v1.RecordServiceListRequest]) (*connect.Response[v1.RecordServiceListResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	var records []*v1.Record
+	for _, r := range f.records {
+		if strings.HasSuffix(r.Name, req.Msg.Domain) {
+			records = append(records, r)
+		}
+	}
+	return connect.NewResponse(&v1.RecordServiceListResponse{Records: records}), nil
+}
+
+func (f fakeRecordService) Create(ctx context.Context, req *connect.Request[v1.RecordServiceCreateRequest]) (*connect.Response[v1.RecordServiceCreateResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	r := &v1.Record{Name: req.Msg.Name, Type: req.Msg.Type, Ttl: req.Msg.Ttl, Data: req.Msg.Data}
+	f.set(r)
+	return connect.NewResponse(&v1.RecordServiceCreateResponse{Record: r}), nil
+}
+
+func (f fakeRecordService) Update(ctx context.Context, req *connect.Request[v1.RecordServiceUpdateRequest]) (*connect.Response[v1.RecordServiceUpdateResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	r := &v1.Record{Name: req.Msg.Name, Type: req.Msg.Type, Ttl: req.Msg.Ttl, Data: req.Msg.Data}
+	f.set(r)
+	return connect.NewResponse(&v1.RecordServiceUpdateResponse{Record: r}), nil
+}
+
+func (f fakeRecordService) Delete(ctx context.Context, req *connect.Request[v1.RecordServiceDeleteRequest]) (*connect.Response[v1.RecordServiceDeleteResponse], error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.records, req.Msg.Name+" "+req.Msg.Type.String())
+	return connect.NewResponse(&v1.RecordServiceDeleteResponse{}), nil
+}
@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: test/v1/streaming.proto
+
+package testv1connect
+
+import (
+	context "context"
+	errors "errors"
+	connect_go "github.com/bufbuild/connect-go"
+	v1 "github.com/majst01/metal-dns/test/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect_go.IsAtLeastVersion0_1_0
+
+const (
+	// StreamingTestServiceName is the fully-qualified name of the StreamingTestService service.
+	StreamingTestServiceName = "test.v1.StreamingTestService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// StreamingTestServiceServerStreamProcedure is the fully-qualified name of the
+	// StreamingTestService's ServerStream RPC.
+	StreamingTestServiceServerStreamProcedure = "/test.v1.StreamingTestService/ServerStream"
+	// StreamingTestServiceClientStreamProcedure is the fully-qualified name of the
+	// StreamingTestService's ClientStream RPC.
+	StreamingTestServiceClientStreamProcedure = "/test.v1.StreamingTestService/ClientStream"
+)
+
+// StreamingTestServiceClient is a client for the test.v1.StreamingTestService service.
+type StreamingTestServiceClient interface {
+	ServerStream(context.Context, *connect_go.Request[v1.StreamingTestServiceServerStreamRequest]) (*connect_go.ServerStreamForClient[v1.StreamingTestServiceServerStreamResponse], error)
+	ClientStream(context.Context) *connect_go.ClientStreamForClient[v1.StreamingTestServiceClientStreamRequest, v1.StreamingTestServiceClientStreamResponse]
+}
+
+// NewStreamingTestServiceClient constructs a client for the test.v1.StreamingTestService service.
+// By default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped
+// responses, and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewStreamingTestServiceClient(httpClient connect_go.HTTPClient, baseURL string, opts ...connect_go.ClientOption) StreamingTestServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &streamingTestServiceClient{
+		serverStream: connect_go.NewClient[v1.StreamingTestServiceServerStreamRequest, v1.StreamingTestServiceServerStreamResponse](
+			httpClient,
+			baseURL+StreamingTestServiceServerStreamProcedure,
+			opts...,
+		),
+		clientStream: connect_go.NewClient[v1.StreamingTestServiceClientStreamRequest, v1.StreamingTestServiceClientStreamResponse](
+			httpClient,
+			baseURL+StreamingTestServiceClientStreamProcedure,
+			opts...,
+		),
+	}
+}
+
+// streamingTestServiceClient implements StreamingTestServiceClient.
+type streamingTestServiceClient struct {
+	serverStream *connect_go.Client[v1.StreamingTestServiceServerStreamRequest, v1.StreamingTestServiceServerStreamResponse]
+	clientStream *connect_go.Client[v1.StreamingTestServiceClientStreamRequest, v1.StreamingTestServiceClientStreamResponse]
+}
+
+// ServerStream calls test.v1.StreamingTestService.ServerStream.
+func (c *streamingTestServiceClient) ServerStream(ctx context.Context, req *connect_go.Request[v1.StreamingTestServiceServerStreamRequest]) (*connect_go.ServerStreamForClient[v1.StreamingTestServiceServerStreamResponse], error) {
+	return c.serverStream.CallServerStream(ctx, req)
+}
+
+// ClientStream calls test.v1.StreamingTestService.ClientStream.
+func (c *streamingTestServiceClient) ClientStream(ctx context.Context) *connect_go.ClientStreamForClient[v1.StreamingTestServiceClientStreamRequest, v1.StreamingTestServiceClientStreamResponse] {
+	return c.clientStream.CallClientStream(ctx)
+}
+
+// StreamingTestServiceHandler is an implementation of the test.v1.StreamingTestService service.
+type StreamingTestServiceHandler interface {
+	ServerStream(context.Context, *connect_go.Request[v1.StreamingTestServiceServerStreamRequest], *connect_go.ServerStream[v1.StreamingTestServiceServerStreamResponse]) error
+	ClientStream(context.Context, *connect_go.ClientStream[v1.StreamingTestServiceClientStreamRequest]) (*connect_go.Response[v1.StreamingTestServiceClientStreamResponse], error)
+}
+
+// NewStreamingTestServiceHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewStreamingTestServiceHandler(svc StreamingTestServiceHandler, opts ...connect_go.HandlerOption) (string, http.Handler) {
+	streamingTestServiceServerStreamHandler := connect_go.NewServerStreamHandler(
+		StreamingTestServiceServerStreamProcedure,
+		svc.ServerStream,
+		opts...,
+	)
+	streamingTestServiceClientStreamHandler := connect_go.NewClientStreamHandler(
+		StreamingTestServiceClientStreamProcedure,
+		svc.ClientStream,
+		opts...,
+	)
+	return "/test.v1.StreamingTestService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case StreamingTestServiceServerStreamProcedure:
+			streamingTestServiceServerStreamHandler.ServeHTTP(w, r)
+		case StreamingTestServiceClientStreamProcedure:
+			streamingTestServiceClientStreamHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedStreamingTestServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedStreamingTestServiceHandler struct{}
+
+func (UnimplementedStreamingTestServiceHandler) ServerStream(context.Context, *connect_go.Request[v1.StreamingTestServiceServerStreamRequest], *connect_go.ServerStream[v1.StreamingTestServiceServerStreamResponse]) error {
+	return connect_go.NewError(connect_go.CodeUnimplemented, errors.New("test.v1.StreamingTestService.ServerStream is not implemented"))
+}
+
+func (UnimplementedStreamingTestServiceHandler) ClientStream(context.Context, *connect_go.ClientStream[v1.StreamingTestServiceClientStreamRequest]) (*connect_go.Response[v1.StreamingTestServiceClientStreamResponse], error) {
+	return nil, connect_go.NewError(connect_go.CodeUnimplemented, errors.New("test.v1.StreamingTestService.ClientStream is not implemented"))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/majst01/metal-dns/pkg/client"
+	"github.com/majst01/metal-dns/pkg/webhook"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func newWebhookCmd() *cobra.Command {
+	webhookCmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "serve the external-dns webhook provider protocol, records are managed through the metal-dns api",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := viper.BindPFlags(cmd.Flags()); err != nil {
+				return err
+			}
+			if err := loadConfig(cmd, cmd.Flags()); err != nil {
+				return err
+			}
+			return runWebhook()
+		},
+	}
+
+	webhookCmd.Flags().StringP("webhook-endpoint", "", "localhost:8888", "the host/ip to serve the webhook on, external-dns expects it on localhost:8888")
+	webhookCmd.Flags().StringP("api-url", "", "http://localhost:8080", "url of the metal-dns api")
+	webhookCmd.Flags().StringP("api-token", "", "", "jwt to authenticate at the metal-dns api")
+	webhookCmd.Flags().StringP("api-ca", "", "", "ca to verify the certificate of the metal-dns api")
+	webhookCmd.Flags().StringP("api-cert", "", "", "client certificate to authenticate at the metal-dns api instead of a token")
+	webhookCmd.Flags().StringP("api-key", "", "", "key of the client certificate")
+	webhookCmd.Flags().StringSliceP("domain-filter", "", nil, "limit the domains to these, defaults to all domains of the token")
+
+	return webhookCmd
+}
+
+func runWebhook() error {
+	var err error
+	logger, err = createLogger()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = logger.Sync()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	c := client.New(ctx, client.DialConfig{
+		BaseURL:   viper.GetString("api-url"),
+		Token:     viper.GetString("api-token"),
+		CA:        viper.GetString("api-ca"),
+		Cert:      viper.GetString("api-cert"),
+		Key:       viper.GetString("api-key"),
+		UserAgent: moduleName + "-webhook",
+		Log:       logger,
+	})
+	w := webhook.New(logger, c, viper.GetStringSlice("domain-filter"))
+
+	server := &http.Server{
+		Addr:              viper.GetString("webhook-endpoint"),
+		Handler:           w.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Infow("serving external-dns webhook", "endpoint", server.Addr, "api", viper.GetString("api-url"))
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("unable to serve webhook %w", err)
+	}
+	return nil
+}